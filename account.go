@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Статусы учётной записи
+const (
+	statusActive      = string(oas.AccountStatusActive)
+	statusSuspended   = string(oas.AccountStatusSuspended)
+	statusDeactivated = string(oas.AccountStatusDeactivated)
+)
+
+// Статус и версия токенов пользователя. Версия увеличивается при блокировке
+// и увольнении, что отзывает все ранее выданные токены.
+type userState struct {
+	Status       string
+	TokenVersion int
+	expires      time.Time
+}
+
+// Кэш состояний пользователей, чтобы не обращаться к БД на каждый запрос.
+// Изменения на этом экземпляре видны сразу, на остальных – через userStateTTL.
+var (
+	userStates   sync.Map
+	userStateTTL = 5 * time.Second
+)
+
+// Возвращает состояние пользователя из кэша или БД; ok=false, если пользователь удалён
+func currentUserState(ctx context.Context, userID int) (state userState, ok bool, err error) {
+	if cached, found := userStates.Load(userID); found {
+		if state := cached.(userState); time.Now().Before(state.expires) {
+			return state, true, nil
+		}
+	}
+
+	err = db.QueryRowContext(ctx, "SELECT status, token_version FROM users WHERE id = $1", userID).Scan(&state.Status, &state.TokenVersion)
+	if err == sql.ErrNoRows {
+		return userState{}, false, nil
+	}
+	if err != nil {
+		return userState{}, false, err
+	}
+	state.expires = time.Now().Add(userStateTTL)
+	userStates.Store(userID, state)
+	return state, true, nil
+}
+
+// Сбрасывает закэшированное состояние после его изменения
+func forgetUserState(userID int) {
+	userStates.Delete(userID)
+}
+
+// Проверяет, что учётная запись активна, а токен не отозван
+func checkUserState(ctx context.Context, claims *Claims) error {
+	state, ok, err := currentUserState(ctx, claims.UserID)
+	if err != nil {
+		return internalError("Database error", err)
+	}
+	if !ok || state.TokenVersion != claims.TokenVersion {
+		return unauthorized(codeInvalidToken, "Token has been revoked")
+	}
+	if state.Status != statusActive {
+		return unauthorized(codeAccountInactive, "Account is "+state.Status)
+	}
+	return nil
+}
+
+// Получатели остатка баланса при увольнении
+type offboardingConfig struct {
+	// Благотворительный счёт; без него передача на благотворительность недоступна
+	CharityAccount string
+	// Счёт казны; без него монеты списываются
+	TreasuryAccount string
+	// Способ передачи по умолчанию
+	DefaultDisposition string
+}
+
+var offboarding = offboardingConfig{DefaultDisposition: string(oas.BalanceDispositionTreasury)}
+
+// Читает CHARITY_ACCOUNT, TREASURY_ACCOUNT, OFFBOARDING_DISPOSITION,
+// USER_STATE_CACHE_TTL и PASSWORD_RESET_TTL
+func initAccounts() error {
+	var env envReader
+	config := offboardingConfig{
+		CharityAccount:     normalizeUsername(envString("CHARITY_ACCOUNT", "")),
+		TreasuryAccount:    normalizeUsername(envString("TREASURY_ACCOUNT", "")),
+		DefaultDisposition: envString("OFFBOARDING_DISPOSITION", string(oas.BalanceDispositionTreasury)),
+	}
+	if err := oas.BalanceDisposition(config.DefaultDisposition).Validate(); err != nil {
+		return fmt.Errorf("invalid offboarding disposition %q", config.DefaultDisposition)
+	}
+	if config.DefaultDisposition == string(oas.BalanceDispositionCharity) && config.CharityAccount == "" {
+		return fmt.Errorf("OFFBOARDING_DISPOSITION=charity requires CHARITY_ACCOUNT")
+	}
+	stateTTL := env.Duration("USER_STATE_CACHE_TTL", userStateTTL)
+	resetTTL := env.Duration("PASSWORD_RESET_TTL", passwordResetTTL)
+	if err := env.Err(); err != nil {
+		return err
+	}
+	offboarding = config
+	userStateTTL, passwordResetTTL = stateTTL, resetTTL
+	return nil
+}
+
+// Счёт, на который передаётся остаток; пустая строка – монеты списываются
+func (c offboardingConfig) recipient(disposition string) (string, error) {
+	if disposition == string(oas.BalanceDispositionCharity) {
+		if c.CharityAccount == "" {
+			return "", badRequest(codeDispositionUnavailable, "Charity account is not configured")
+		}
+		return c.CharityAccount, nil
+	}
+	return c.TreasuryAccount, nil
+}
+
+// Меняет статус пользователя. Блокировка и деактивация отзывают выданные токены.
+func setUserStatus(ctx context.Context, username, status string) (int, error) {
+	var userID int
+	err := db.QueryRowContext(ctx, `
+        UPDATE users
+        SET status = $2,
+            token_version = token_version + CASE WHEN $2 = 'active' THEN 0 ELSE 1 END
+        WHERE lower(username) = $1
+        RETURNING id
+    `, username, status).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, notFound(codeUserNotFound, "User not found")
+	}
+	if err != nil {
+		return 0, internalError("Failed to update status", err)
+	}
+	forgetUserState(userID)
+	return userID, nil
+}
+
+// Результат увольнения
+type offboardResult struct {
+	UserID      int
+	Amount      int
+	RecipientID int
+	Recipient   string
+}
+
+// Деактивирует пользователя, отзывает его токены, отменяет его ожидающие
+// переводы, приостанавливает запланированные и передаёт остаток баланса
+// получателю из конфигурации одной транзакцией
+func offboardUser(ctx context.Context, username, disposition string) (*offboardResult, error) {
+	recipient, err := offboarding.recipient(disposition)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Ожидающие переводы пользователя блокируются раньше строк пользователей,
+	// в том же порядке, что и при их завершении
+	var pending []int
+	rows, err := tx.QueryContext(ctx, `
+        SELECT p.id FROM pending_transfers p
+        JOIN users u ON u.id IN (p.sender_id, p.receiver_id)
+        WHERE lower(u.username) = $1 AND p.status IN `+openTransferStatuses+`
+        ORDER BY p.id
+        FOR UPDATE OF p
+    `, username)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, internalError("Database error", err)
+		}
+		pending = append(pending, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, internalError("Database error", err)
+	}
+
+	result := &offboardResult{Recipient: recipient}
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT id, status FROM users WHERE lower(username) = $1 FOR UPDATE", username).
+		Scan(&result.UserID, &status)
+	if err == sql.ErrNoRows {
+		return nil, notFound(codeUserNotFound, "User not found")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if status == statusDeactivated {
+		return nil, conflict(codeAlreadyDeactivated, "Account is already deactivated")
+	}
+
+	// Ожидающие переводы отменяются: исходящие возвращаются на баланс
+	// пользователя и уходят вместе с ним, входящие – отправителям
+	var refunded []int
+	for _, id := range pending {
+		t, err := lockPendingTransfer(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.refund(ctx, tx, oas.TransferStatusCancelled); err != nil {
+			return nil, err
+		}
+		refunded = append(refunded, t.SenderID)
+	}
+	// Запланированные переводы от пользователя и ему приостанавливаются
+	_, err = tx.ExecContext(ctx, `
+        UPDATE scheduled_transfers SET status = 'paused'
+        WHERE (sender_id = $1 OR receiver_id = $1) AND status = 'active'
+    `, result.UserID)
+	if err != nil {
+		return nil, internalError("Failed to pause scheduled transfers", err)
+	}
+	if err := tx.QueryRowContext(ctx, "SELECT coins FROM users WHERE id = $1", result.UserID).Scan(&result.Amount); err != nil {
+		return nil, internalError("Database error", err)
+	}
+
+	if recipient != "" {
+		var recipientStatus string
+		err = tx.QueryRowContext(ctx, "SELECT id, status FROM users WHERE lower(username) = $1", recipient).Scan(&result.RecipientID, &recipientStatus)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, internalError("Database error", err)
+		}
+		if err == sql.ErrNoRows || recipientStatus == statusDeactivated {
+			return nil, internalError("Offboarding recipient is unavailable", fmt.Errorf("account %q not found or deactivated", recipient))
+		}
+		if result.RecipientID == result.UserID {
+			return nil, badRequest(codeDispositionUnavailable, "Cannot offboard the "+disposition+" account")
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE users SET status = 'deactivated', token_version = token_version + 1, coins = 0
+        WHERE id = $1
+    `, result.UserID)
+	if err != nil {
+		return nil, internalError("Failed to deactivate user", err)
+	}
+	// Партии переходят получателю с исходными сроками или списываются вместе с балансом
+	portions, err := takeLots(ctx, tx, result.UserID, result.Amount, max(result.Amount, 0))
+	if err != nil {
+		return nil, internalError("Failed to deactivate user", err)
+	}
+
+	var recipientID sql.NullInt64
+	if result.RecipientID != 0 && result.Amount > 0 {
+		recipientID = sql.NullInt64{Int64: int64(result.RecipientID), Valid: true}
+		if err = credit(ctx, tx, result.RecipientID, portions); err != nil {
+			return nil, err
+		}
+		// Передача видна в истории переводов обеих сторон
+		_, err = tx.ExecContext(ctx, "INSERT INTO transactions (sender_id, receiver_id, amount) VALUES ($1, $2, $3)", result.UserID, result.RecipientID, result.Amount)
+		if err != nil {
+			return nil, internalError("Failed to record transaction", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO offboardings (user_id, disposition, amount, recipient_id) VALUES ($1, $2, $3, $4)",
+		result.UserID, disposition, result.Amount, recipientID)
+	if err != nil {
+		return nil, internalError("Failed to record offboarding", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+	forgetUserState(result.UserID)
+	invalidated := append(refunded, result.UserID)
+	if recipientID.Valid {
+		invalidated = append(invalidated, result.RecipientID)
+	}
+	userInfoCache.Invalidate(ctx, invalidated...)
+	requestLogger(ctx).Info("User offboarded",
+		zap.Int("offboarded_user_id", result.UserID),
+		zap.String("disposition", disposition),
+		zap.Int("amount", result.Amount),
+	)
+	return result, nil
+}
+
+func (apiHandler) APIAdminUsersUsernameStatusPut(ctx context.Context, req *oas.UserStatusRequest, params oas.APIAdminUsersUsernameStatusPutParams) (oas.APIAdminUsersUsernameStatusPutRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	username := normalizeUsername(params.Username)
+	if _, err := setUserStatus(ctx, username, string(req.Status)); err != nil {
+		return nil, err
+	}
+	return &oas.UserStatusResponse{Username: username, Status: req.Status}, nil
+}
+
+func (apiHandler) APIAdminUsersUsernameOffboardPost(ctx context.Context, req oas.OptOffboardRequest, params oas.APIAdminUsersUsernameOffboardPostParams) (oas.APIAdminUsersUsernameOffboardPostRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	username := normalizeUsername(params.Username)
+	disposition := offboarding.DefaultDisposition
+	if d, ok := req.Value.Disposition.Get(); req.Set && ok {
+		disposition = string(d)
+	}
+
+	result, err := offboardUser(ctx, username, disposition)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &oas.OffboardResponse{
+		Username:    username,
+		Status:      oas.AccountStatusDeactivated,
+		Disposition: oas.BalanceDisposition(disposition),
+		Amount:      result.Amount,
+	}
+	if result.RecipientID != 0 {
+		resp.Recipient = oas.NewOptString(result.Recipient)
+	}
+	return resp, nil
+}
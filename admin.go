@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Роли пользователей; администраторы назначаются вручную в БД
+const (
+	roleUser  = "user"
+	roleAdmin = "admin"
+)
+
+// Проверяет, что запрос выполняет администратор. Роль читается из БД, а не из
+// токена, чтобы её отзыв действовал сразу.
+func requireAdmin(ctx context.Context) error {
+	claims := claimsFromContext(ctx)
+
+	admin, err := isAdmin(ctx, claims.UserID)
+	if err != nil {
+		return internalError("Database error", err)
+	}
+	if !admin {
+		return forbidden(codeAdminRequired, "Admin role required")
+	}
+	if mfa.RequireForAdmins && !claims.MFA {
+		return forbidden(codeMfaRequired, "Two-factor authentication is required for admin operations")
+	}
+	return nil
+}
+
+func isAdmin(ctx context.Context, userID int) (bool, error) {
+	var role string
+	err := db.QueryRowContext(ctx, "SELECT role FROM users WHERE id = $1", userID).Scan(&role)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	return role == roleAdmin, nil
+}
+
+func (apiHandler) APIAdminImportUsersPost(ctx context.Context, req oas.APIAdminImportUsersPostReq, params oas.APIAdminImportUsersPostParams) (oas.APIAdminImportUsersPostRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var roster []employee
+	switch req := req.(type) {
+	case *oas.APIAdminImportUsersPostReqApplicationJSON:
+		roster = employeesFromRecords(*req)
+	case *oas.APIAdminImportUsersPostReqTextCsv:
+		var err error
+		if roster, err = parseEmployeesCSV(req.Data); err != nil {
+			return nil, importError(err)
+		}
+	}
+
+	apply := params.Apply.Or(false)
+	plan, err := importEmployees(ctx, roster, apply)
+	if err != nil {
+		return nil, importError(err)
+	}
+	if apply {
+		requestLogger(ctx).Info("Employee directory imported",
+			zap.Int("created", len(plan.Create)),
+			zap.Int("updated", len(plan.Update)),
+			zap.Int("departed", len(plan.Depart)),
+		)
+	}
+	return plan.response(apply), nil
+}
+
+func importError(err error) *apiError {
+	var rosterErr *rosterError
+	if errors.As(err, &rosterErr) {
+		return badRequest(codeInvalidRoster, rosterErr.msg)
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return requestError(err)
+	}
+	return internalError("Failed to import users", err)
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// API-ключи отличаются от JWT префиксом и передаются так же, в Authorization
+const apiKeyPrefix = "ask_"
+
+// Длина начала ключа, которое хранится открыто для опознания ключа в списке
+const apiKeyDisplayLen = len(apiKeyPrefix) + 8
+
+// Разрешение, которое требуется API-ключу для операции. Операции, которых
+// нет в таблице, с API-ключом недоступны.
+var operationScopes = map[oas.OperationName]oas.ApiKeyScope{
+	oas.APIInfoGetOperation:           oas.ApiKeyScopeReadInfo,
+	oas.APISendCoinPostOperation:      oas.ApiKeyScopeGrantCoins,
+	oas.APISendCoinBatchPostOperation: oas.ApiKeyScopeGrantCoins,
+	oas.APIBuyItemGetOperation:        oas.ApiKeyScopeBuyMerch,
+}
+
+func isAPIKey(token string) bool {
+	return strings.HasPrefix(token, apiKeyPrefix)
+}
+
+func newAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Проверенный API-ключ
+type apiKeyEntry struct {
+	ID        int
+	UserID    int
+	Scopes    []string
+	ExpiresAt sql.NullTime
+	expires   time.Time
+}
+
+// Кэш проверенных ключей по хэшу. Отзыв на этом экземпляре действует сразу,
+// на остальных – через userStateTTL; с тем же шагом обновляется last_used_at.
+var apiKeyCache sync.Map
+
+// Находит действующий ключ и отмечает его использование
+func lookupAPIKey(ctx context.Context, hash string) (*apiKeyEntry, error) {
+	if cached, found := apiKeyCache.Load(hash); found {
+		if entry := cached.(*apiKeyEntry); time.Now().Before(entry.expires) {
+			return entry, nil
+		}
+	}
+
+	entry := &apiKeyEntry{}
+	err := db.QueryRowContext(ctx, `
+        UPDATE api_keys SET last_used_at = NOW()
+        WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+        RETURNING id, user_id, scopes, expires_at
+    `, hash).Scan(&entry.ID, &entry.UserID, pq.Array(&entry.Scopes), &entry.ExpiresAt)
+	if err == sql.ErrNoRows {
+		apiKeyCache.Delete(hash)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry.expires = time.Now().Add(userStateTTL)
+	apiKeyCache.Store(hash, entry)
+	return entry, nil
+}
+
+// Проверяет API-ключ, статус сервисного аккаунта и разрешение на операцию
+func validateAPIKey(ctx context.Context, key string, operation oas.OperationName) (*Claims, error) {
+	entry, err := lookupAPIKey(ctx, hashSecret(key))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if entry == nil || entry.ExpiresAt.Valid && time.Now().After(entry.ExpiresAt.Time) {
+		return nil, unauthorized(codeInvalidToken, "Invalid API key")
+	}
+
+	state, ok, err := currentUserState(ctx, entry.UserID)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if !ok || state.Status != statusActive {
+		return nil, unauthorized(codeAccountInactive, "Account is not active")
+	}
+
+	scope, ok := operationScopes[operation]
+	if !ok || !slices.Contains(entry.Scopes, string(scope)) {
+		return nil, forbidden(codeInsufficientScope, "API key does not permit this operation")
+	}
+	return &Claims{UserID: entry.UserID, APIKeyID: entry.ID, Scopes: entry.Scopes}, nil
+}
+
+// ID ключа для лимита частоты запросов; только для уже проверенных ключей,
+// чтобы произвольные строки не получали собственный лимит
+func cachedAPIKeyID(key string) (int, bool) {
+	if cached, found := apiKeyCache.Load(hashSecret(key)); found {
+		return cached.(*apiKeyEntry).ID, true
+	}
+	return 0, false
+}
+
+// Находит сервисный аккаунт по имени
+func serviceAccountID(ctx context.Context, username string) (int, error) {
+	var userID int
+	err := db.QueryRowContext(ctx, "SELECT id FROM users WHERE lower(username) = $1 AND kind = 'service'", normalizeUsername(username)).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, notFound(codeUserNotFound, "Service account not found")
+	}
+	if err != nil {
+		return 0, internalError("Database error", err)
+	}
+	return userID, nil
+}
+
+// Строка api_keys в ответе
+type apiKeyRow struct {
+	ID         int
+	Name       string
+	Prefix     string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+	RevokedAt  sql.NullTime
+}
+
+const apiKeyColumns = "id, name, prefix, scopes, created_at, expires_at, last_used_at, revoked_at"
+
+func (r *apiKeyRow) scanArgs() []any {
+	return []any{&r.ID, &r.Name, &r.Prefix, pq.Array(&r.Scopes), &r.CreatedAt, &r.ExpiresAt, &r.LastUsedAt, &r.RevokedAt}
+}
+
+func (r *apiKeyRow) response() oas.ApiKey {
+	key := oas.ApiKey{ID: r.ID, Name: r.Name, Prefix: r.Prefix, CreatedAt: r.CreatedAt}
+	key.Scopes = make([]oas.ApiKeyScope, len(r.Scopes))
+	for i, scope := range r.Scopes {
+		key.Scopes[i] = oas.ApiKeyScope(scope)
+	}
+	if r.ExpiresAt.Valid {
+		key.ExpiresAt = oas.NewOptDateTime(r.ExpiresAt.Time)
+	}
+	if r.LastUsedAt.Valid {
+		key.LastUsedAt = oas.NewOptDateTime(r.LastUsedAt.Time)
+	}
+	if r.RevokedAt.Valid {
+		key.RevokedAt = oas.NewOptDateTime(r.RevokedAt.Time)
+	}
+	return key
+}
+
+func (apiHandler) APIAdminServiceAccountsPost(ctx context.Context, req *oas.ServiceAccountRequest) (oas.APIAdminServiceAccountsPostRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	username := normalizeUsername(req.Username)
+	coins := req.Coins.Or(0)
+
+	// Пароль не задаётся: сервисный аккаунт работает только по API-ключам
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	ids, err := insertAccounts(ctx, tx, []newAccount{{
+		Username:    username,
+		Coins:       coins,
+		Service:     true,
+		DisplayName: req.DisplayName.Or(""),
+	}})
+	if err != nil {
+		return nil, internalError("Failed to create service account", err)
+	}
+	userID := ids[0]
+	if userID == 0 {
+		return nil, conflict(codeUsernameTaken, "Username already taken")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+	requestLogger(ctx).Info("Service account created", zap.Int("service_account_id", userID), zap.Int("coins", coins))
+
+	return &oas.ServiceAccountResponse{Username: username, Coins: coins}, nil
+}
+
+func (apiHandler) APIAdminServiceAccountsUsernameKeysPost(ctx context.Context, req *oas.ApiKeyRequest, params oas.APIAdminServiceAccountsUsernameKeysPostParams) (oas.APIAdminServiceAccountsUsernameKeysPostRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	userID, err := serviceAccountID(ctx, params.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt sql.NullTime
+	if t, ok := req.ExpiresAt.Get(); ok {
+		if !t.After(time.Now()) {
+			return nil, badRequest(codeValidationFailed, "expiresAt must be in the future")
+		}
+		expiresAt = sql.NullTime{Time: t, Valid: true}
+	}
+	scopes := make([]string, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	key, err := newAPIKey()
+	if err != nil {
+		return nil, internalError("Failed to generate API key", err)
+	}
+	row := apiKeyRow{}
+	err = db.QueryRowContext(ctx, `
+        INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING `+apiKeyColumns,
+		userID, req.Name, key[:apiKeyDisplayLen], hashSecret(key), pq.Array(scopes), expiresAt).Scan(row.scanArgs()...)
+	if err != nil {
+		return nil, internalError("Failed to store API key", err)
+	}
+	requestLogger(ctx).Info("API key issued", zap.Int("service_account_id", userID), zap.Int("api_key_id", row.ID), zap.Strings("scopes", scopes))
+
+	return &oas.ApiKeyCreatedResponse{Key: key, ApiKey: row.response()}, nil
+}
+
+func (apiHandler) APIAdminServiceAccountsUsernameKeysGet(ctx context.Context, params oas.APIAdminServiceAccountsUsernameKeysGetParams) (oas.APIAdminServiceAccountsUsernameKeysGetRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	userID, err := serviceAccountID(ctx, params.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE user_id = $1 ORDER BY id", userID)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	defer rows.Close()
+
+	resp := &oas.ApiKeyList{Keys: []oas.ApiKey{}}
+	for rows.Next() {
+		var row apiKeyRow
+		if err := rows.Scan(row.scanArgs()...); err != nil {
+			return nil, internalError("Database error", err)
+		}
+		resp.Keys = append(resp.Keys, row.response())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, internalError("Database error", err)
+	}
+	return resp, nil
+}
+
+func (apiHandler) APIAdminServiceAccountsUsernameKeysKeyIdDelete(ctx context.Context, params oas.APIAdminServiceAccountsUsernameKeysKeyIdDeleteParams) (oas.APIAdminServiceAccountsUsernameKeysKeyIdDeleteRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	userID, err := serviceAccountID(ctx, params.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	row := apiKeyRow{}
+	var hash string
+	err = db.QueryRowContext(ctx, `
+        UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW())
+        WHERE id = $1 AND user_id = $2
+        RETURNING key_hash, `+apiKeyColumns,
+		params.KeyId, userID).Scan(append([]any{&hash}, row.scanArgs()...)...)
+	if err == sql.ErrNoRows {
+		return nil, notFound(codeAPIKeyNotFound, "API key not found")
+	}
+	if err != nil {
+		return nil, internalError("Failed to revoke API key", err)
+	}
+	apiKeyCache.Delete(hash)
+	requestLogger(ctx).Info("API key revoked", zap.Int("service_account_id", userID), zap.Int("api_key_id", row.ID))
+
+	resp := row.response()
+	return &resp, nil
+}
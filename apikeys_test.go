@@ -0,0 +1,59 @@
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Подставляет проверенный ключ в кэш, чтобы проверка обходилась без БД
+func stubAPIKey(t testing.TB, entry apiKeyEntry) string {
+	key, err := newAPIKey()
+	if err != nil {
+		t.Fatalf("Не удалось создать ключ: %v", err)
+	}
+	entry.expires = time.Now().Add(time.Hour)
+	hash := hashSecret(key)
+	apiKeyCache.Store(hash, &entry)
+	t.Cleanup(func() { apiKeyCache.Delete(hash) })
+	return key
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, err := newAPIKey()
+	assert.NoError(t, err)
+	assert.True(t, isAPIKey(key))
+	assert.Len(t, key, len(apiKeyPrefix)+43, "32 байта в base64url после префикса")
+	assert.False(t, isAPIKey(signTestToken(t, 1)), "JWT не должен приниматься за API-ключ")
+}
+
+func TestAPIKey_Scopes(t *testing.T) {
+	stubUserState(t, 7, userState{Status: statusActive})
+	key := stubAPIKey(t, apiKeyEntry{ID: 1, UserID: 7, Scopes: []string{"read:info"}})
+
+	code, errResponse := performRawRequest(t, "POST", "/api/sendCoin", "Bearer "+key, `{"toUser":"user2","amount":1}`)
+	assert.Equal(t, http.StatusForbidden, code, "Операция вне разрешений ключа")
+	assert.Equal(t, codeInsufficientScope, errResponse.Code.Value)
+
+	code, errResponse = performRawRequest(t, "POST", "/api/password", key, `{"oldPassword":"a","newPassword":"b"}`)
+	assert.Equal(t, http.StatusForbidden, code, "Операции без разрешения в таблице недоступны с ключом")
+	assert.Equal(t, codeInsufficientScope, errResponse.Code.Value)
+}
+
+func TestAPIKey_Rejected(t *testing.T) {
+	stubUserState(t, 8, userState{Status: statusSuspended})
+	suspended := stubAPIKey(t, apiKeyEntry{ID: 2, UserID: 8, Scopes: []string{"grant:coins"}})
+	code, errResponse := performRawRequest(t, "POST", "/api/sendCoin", suspended, `{"toUser":"user2","amount":1}`)
+	assert.Equal(t, http.StatusUnauthorized, code, "Ключ заблокированного аккаунта не должен работать")
+	assert.Equal(t, codeAccountInactive, errResponse.Code.Value)
+
+	stubUserState(t, 9, userState{Status: statusActive})
+	expired := stubAPIKey(t, apiKeyEntry{ID: 3, UserID: 9, Scopes: []string{"grant:coins"},
+		ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Second), Valid: true}})
+	code, errResponse = performRawRequest(t, "POST", "/api/sendCoin", expired, `{"toUser":"user2","amount":1}`)
+	assert.Equal(t, http.StatusUnauthorized, code, "Просроченный ключ не должен работать даже из кэша")
+	assert.Equal(t, codeInvalidToken, errResponse.Code.Value)
+}
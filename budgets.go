@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Бюджет на благодарности: монеты, которые руководитель может только
+// переводить другим. Бюджет начисляется на календарный период и сгорает в
+// его конце. Начисление происходит при первом обращении к бюджету в периоде
+// и не меняется до конца периода, даже если меняется команда.
+type budgetPolicy struct {
+	// Период: week, month, quarter или year; единица date_trunc в Postgres
+	Period string
+	// Начисление руководителю с подчинёнными и за каждого активного подчинённого
+	Base      int
+	PerReport int
+}
+
+var givingBudgets = budgetPolicy{Period: "month"}
+
+// Читает GIVING_BUDGET_PERIOD, GIVING_BUDGET_BASE и GIVING_BUDGET_PER_REPORT
+func initGivingBudgets() error {
+	var env envReader
+	policy := budgetPolicy{
+		Period:    envString("GIVING_BUDGET_PERIOD", givingBudgets.Period),
+		Base:      env.Int("GIVING_BUDGET_BASE", 0),
+		PerReport: env.Int("GIVING_BUDGET_PER_REPORT", 0),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	switch policy.Period {
+	case "week", "month", "quarter", "year":
+	default:
+		return fmt.Errorf("unknown giving budget period %q", policy.Period)
+	}
+	if policy.Base < 0 || policy.PerReport < 0 {
+		return fmt.Errorf("giving budget must not be negative")
+	}
+	givingBudgets = policy
+	return nil
+}
+
+func (p budgetPolicy) enabled() bool {
+	return p.Base > 0 || p.PerReport > 0
+}
+
+// Конец периода, начавшегося в start
+func (p budgetPolicy) periodEnd(start time.Time) time.Time {
+	switch p.Period {
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "quarter":
+		return start.AddDate(0, 3, 0)
+	case "year":
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// Выполняет запрос вне транзакции или в ней
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Начисляет бюджет текущего периода, если он ещё не начислен. Пользователь
+// без активных подчинённых бюджета не получает.
+func (p budgetPolicy) refresh(ctx context.Context, q execer, userID int) error {
+	if !p.enabled() {
+		return nil
+	}
+	_, err := q.ExecContext(ctx, `
+        INSERT INTO giving_budgets (user_id, period_start, granted, remaining)
+        SELECT $1, date_trunc($2, LOCALTIMESTAMP), a.amount, a.amount
+        FROM (
+            SELECT $3 + $4 * COUNT(*) AS amount, COUNT(*) AS reports
+            FROM users WHERE manager_id = $1 AND status = 'active'
+        ) a
+        WHERE a.reports > 0
+        ON CONFLICT (user_id, period_start) DO NOTHING
+    `, userID, p.Period, p.Base, p.PerReport)
+	return err
+}
+
+// Начисляет и блокирует бюджет текущего периода; возвращает остаток
+func (p budgetPolicy) lock(ctx context.Context, tx *sql.Tx, userID int) (int, error) {
+	if err := p.refresh(ctx, tx, userID); err != nil {
+		return 0, err
+	}
+	var remaining int
+	err := tx.QueryRowContext(ctx, `
+        SELECT remaining FROM giving_budgets
+        WHERE user_id = $1 AND period_start = date_trunc($2, LOCALTIMESTAMP)
+        FOR UPDATE
+    `, userID, p.Period).Scan(&remaining)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return remaining, err
+}
+
+// Списывает монеты с заблокированного бюджета текущего периода
+func (p budgetPolicy) spend(ctx context.Context, tx *sql.Tx, userID, amount int) error {
+	_, err := tx.ExecContext(ctx, `
+        UPDATE giving_budgets SET remaining = remaining - $3
+        WHERE user_id = $1 AND period_start = date_trunc($2, LOCALTIMESTAMP)
+    `, userID, p.Period, amount)
+	return err
+}
+
+// Возвращает монеты в бюджет периода, из которого они были потрачены в
+// spentAt. Если этот период закончился, монеты сгорают вместе с его остатком.
+func (p budgetPolicy) restore(ctx context.Context, tx *sql.Tx, userID, amount int, spentAt time.Time) error {
+	if amount == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+        UPDATE giving_budgets SET remaining = remaining + $3
+        WHERE user_id = $1 AND period_start = date_trunc($2, $4::timestamp)
+    `, userID, p.Period, amount, spentAt)
+	return err
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitGivingBudgets(t *testing.T) {
+	defer func(saved budgetPolicy) { givingBudgets = saved }(givingBudgets)
+
+	assert.NoError(t, initGivingBudgets())
+	assert.False(t, givingBudgets.enabled(), "По умолчанию бюджет не начисляется")
+
+	t.Setenv("GIVING_BUDGET_PERIOD", "quarter")
+	t.Setenv("GIVING_BUDGET_PER_REPORT", "100")
+	assert.NoError(t, initGivingBudgets())
+	assert.True(t, givingBudgets.enabled())
+	assert.Equal(t, "quarter", givingBudgets.Period)
+
+	t.Setenv("GIVING_BUDGET_PERIOD", "day; DROP TABLE users")
+	assert.Error(t, initGivingBudgets(), "Период передаётся в date_trunc и должен быть из списка")
+}
+
+func TestBudgetPolicy_PeriodEnd(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), budgetPolicy{Period: "month"}.periodEnd(start))
+	assert.Equal(t, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), budgetPolicy{Period: "quarter"}.periodEnd(start))
+	assert.Equal(t, time.Date(2025, time.January, 8, 0, 0, 0, 0, time.UTC), budgetPolicy{Period: "week"}.periodEnd(start))
+	assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), budgetPolicy{Period: "year"}.periodEnd(start))
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Read-through кэш ответов /api/info в Redis.
+//
+// Для каждого пользователя хранится счётчик поколений; запись в кэше
+// помечается поколением, прочитанным до запроса в БД. Инвалидация
+// увеличивает счётчик, поэтому данные, загруженные до коммита и записанные
+// после инвалидации, никогда не будут прочитаны.
+type infoCache struct {
+	// nil – кэш выключен, все запросы идут в БД
+	client *redis.Client
+	ttl    time.Duration
+	// Защита от одновременной загрузки одного ключа в пределах процесса
+	group singleflight.Group
+}
+
+var userInfoCache = &infoCache{}
+
+// Включает кэш, если настроен Redis и он не выключен через INFO_CACHE_ENABLED=false
+func initInfoCache(client *redis.Client) error {
+	if client == nil || envString("INFO_CACHE_ENABLED", "true") != "true" {
+		userInfoCache = &infoCache{}
+		return nil
+	}
+	var env envReader
+	ttl := env.Duration("INFO_CACHE_TTL", 30*time.Second)
+	if err := env.Err(); err != nil {
+		return err
+	}
+	userInfoCache = &infoCache{client: client, ttl: ttl}
+	return nil
+}
+
+func infoCacheKey(userID int) string {
+	return "info:" + strconv.Itoa(userID)
+}
+
+func infoCacheGenKey(userID int) string {
+	return "info:gen:" + strconv.Itoa(userID)
+}
+
+// Возвращает данные из кэша или загружает их из БД функцией load
+func (c *infoCache) GetOrLoad(ctx context.Context, userID int, load func(context.Context) (*oas.InfoResponse, error)) (*oas.InfoResponse, error) {
+	if c.client == nil {
+		return load(ctx)
+	}
+
+	info, gen, err := c.get(ctx, userID)
+	if err != nil {
+		// Redis недоступен – работаем напрямую с БД
+		infoCacheRequests.WithLabelValues("error").Inc()
+		requestLogger(ctx).Warn("Info cache unavailable", zap.Error(err))
+		return load(ctx)
+	}
+	if info != nil {
+		infoCacheRequests.WithLabelValues("hit").Inc()
+		return info, nil
+	}
+	infoCacheRequests.WithLabelValues("miss").Inc()
+
+	// Загрузку ждут все одновременные запросы, поэтому отмена запроса,
+	// начавшего её, не должна прерывать загрузку для остальных
+	loadCtx := context.WithoutCancel(ctx)
+	// Загрузка объединяется только в пределах поколения: запрос, пришедший
+	// после инвалидации, не присоединяется к загрузке, начатой до коммита
+	flight := strconv.Itoa(userID) + ":" + strconv.FormatInt(gen, 10)
+	v, err, _ := c.group.Do(flight, func() (interface{}, error) {
+		ctx := loadCtx
+		info, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.set(ctx, userID, gen, info); err != nil {
+			requestLogger(ctx).Warn("Failed to store info in cache", zap.Error(err))
+		}
+		return info, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*oas.InfoResponse), nil
+}
+
+// Читает поколение и запись одним запросом; запись другого поколения считается промахом
+func (c *infoCache) get(ctx context.Context, userID int) (*oas.InfoResponse, int64, error) {
+	ctx, span := tracer.Start(ctx, "infoCache.Get")
+	defer span.End()
+
+	values, err := c.client.MGet(ctx, infoCacheGenKey(userID), infoCacheKey(userID)).Result()
+	if err != nil {
+		span.RecordError(err)
+		return nil, 0, err
+	}
+
+	var gen int64
+	if s, ok := values[0].(string); ok {
+		if gen, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	s, ok := values[1].(string)
+	if !ok {
+		span.SetAttributes(attribute.Bool("cache.hit", false))
+		return nil, gen, nil
+	}
+	entryGen, payload, found := strings.Cut(s, "|")
+	if !found || entryGen != strconv.FormatInt(gen, 10) {
+		span.SetAttributes(attribute.Bool("cache.hit", false))
+		return nil, gen, nil
+	}
+
+	info := &oas.InfoResponse{}
+	if err := info.UnmarshalJSON([]byte(payload)); err != nil {
+		return nil, 0, err
+	}
+	span.SetAttributes(attribute.Bool("cache.hit", true))
+	return info, gen, nil
+}
+
+// Запись живёт не дольше TTL и не дольше момента, когда ответ устаревает
+// без изменений в БД: конца периода бюджета или ближайшего сгорания монет.
+// Данные, загруженные до инвалидации, не сохраняются.
+func (c *infoCache) set(ctx context.Context, userID int, gen int64, info *oas.InfoResponse) error {
+	ctx, span := tracer.Start(ctx, "infoCache.Set")
+	defer span.End()
+
+	ttl := c.ttl
+	if until := infoExpiresAt(info); !until.IsZero() {
+		ttl = min(ttl, time.Until(until))
+	}
+	if ttl <= 0 {
+		return nil
+	}
+
+	payload, err := info.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	// Запись сохраняется, только если поколение не изменилось с начала
+	// загрузки; инвалидация между чтением и записью отменяет транзакцию
+	genKey := infoCacheGenKey(userID)
+	err = c.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, genKey).Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if current != gen {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, infoCacheKey(userID), strconv.FormatInt(gen, 10)+"|"+string(payload), ttl)
+			return nil
+		})
+		return err
+	}, genKey)
+	if errors.Is(err, redis.TxFailedErr) {
+		return nil
+	}
+	return err
+}
+
+// Ближайший момент, когда закончится период бюджета или сгорят монеты;
+// нулевое время – ответ не устаревает сам по себе
+func infoExpiresAt(info *oas.InfoResponse) time.Time {
+	var until time.Time
+	earlier := func(t time.Time) {
+		if !t.IsZero() && (until.IsZero() || t.Before(until)) {
+			until = t
+		}
+	}
+	if budget, ok := info.GivingBudget.Get(); ok {
+		earlier(budget.ExpiresAt)
+	}
+	for _, item := range info.ExpiringCoins {
+		earlier(item.ExpiresAt)
+	}
+	return until
+}
+
+// Сбрасывает кэш пользователей; вызывается после коммита операций с их балансом
+func (c *infoCache) Invalidate(ctx context.Context, userIDs ...int) {
+	if c.client == nil {
+		return
+	}
+	ctx, span := tracer.Start(ctx, "infoCache.Invalidate")
+	defer span.End()
+
+	pipe := c.client.TxPipeline()
+	for _, userID := range userIDs {
+		pipe.Incr(ctx, infoCacheGenKey(userID))
+		pipe.Del(ctx, infoCacheKey(userID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		// Устаревшие данные доживут не дольше TTL
+		span.RecordError(err)
+		requestLogger(ctx).Warn("Failed to invalidate info cache", zap.Error(err))
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+func newTestInfoCache(t *testing.T) (*infoCache, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return &infoCache{client: client, ttl: time.Minute}, server
+}
+
+// Загрузчик, считающий обращения к «БД»
+func countingLoader(calls *int32, coins int) func(context.Context) (*oas.InfoResponse, error) {
+	return func(context.Context) (*oas.InfoResponse, error) {
+		atomic.AddInt32(calls, 1)
+		return &oas.InfoResponse{Coins: oas.NewOptInt(coins)}, nil
+	}
+}
+
+func TestInfoCache_ReadThrough(t *testing.T) {
+	cache, _ := newTestInfoCache(t)
+	ctx := context.Background()
+	var calls int32
+
+	info, err := cache.GetOrLoad(ctx, 1, countingLoader(&calls, 1000))
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, info.Coins.Value)
+
+	info, err = cache.GetOrLoad(ctx, 1, countingLoader(&calls, 900))
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, info.Coins.Value, "Второй запрос должен обслуживаться из кэша")
+	assert.EqualValues(t, 1, calls)
+
+	// После инвалидации данные загружаются заново
+	cache.Invalidate(ctx, 1)
+	info, err = cache.GetOrLoad(ctx, 1, countingLoader(&calls, 900))
+	assert.NoError(t, err)
+	assert.Equal(t, 900, info.Coins.Value)
+	assert.EqualValues(t, 2, calls)
+}
+
+func TestInfoCache_InvalidationDuringLoad(t *testing.T) {
+	cache, _ := newTestInfoCache(t)
+	ctx := context.Background()
+
+	// Перевод коммитится, пока первый запрос читает старый баланс из БД
+	_, err := cache.GetOrLoad(ctx, 1, func(ctx context.Context) (*oas.InfoResponse, error) {
+		cache.Invalidate(ctx, 1)
+		return &oas.InfoResponse{Coins: oas.NewOptInt(1000)}, nil
+	})
+	assert.NoError(t, err)
+
+	var calls int32
+	info, err := cache.GetOrLoad(ctx, 1, countingLoader(&calls, 900))
+	assert.NoError(t, err)
+	assert.Equal(t, 900, info.Coins.Value, "Устаревшие данные не должны читаться из кэша")
+	assert.EqualValues(t, 1, calls)
+}
+
+func TestInfoCache_StampedeProtection(t *testing.T) {
+	cache, _ := newTestInfoCache(t)
+	var calls int32
+	release := make(chan struct{})
+
+	load := func(context.Context) (*oas.InfoResponse, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &oas.InfoResponse{Coins: oas.NewOptInt(1000)}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, err := cache.GetOrLoad(context.Background(), 1, load)
+			assert.NoError(t, err)
+			assert.Equal(t, 1000, info.Coins.Value)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls, "Одновременные промахи должны приводить к одной загрузке")
+}
+
+func TestInfoCache_FallbackWhenRedisUnavailable(t *testing.T) {
+	cache, server := newTestInfoCache(t)
+	server.Close()
+	var calls int32
+
+	for i := 0; i < 2; i++ {
+		info, err := cache.GetOrLoad(context.Background(), 1, countingLoader(&calls, 1000))
+		assert.NoError(t, err, "Недоступность Redis не должна приводить к ошибке")
+		assert.Equal(t, 1000, info.Coins.Value)
+	}
+	assert.EqualValues(t, 2, calls, "Без Redis данные читаются из БД")
+
+	cache.Invalidate(context.Background(), 1)
+}
+
+func TestInfoCache_EntryExpiresWithBudgetAndCoins(t *testing.T) {
+	cache, server := newTestInfoCache(t)
+	ctx := context.Background()
+	var calls int32
+
+	load := func(context.Context) (*oas.InfoResponse, error) {
+		atomic.AddInt32(&calls, 1)
+		return &oas.InfoResponse{
+			Coins: oas.NewOptInt(1000),
+			GivingBudget: oas.NewOptInfoResponseGivingBudget(oas.InfoResponseGivingBudget{
+				Granted: 50, Remaining: 50, ExpiresAt: time.Now().Add(20 * time.Second),
+			}),
+			ExpiringCoins: []oas.InfoResponseExpiringCoinsItem{{Amount: 10, ExpiresAt: time.Now().Add(10 * time.Second)}},
+		}, nil
+	}
+
+	_, err := cache.GetOrLoad(ctx, 1, load)
+	assert.NoError(t, err)
+	assert.InDelta(t, 10*time.Second, server.TTL(infoCacheKey(1)), float64(time.Second), "Запись живёт до ближайшего сгорания монет")
+
+	server.FastForward(11 * time.Second)
+	_, err = cache.GetOrLoad(ctx, 1, load)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, calls, "После сгорания монет данные загружаются заново")
+}
+
+func TestInfoCache_LoadSurvivesCallerCancellation(t *testing.T) {
+	cache, _ := newTestInfoCache(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	started, release := make(chan struct{}), make(chan struct{})
+
+	load := func(ctx context.Context) (*oas.InfoResponse, error) {
+		close(started)
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return &oas.InfoResponse{Coins: oas.NewOptInt(1000)}, nil
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := cache.GetOrLoad(ctx, 1, load)
+		assert.NoError(t, err)
+	}()
+	<-started
+
+	// Запрос, начавший загрузку, отменяется, пока её ждёт второй запрос
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		info, err := cache.GetOrLoad(context.Background(), 1, load)
+		if assert.NoError(t, err, "Общая загрузка не должна прерываться отменой одного запроса") {
+			assert.Equal(t, 1000, info.Coins.Value)
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	close(release)
+	wg.Wait()
+}
+
+func TestInfoCache_NoJoinAcrossInvalidation(t *testing.T) {
+	cache, _ := newTestInfoCache(t)
+	ctx := context.Background()
+	started, release := make(chan struct{}), make(chan struct{})
+
+	// Загрузка начата до коммита перевода и вернёт старый баланс
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		info, err := cache.GetOrLoad(ctx, 1, func(context.Context) (*oas.InfoResponse, error) {
+			close(started)
+			<-release
+			return &oas.InfoResponse{Coins: oas.NewOptInt(1000)}, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, info.Coins.Value)
+	}()
+	<-started
+	cache.Invalidate(ctx, 1)
+
+	// Запрос после коммита читает свои изменения, а не ждёт старую загрузку
+	var calls int32
+	info, err := cache.GetOrLoad(ctx, 1, countingLoader(&calls, 900))
+	assert.NoError(t, err)
+	assert.Equal(t, 900, info.Coins.Value)
+	assert.EqualValues(t, 1, calls)
+
+	close(release)
+	<-done
+	info, err = cache.GetOrLoad(ctx, 1, countingLoader(&calls, 800))
+	assert.NoError(t, err)
+	assert.Equal(t, 900, info.Coins.Value, "Старая загрузка не должна перезаписать кэш")
+	assert.EqualValues(t, 1, calls)
+}
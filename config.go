@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Возвращает строковую переменную окружения или значение по умолчанию
+func envString(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// Читает числовые переменные окружения. Неверное значение не заменяется
+// молча значением по умолчанию: ошибки собираются, и init-функция
+// возвращает их все сразу через Err.
+type envReader struct {
+	errs []error
+}
+
+// Возвращает целочисленную переменную окружения или значение по умолчанию, если она не задана
+func (r *envReader) Int(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("invalid %s %q: expected an integer", key, v))
+		return def
+	}
+	return n
+}
+
+// Возвращает длительность из переменной окружения (формат time.ParseDuration)
+// или значение по умолчанию, если она не задана
+func (r *envReader) Duration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("invalid %s %q: expected a duration such as 30s or 5m", key, v))
+		return def
+	}
+	return d
+}
+
+// Ошибки разбора всех прочитанных переменных
+func (r *envReader) Err() error {
+	return errors.Join(r.errs...)
+}
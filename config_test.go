@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvReader(t *testing.T) {
+	var env envReader
+	assert.Equal(t, 7, env.Int("TEST_ENV_INT", 7), "Незаданная переменная – значение по умолчанию")
+	assert.Equal(t, time.Minute, env.Duration("TEST_ENV_DURATION", time.Minute))
+	assert.NoError(t, env.Err())
+
+	t.Setenv("TEST_ENV_INT", "42")
+	t.Setenv("TEST_ENV_DURATION", "90s")
+	assert.Equal(t, 42, env.Int("TEST_ENV_INT", 7))
+	assert.Equal(t, 90*time.Second, env.Duration("TEST_ENV_DURATION", time.Minute))
+	assert.NoError(t, env.Err())
+
+	t.Setenv("TEST_ENV_INT", "10k")
+	t.Setenv("TEST_ENV_DURATION", "5")
+	env.Int("TEST_ENV_INT", 7)
+	env.Duration("TEST_ENV_DURATION", time.Minute)
+	err := env.Err()
+	if assert.Error(t, err, "Неверное значение не должно молча заменяться значением по умолчанию") {
+		assert.Contains(t, err.Error(), "TEST_ENV_INT")
+		assert.Contains(t, err.Error(), "TEST_ENV_DURATION", "Сообщаются все неверные переменные")
+	}
+}
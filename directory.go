@@ -0,0 +1,496 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Запись справочника сотрудников из HR-системы. Имена пользователя
+// и руководителя нормализованы, дата найма в формате 2006-01-02 или пустая.
+type employee struct {
+	Username    string
+	DisplayName string
+	Department  string
+	Manager     string
+	HireDate    string
+}
+
+// Сотрудник, уже заведённый в БД
+type directoryEntry struct {
+	ID int
+	employee
+	Departed bool
+}
+
+// Изменения атрибутов существующего пользователя
+type employeeUpdate struct {
+	ID       int
+	Employee employee
+	Changes  []oas.FieldChange
+}
+
+// Дифф между справочником и БД
+type importPlan struct {
+	Create []employee
+	Update []employeeUpdate
+	Depart []directoryEntry
+}
+
+// Ошибка в содержимом справочника; возвращается клиенту как 400
+type rosterError struct {
+	msg string
+}
+
+func (e *rosterError) Error() string {
+	return e.msg
+}
+
+func invalidRoster(format string, args ...interface{}) error {
+	return &rosterError{msg: fmt.Sprintf(format, args...)}
+}
+
+var employeeUsernameRe = regexp.MustCompile(`^[a-z0-9_.-]{1,64}$`)
+
+// Колонки CSV; порядок задаётся заголовком, обязателен только username
+var csvColumns = []string{"username", "display_name", "department", "manager", "hire_date"}
+
+// Разбирает CSV со строкой заголовка
+func parseEmployeesCSV(r io.Reader) ([]employee, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, invalidRoster("roster is empty")
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, invalidRoster("invalid CSV: %v", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(csvColumns, name) {
+			return nil, invalidRoster("unknown CSV column %q", name)
+		}
+		index[name] = i
+	}
+	if _, ok := index["username"]; !ok {
+		return nil, invalidRoster("CSV header must contain username")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := index[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var employees []employee
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, invalidRoster("invalid CSV: %v", err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		employees = append(employees, employee{
+			Username:    field(record, "username"),
+			DisplayName: field(record, "display_name"),
+			Department:  field(record, "department"),
+			Manager:     field(record, "manager"),
+			HireDate:    field(record, "hire_date"),
+		})
+	}
+	return employees, nil
+}
+
+// Разбирает JSON-массив записей в формате EmployeeRecord из api/schema.json
+func parseEmployeesJSON(r io.Reader) ([]employee, error) {
+	var records []oas.EmployeeRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, invalidRoster("invalid JSON: %v", err)
+	}
+	return employeesFromRecords(records), nil
+}
+
+func employeesFromRecords(records []oas.EmployeeRecord) []employee {
+	employees := make([]employee, 0, len(records))
+	for _, record := range records {
+		e := employee{
+			Username:    record.Username,
+			DisplayName: record.DisplayName.Or(""),
+			Department:  record.Department.Or(""),
+			Manager:     record.Manager.Or(""),
+		}
+		if date, ok := record.HireDate.Get(); ok {
+			e.HireDate = date.Format(time.DateOnly)
+		}
+		employees = append(employees, e)
+	}
+	return employees
+}
+
+func (e employee) record() oas.EmployeeRecord {
+	record := oas.EmployeeRecord{Username: e.Username}
+	if e.DisplayName != "" {
+		record.DisplayName = oas.NewOptString(e.DisplayName)
+	}
+	if e.Department != "" {
+		record.Department = oas.NewOptString(e.Department)
+	}
+	if e.Manager != "" {
+		record.Manager = oas.NewOptString(e.Manager)
+	}
+	if date, err := time.Parse(time.DateOnly, e.HireDate); err == nil {
+		record.HireDate = oas.NewOptDate(date)
+	}
+	return record
+}
+
+// Нормализует и проверяет справочник целиком: имена уникальны,
+// руководители присутствуют в справочнике, даты корректны
+func normalizeEmployees(employees []employee) ([]employee, error) {
+	seen := make(map[string]struct{}, len(employees))
+	normalized := make([]employee, 0, len(employees))
+	for i, e := range employees {
+		line := i + 1
+		e.Username = normalizeUsername(e.Username)
+		e.Manager = normalizeUsername(e.Manager)
+		e.DisplayName = strings.TrimSpace(e.DisplayName)
+		e.Department = strings.TrimSpace(e.Department)
+		e.HireDate = strings.TrimSpace(e.HireDate)
+
+		if !employeeUsernameRe.MatchString(e.Username) {
+			return nil, invalidRoster("record %d: invalid username %q", line, e.Username)
+		}
+		if _, ok := seen[e.Username]; ok {
+			return nil, invalidRoster("record %d: duplicate username %q", line, e.Username)
+		}
+		seen[e.Username] = struct{}{}
+		if e.Manager == e.Username {
+			return nil, invalidRoster("record %d: %q cannot be their own manager", line, e.Username)
+		}
+		if e.HireDate != "" {
+			if _, err := time.Parse(time.DateOnly, e.HireDate); err != nil {
+				return nil, invalidRoster("record %d: invalid hire date %q", line, e.HireDate)
+			}
+		}
+		if len(e.DisplayName) > 255 || len(e.Department) > 255 {
+			return nil, invalidRoster("record %d: display name and department must be at most 255 characters", line)
+		}
+		normalized = append(normalized, e)
+	}
+
+	for i, e := range normalized {
+		if _, ok := seen[e.Manager]; e.Manager != "" && !ok {
+			return nil, invalidRoster("record %d: manager %q is not in the roster", i+1, e.Manager)
+		}
+	}
+	return normalized, nil
+}
+
+// Сравнивает справочник с текущим состоянием БД. Пользователи, которых нет
+// в справочнике, помечаются уволенными; уволенные, снова появившиеся
+// в справочнике, восстанавливаются.
+func planImport(current []directoryEntry, roster []employee) importPlan {
+	existing := make(map[string]directoryEntry, len(current))
+	for _, entry := range current {
+		existing[entry.Username] = entry
+	}
+
+	var plan importPlan
+	listed := make(map[string]struct{}, len(roster))
+	for _, e := range roster {
+		listed[e.Username] = struct{}{}
+		entry, ok := existing[e.Username]
+		if !ok {
+			plan.Create = append(plan.Create, e)
+			continue
+		}
+
+		var changes []oas.FieldChange
+		diff := func(field, old, new string) {
+			if old != new {
+				changes = append(changes, oas.FieldChange{Field: field, Old: old, New: new})
+			}
+		}
+		diff("displayName", entry.DisplayName, e.DisplayName)
+		diff("department", entry.Department, e.Department)
+		diff("manager", entry.Manager, e.Manager)
+		diff("hireDate", entry.HireDate, e.HireDate)
+		diff("departed", strconv.FormatBool(entry.Departed), "false")
+		if len(changes) > 0 {
+			plan.Update = append(plan.Update, employeeUpdate{ID: entry.ID, Employee: e, Changes: changes})
+		}
+	}
+
+	for _, entry := range current {
+		if _, ok := listed[entry.Username]; !ok && !entry.Departed {
+			plan.Depart = append(plan.Depart, entry)
+		}
+	}
+	sort.Slice(plan.Depart, func(i, j int) bool { return plan.Depart[i].Username < plan.Depart[j].Username })
+	return plan
+}
+
+func (p importPlan) response(applied bool) *oas.ImportUsersResponse {
+	resp := &oas.ImportUsersResponse{
+		Applied:  applied,
+		Created:  make([]oas.EmployeeRecord, 0, len(p.Create)),
+		Updated:  make([]oas.EmployeeChange, 0, len(p.Update)),
+		Departed: make([]string, 0, len(p.Depart)),
+	}
+	for _, e := range p.Create {
+		resp.Created = append(resp.Created, e.record())
+	}
+	for _, u := range p.Update {
+		resp.Updated = append(resp.Updated, oas.EmployeeChange{Username: u.Employee.Username, Changes: u.Changes})
+	}
+	for _, entry := range p.Depart {
+		resp.Departed = append(resp.Departed, entry.Username)
+	}
+	return resp
+}
+
+// Загружает атрибуты всех пользователей в транзакции импорта
+func loadDirectory(ctx context.Context, tx *sql.Tx) ([]directoryEntry, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT u.id, lower(u.username), COALESCE(u.display_name, ''), COALESCE(u.department, ''),
+            COALESCE(lower(m.username), ''), COALESCE(to_char(u.hire_date, 'YYYY-MM-DD'), ''),
+            u.departed_at IS NOT NULL
+        FROM users u
+        LEFT JOIN users m ON m.id = u.manager_id
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []directoryEntry
+	for rows.Next() {
+		var entry directoryEntry
+		if err := rows.Scan(&entry.ID, &entry.Username, &entry.DisplayName, &entry.Department,
+			&entry.Manager, &entry.HireDate, &entry.Departed); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// Применяет дифф пакетными запросами, чтобы импорт 100k сотрудников
+// занимал несколько запросов, а не сотни тысяч
+func applyImport(ctx context.Context, tx *sql.Tx, plan importPlan) error {
+	if len(plan.Create) > 0 {
+		// Пароль сотрудник задаёт по токену сброса от администратора или
+		// входит через OIDC
+		accounts := make([]newAccount, len(plan.Create))
+		for i, e := range plan.Create {
+			accounts[i] = newAccount{
+				Username:    e.Username,
+				Coins:       registration.StartingBalance,
+				DisplayName: e.DisplayName,
+				Department:  e.Department,
+				HireDate:    e.HireDate,
+				Imported:    true,
+			}
+		}
+		ids, err := insertAccounts(ctx, tx, accounts)
+		if err != nil {
+			return fmt.Errorf("failed to create users: %w", err)
+		}
+		for i, id := range ids {
+			if id == 0 {
+				return fmt.Errorf("failed to create users: username %q is taken", accounts[i].Username)
+			}
+		}
+		signupsTotal.Add(float64(len(plan.Create)))
+	}
+
+	if len(plan.Update) > 0 {
+		var ids []int64
+		var names, departments, hireDates []string
+		for _, u := range plan.Update {
+			ids = append(ids, int64(u.ID))
+			names = append(names, u.Employee.DisplayName)
+			departments = append(departments, u.Employee.Department)
+			hireDates = append(hireDates, u.Employee.HireDate)
+		}
+		_, err := tx.ExecContext(ctx, `
+            UPDATE users u
+            SET display_name = NULLIF(x.display_name, ''), department = NULLIF(x.department, ''),
+                hire_date = NULLIF(x.hire_date, '')::date, departed_at = NULL
+            FROM unnest($1::int[], $2::text[], $3::text[], $4::text[]) AS x(id, display_name, department, hire_date)
+            WHERE u.id = x.id
+        `, pq.Array(ids), pq.Array(names), pq.Array(departments), pq.Array(hireDates))
+		if err != nil {
+			return fmt.Errorf("failed to update users: %w", err)
+		}
+	}
+
+	// Руководители назначаются после создания, так как могут быть новыми сотрудниками
+	var usernames, managers []string
+	for _, e := range plan.Create {
+		if e.Manager != "" {
+			usernames = append(usernames, e.Username)
+			managers = append(managers, e.Manager)
+		}
+	}
+	for _, u := range plan.Update {
+		usernames = append(usernames, u.Employee.Username)
+		managers = append(managers, u.Employee.Manager)
+	}
+	if len(usernames) > 0 {
+		_, err := tx.ExecContext(ctx, `
+            UPDATE users u
+            SET manager_id = (SELECT m.id FROM users m WHERE lower(m.username) = NULLIF(x.manager, ''))
+            FROM unnest($1::text[], $2::text[]) AS x(username, manager)
+            WHERE lower(u.username) = x.username
+        `, pq.Array(usernames), pq.Array(managers))
+		if err != nil {
+			return fmt.Errorf("failed to assign managers: %w", err)
+		}
+	}
+
+	if len(plan.Depart) > 0 {
+		var ids []int64
+		for _, entry := range plan.Depart {
+			ids = append(ids, int64(entry.ID))
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE users SET departed_at = NOW() WHERE id = ANY($1)", pq.Array(ids)); err != nil {
+			return fmt.Errorf("failed to flag departed users: %w", err)
+		}
+	}
+	return nil
+}
+
+// Строит дифф справочника с БД и, если apply, применяет его в той же транзакции
+func importEmployees(ctx context.Context, roster []employee, apply bool) (importPlan, error) {
+	ctx, span := tracer.Start(ctx, "importEmployees")
+	defer span.End()
+
+	roster, err := normalizeEmployees(roster)
+	if err != nil {
+		return importPlan{}, err
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: !apply})
+	if err != nil {
+		return importPlan{}, err
+	}
+	defer tx.Rollback()
+
+	if apply {
+		// Регистрации и параллельные импорты ждут завершения, чтобы дифф не устарел
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE users IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return importPlan{}, err
+		}
+	}
+
+	current, err := loadDirectory(ctx, tx)
+	if err != nil {
+		return importPlan{}, fmt.Errorf("failed to load users: %w", err)
+	}
+	plan := planImport(current, roster)
+	if !apply {
+		return plan, nil
+	}
+
+	if err := applyImport(ctx, tx, plan); err != nil {
+		return importPlan{}, err
+	}
+	return plan, tx.Commit()
+}
+
+// Подкоманда import-users: печатает дифф справочника с БД и с -apply применяет его
+//
+//	avito-shop import-users -file roster.csv [-format csv|json] [-apply]
+func importUsersCommand(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("import-users", flag.ContinueOnError)
+	path := flags.String("file", "", "путь к справочнику сотрудников (CSV или JSON)")
+	format := flags.String("format", "", "формат справочника: csv или json (по умолчанию по расширению файла)")
+	apply := flags.Bool("apply", false, "применить изменения; без флага печатается только дифф")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("-file is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*path)), ".")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var roster []employee
+	switch *format {
+	case "csv":
+		roster, err = parseEmployeesCSV(file)
+	case "json":
+		roster, err = parseEmployeesJSON(file)
+	default:
+		return fmt.Errorf("unknown roster format %q", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	plan, err := importEmployees(ctx, roster, *apply)
+	if err != nil {
+		return err
+	}
+	plan.print(out)
+	if *apply {
+		fmt.Fprintln(out, "Изменения применены.")
+	} else {
+		fmt.Fprintln(out, "Пробный запуск: для применения повторите с -apply.")
+	}
+	return nil
+}
+
+// Печатает дифф в формате, похожем на unified diff
+func (p importPlan) print(out io.Writer) {
+	for _, e := range p.Create {
+		fmt.Fprintf(out, "+ %s\tdisplayName=%q department=%q manager=%q hireDate=%q\n",
+			e.Username, e.DisplayName, e.Department, e.Manager, e.HireDate)
+	}
+	for _, u := range p.Update {
+		for _, change := range u.Changes {
+			fmt.Fprintf(out, "~ %s\t%s: %q -> %q\n", u.Employee.Username, change.Field, change.Old, change.New)
+		}
+	}
+	for _, entry := range p.Depart {
+		fmt.Fprintf(out, "- %s\tdeparted\n", entry.Username)
+	}
+	fmt.Fprintf(out, "Создать: %d, изменить: %d, уволены: %d\n", len(p.Create), len(p.Update), len(p.Depart))
+}
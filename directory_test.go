@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+func TestParseEmployeesCSV(t *testing.T) {
+	employees, err := parseEmployeesCSV(strings.NewReader(
+		"username,manager,display_name,department,hire_date\n" +
+			"Ivan.Petrov,,Иван Петров,Платформа,2021-03-01\n" +
+			"anna.smirnova,ivan.petrov,\"Смирнова, Анна\",Платформа,\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []employee{
+		{Username: "Ivan.Petrov", DisplayName: "Иван Петров", Department: "Платформа", HireDate: "2021-03-01"},
+		{Username: "anna.smirnova", DisplayName: "Смирнова, Анна", Department: "Платформа", Manager: "ivan.petrov"},
+	}, employees, "Колонки должны определяться по заголовку")
+
+	_, err = parseEmployeesCSV(strings.NewReader("login,department\nivan,IT\n"))
+	assert.Error(t, err, "Неизвестная колонка должна отклоняться")
+
+	_, err = parseEmployeesCSV(strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestParseEmployeesJSON(t *testing.T) {
+	employees, err := parseEmployeesJSON(strings.NewReader(
+		`[{"username":"ivan.petrov","displayName":"Иван Петров","hireDate":"2021-03-01"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []employee{{Username: "ivan.petrov", DisplayName: "Иван Петров", HireDate: "2021-03-01"}}, employees)
+}
+
+func TestNormalizeEmployees(t *testing.T) {
+	employees, err := normalizeEmployees([]employee{
+		{Username: " Ivan.Petrov ", Department: " Платформа "},
+		{Username: "anna.smirnova", Manager: "IVAN.PETROV"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ivan.petrov", employees[0].Username)
+	assert.Equal(t, "Платформа", employees[0].Department)
+	assert.Equal(t, "ivan.petrov", employees[1].Manager)
+
+	tests := []struct {
+		name      string
+		employees []employee
+	}{
+		{"Дубликат без учёта регистра", []employee{{Username: "ivan"}, {Username: "IVAN"}}},
+		{"Недопустимое имя", []employee{{Username: "ivan petrov"}}},
+		{"Руководитель вне справочника", []employee{{Username: "ivan", Manager: "boss"}}},
+		{"Сам себе руководитель", []employee{{Username: "ivan", Manager: "ivan"}}},
+		{"Неверная дата", []employee{{Username: "ivan", HireDate: "01.03.2021"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := normalizeEmployees(tt.employees)
+			var rosterErr *rosterError
+			assert.ErrorAs(t, err, &rosterErr)
+		})
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	current := []directoryEntry{
+		{ID: 1, employee: employee{Username: "ivan", Department: "IT"}},
+		{ID: 2, employee: employee{Username: "anna", Department: "IT", Manager: "ivan"}},
+		{ID: 3, employee: employee{Username: "oleg"}},
+		{ID: 4, employee: employee{Username: "maria"}, Departed: true},
+		{ID: 5, employee: employee{Username: "petr"}, Departed: true},
+	}
+	roster := []employee{
+		{Username: "ivan", Department: "IT"},
+		{Username: "anna", Department: "HR", Manager: "ivan"},
+		{Username: "maria"},
+		{Username: "elena", Manager: "anna"},
+	}
+
+	plan := planImport(current, roster)
+
+	assert.Equal(t, []employee{{Username: "elena", Manager: "anna"}}, plan.Create)
+	assert.Equal(t, []employeeUpdate{
+		{ID: 2, Employee: roster[1], Changes: []oas.FieldChange{{Field: "department", Old: "IT", New: "HR"}}},
+		{ID: 4, Employee: roster[2], Changes: []oas.FieldChange{{Field: "departed", Old: "true", New: "false"}}},
+	}, plan.Update, "Изменённые и вернувшиеся сотрудники должны обновляться")
+	assert.Len(t, plan.Depart, 1, "Уже уволенные не должны помечаться повторно")
+	assert.Equal(t, "oleg", plan.Depart[0].Username)
+
+	resp := plan.response(false)
+	assert.False(t, resp.Applied)
+	assert.Equal(t, []string{"oleg"}, resp.Departed)
+	assert.Equal(t, "anna", resp.Created[0].Manager.Value)
+}
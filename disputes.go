@@ -0,0 +1,353 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Сколько времени после перевода отправитель может запросить его отмену
+var disputeWindow = 30 * 24 * time.Hour
+
+// Читает DISPUTE_WINDOW
+func initDisputes() error {
+	var env envReader
+	window := env.Duration("DISPUTE_WINDOW", disputeWindow)
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if window <= 0 {
+		return fmt.Errorf("dispute window must be positive")
+	}
+	disputeWindow = window
+	return nil
+}
+
+// Спор по переводу, заблокированный в транзакции
+type dispute struct {
+	ID            int
+	TransactionID int
+	SenderID      int
+	ReceiverID    int
+	Amount        int
+	Status        oas.DisputeStatus
+	// Часть перевода из бюджета на благодарности и время перевода
+	Budget    int
+	CreatedAt time.Time
+}
+
+// Блокирует спор; строка перевода не блокируется – она не меняется
+func lockDispute(ctx context.Context, tx *sql.Tx, id int) (*dispute, error) {
+	d := &dispute{ID: id}
+	var status string
+	err := tx.QueryRowContext(ctx, `
+        SELECT d.transaction_id, t.sender_id, t.receiver_id, t.amount, t.budget_amount, t.created_at, d.status
+        FROM transfer_disputes d
+        JOIN transactions t ON t.id = d.transaction_id
+        WHERE d.id = $1
+        FOR UPDATE OF d
+    `, id).Scan(&d.TransactionID, &d.SenderID, &d.ReceiverID, &d.Amount, &d.Budget, &d.CreatedAt, &status)
+	if err == sql.ErrNoRows {
+		return nil, notFound(codeDisputeNotFound, "Dispute not found")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	d.Status = oas.DisputeStatus(status)
+	return d, nil
+}
+
+// Возвращает отправителю монеты компенсирующим переводом, связанным
+// с исходным. Если получатель уже потратил часть монет, без allowNegative
+// возвращается только его баланс, а недостача записывается в спор. Монеты
+// из бюджета на благодарности возвращаются в бюджет, а не на баланс.
+func (d *dispute) reverse(ctx context.Context, tx *sql.Tx, status oas.DisputeStatus, allowNegative bool) error {
+	accounts, err := lockAccounts(ctx, tx, d.SenderID, d.ReceiverID)
+	if err != nil {
+		return internalError("Failed to lock users", err)
+	}
+	amount := d.Amount
+	if !allowNegative {
+		amount = min(amount, max(accounts[d.ReceiverID].Coins, 0))
+	}
+
+	var reversalID int
+	if amount > 0 {
+		receiver := accounts[d.ReceiverID]
+		if _, err := tx.ExecContext(ctx, "UPDATE users SET coins = coins - $1 WHERE id = $2", amount, d.ReceiverID); err != nil {
+			return internalError("Failed to update receiver balance", err)
+		}
+		taken, err := takeLots(ctx, tx, d.ReceiverID, receiver.Coins, min(amount, max(receiver.Coins, 0)))
+		if err != nil {
+			return internalError("Failed to update receiver balance", err)
+		}
+		// Отправитель получает самые старые из снятых монет; то, что получатель
+		// остался должен, зачисляется новым начислением
+		toBudget := min(amount, d.Budget)
+		portions, _ := splitPortions(taken, amount-toBudget)
+		portions = append(portions, freshLot(amount-toBudget-sumPortions(portions))...)
+		if err := credit(ctx, tx, d.SenderID, portions); err != nil {
+			return err
+		}
+		if err := givingBudgets.restore(ctx, tx, d.SenderID, toBudget, d.CreatedAt); err != nil {
+			return internalError("Failed to update giving budget", err)
+		}
+		err = tx.QueryRowContext(ctx, `
+            INSERT INTO transactions (sender_id, receiver_id, amount, reverses_id)
+            VALUES ($1, $2, $3, $4)
+            RETURNING id
+        `, d.ReceiverID, d.SenderID, amount, d.TransactionID).Scan(&reversalID)
+		if err != nil {
+			return internalError("Failed to record transaction", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE transfer_disputes
+        SET status = $2, reversal_transaction_id = NULLIF($3, 0), reversed_amount = $4, shortfall = $5, resolved_at = NOW()
+        WHERE id = $1
+    `, d.ID, string(status), reversalID, amount, d.Amount-amount)
+	if err != nil {
+		return internalError("Failed to update dispute", err)
+	}
+	d.Status = status
+	return nil
+}
+
+// Выполняет действие над спором в транзакции и возвращает спор в новом состоянии
+func applyDisputeAction(ctx context.Context, id int, action func(tx *sql.Tx, d *dispute) error) (*oas.Dispute, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	d, err := lockDispute(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := action(tx, d); err != nil {
+		return nil, err
+	}
+	resp, err := scanDispute(tx.QueryRowContext(ctx, disputeQuery+"WHERE d.id = $1", id))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+
+	if d.Status != oas.DisputeStatusRejected {
+		userInfoCache.Invalidate(ctx, d.SenderID, d.ReceiverID)
+	}
+	requestLogger(ctx).Info("Dispute resolved",
+		zap.Int("dispute_id", id),
+		zap.String("status", string(d.Status)),
+		zap.Int("reversed", resp.ReversedAmount.Or(0)),
+		zap.Int("shortfall", resp.Shortfall.Or(0)),
+	)
+	return resp, nil
+}
+
+// Спор с именами участников для ответа
+const disputeQuery = `
+    SELECT d.id, d.transaction_id, s.username, r.username, t.amount, d.reason, d.status,
+        d.reversed_amount, d.shortfall, d.reversal_transaction_id, d.created_at, d.resolved_at, a.username, d.note
+    FROM transfer_disputes d
+    JOIN transactions t ON t.id = d.transaction_id
+    JOIN users s ON s.id = t.sender_id
+    JOIN users r ON r.id = t.receiver_id
+    LEFT JOIN users a ON a.id = d.resolved_by
+`
+
+func scanDispute(row interface{ Scan(...any) error }) (*oas.Dispute, error) {
+	var d oas.Dispute
+	var status string
+	var reversed, shortfall, reversalID sql.NullInt64
+	var resolvedAt sql.NullTime
+	var resolvedBy, note sql.NullString
+	err := row.Scan(&d.ID, &d.TransactionId, &d.FromUser, &d.ToUser, &d.Amount, &d.Reason, &status,
+		&reversed, &shortfall, &reversalID, &d.CreatedAt, &resolvedAt, &resolvedBy, &note)
+	if err != nil {
+		return nil, err
+	}
+	d.Status = oas.DisputeStatus(status)
+	if reversed.Valid {
+		d.ReversedAmount = oas.NewOptInt(int(reversed.Int64))
+	}
+	if shortfall.Valid {
+		d.Shortfall = oas.NewOptInt(int(shortfall.Int64))
+	}
+	if reversalID.Valid {
+		d.ReversalTransactionId = oas.NewOptInt(int(reversalID.Int64))
+	}
+	if resolvedAt.Valid {
+		d.ResolvedAt = oas.NewOptDateTime(resolvedAt.Time)
+	}
+	if resolvedBy.Valid {
+		d.ResolvedBy = oas.NewOptString(resolvedBy.String)
+	}
+	if note.Valid {
+		d.Note = oas.NewOptString(note.String)
+	}
+	return &d, nil
+}
+
+func queryDisputes(ctx context.Context, where string, args ...any) ([]oas.Dispute, error) {
+	rows, err := db.QueryContext(ctx, disputeQuery+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disputes := []oas.Dispute{}
+	for rows.Next() {
+		d, err := scanDispute(rows)
+		if err != nil {
+			return nil, err
+		}
+		disputes = append(disputes, *d)
+	}
+	return disputes, rows.Err()
+}
+
+func (apiHandler) APITransactionsTransactionIdDisputePost(ctx context.Context, req *oas.DisputeRequest, params oas.APITransactionsTransactionIdDisputePostParams) (oas.APITransactionsTransactionIdDisputePostRes, error) {
+	userID := claimsFromContext(ctx).UserID
+	reason := sanitizeMessage(req.Reason)
+	if reason == "" {
+		return nil, validationFailed(oas.ErrorDetail{Field: "reason", Code: fieldRequired, Message: "field is required"})
+	}
+
+	var senderID int
+	var reversal sql.NullInt64
+	var expired bool
+	err := db.QueryRowContext(ctx, `
+        SELECT sender_id, reverses_id, created_at < NOW() - make_interval(secs => $2)
+        FROM transactions WHERE id = $1
+    `, params.TransactionId, disputeWindow.Seconds()).Scan(&senderID, &reversal, &expired)
+	// Чужие переводы неотличимы от несуществующих
+	if err == sql.ErrNoRows || (err == nil && senderID != userID) {
+		return nil, notFound(codeTransactionNotFound, "Transaction not found")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if reversal.Valid {
+		return nil, badRequest(codeNotReversible, "Reversal transactions cannot be disputed")
+	}
+	if expired {
+		return nil, badRequest(codeDisputeWindowExpired, "Transfers can only be disputed within "+disputeWindow.String())
+	}
+
+	var id int
+	err = db.QueryRowContext(ctx, `
+        INSERT INTO transfer_disputes (transaction_id, reason) VALUES ($1, $2)
+        ON CONFLICT (transaction_id) DO NOTHING
+        RETURNING id
+    `, params.TransactionId, reason).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, conflict(codeDisputeExists, "Transaction has already been disputed")
+	}
+	if err != nil {
+		return nil, internalError("Failed to open dispute", err)
+	}
+
+	resp, err := scanDispute(db.QueryRowContext(ctx, disputeQuery+"WHERE d.id = $1", id))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	requestLogger(ctx).Info("Dispute opened", zap.Int("dispute_id", id), zap.Int("transaction_id", params.TransactionId))
+	return resp, nil
+}
+
+func (apiHandler) APIDisputesGet(ctx context.Context) (oas.APIDisputesGetRes, error) {
+	userID := claimsFromContext(ctx).UserID
+
+	var err error
+	resp := &oas.DisputeList{}
+	if resp.Opened, err = queryDisputes(ctx, "WHERE t.sender_id = $1 ORDER BY d.id DESC", userID); err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if resp.Received, err = queryDisputes(ctx, "WHERE t.receiver_id = $1 ORDER BY d.id DESC", userID); err != nil {
+		return nil, internalError("Database error", err)
+	}
+	return resp, nil
+}
+
+// Получатель возвращает монеты сам; вернуть можно только всю сумму
+func (apiHandler) APIDisputesDisputeIdReturnPost(ctx context.Context, params oas.APIDisputesDisputeIdReturnPostParams) (oas.APIDisputesDisputeIdReturnPostRes, error) {
+	userID := claimsFromContext(ctx).UserID
+	resp, err := applyDisputeAction(ctx, params.DisputeId, func(tx *sql.Tx, d *dispute) error {
+		if userID != d.ReceiverID {
+			return notFound(codeDisputeNotFound, "Dispute not found")
+		}
+		if d.Status != oas.DisputeStatusOpen {
+			return conflict(codeDisputeClosed, "Dispute is already resolved")
+		}
+		// Строки блокируются до проверки баланса; reverse блокирует их повторно
+		accounts, err := lockAccounts(ctx, tx, d.SenderID, d.ReceiverID)
+		if err != nil {
+			return internalError("Failed to lock users", err)
+		}
+		if accounts[d.ReceiverID].Coins < d.Amount {
+			return badRequest(codeNotEnoughCoins, "Not enough coins")
+		}
+		return d.reverse(ctx, tx, oas.DisputeStatusReturned, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (apiHandler) APIAdminDisputesGet(ctx context.Context, params oas.APIAdminDisputesGetParams) (oas.APIAdminDisputesGetRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	disputes, err := queryDisputes(ctx, "WHERE d.status = $1 ORDER BY d.id DESC LIMIT $2",
+		string(params.Status.Or(oas.DisputeStatusOpen)), params.Limit.Or(100))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	return &oas.AdminDisputeList{Disputes: disputes}, nil
+}
+
+func (apiHandler) APIAdminDisputesDisputeIdResolvePost(ctx context.Context, req *oas.DisputeResolveRequest, params oas.APIAdminDisputesDisputeIdResolvePostParams) (oas.APIAdminDisputesDisputeIdResolvePostRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	adminID := claimsFromContext(ctx).UserID
+	var note sql.NullString
+	if n := sanitizeMessage(req.Note.Or("")); n != "" {
+		note = sql.NullString{String: n, Valid: true}
+	}
+
+	resp, err := applyDisputeAction(ctx, params.DisputeId, func(tx *sql.Tx, d *dispute) error {
+		if adminID == d.SenderID || adminID == d.ReceiverID {
+			return forbidden(codeTransferForbidden, "Cannot resolve a dispute over your own transfer")
+		}
+		if d.Status != oas.DisputeStatusOpen {
+			return conflict(codeDisputeClosed, "Dispute is already resolved")
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE transfer_disputes SET resolved_by = $2, note = $3 WHERE id = $1", d.ID, adminID, note); err != nil {
+			return internalError("Failed to update dispute", err)
+		}
+		if req.Decision == oas.DisputeDecisionReject {
+			if _, err := tx.ExecContext(ctx, "UPDATE transfer_disputes SET status = 'rejected', resolved_at = NOW() WHERE id = $1", d.ID); err != nil {
+				return internalError("Failed to update dispute", err)
+			}
+			d.Status = oas.DisputeStatusRejected
+			return nil
+		}
+		return d.reverse(ctx, tx, oas.DisputeStatusReversed, req.AllowNegativeBalance.Or(false))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+func TestInitDisputes(t *testing.T) {
+	defer func(saved time.Duration) { disputeWindow = saved }(disputeWindow)
+
+	assert.NoError(t, initDisputes())
+	assert.Equal(t, 720*time.Hour, disputeWindow, "По умолчанию спор можно открыть в течение 30 дней")
+
+	t.Setenv("DISPUTE_WINDOW", "0s")
+	assert.Error(t, initDisputes())
+}
+
+func TestAPITransactionDispute_BlankReason(t *testing.T) {
+	stubUserState(t, 42, userState{Status: statusActive})
+
+	code, errResponse := performRawRequest(t, "POST", "/api/transactions/1/dispute", signTestToken(t, 42), `{"reason":" \t "}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Причина из одних пробелов должна отклоняться до обращения к БД")
+	assert.Equal(t, []oas.ErrorDetail{{Field: "reason", Code: fieldRequired, Message: "field is required"}}, errResponse.Details)
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/ogen-go/ogen/validate"
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Машиночитаемые коды ошибок, возвращаемые в поле code
+const (
+	codeInvalidRequest         = "invalid_request"
+	codeValidationFailed       = "validation_failed"
+	codePayloadTooLarge        = "payload_too_large"
+	codeMissingToken           = "missing_token"
+	codeInvalidToken           = "invalid_token"
+	codeInvalidCredentials     = "invalid_credentials"
+	codeItemNotFound           = "item_not_found"
+	codeNotEnoughCoins         = "not_enough_coins"
+	codeSelfTransfer           = "self_transfer"
+	codeReceiverNotFound       = "receiver_not_found"
+	codeRateLimited            = "rate_limited"
+	codeLoginLocked            = "login_locked"
+	codeRegistrationDenied     = "registration_denied"
+	codeUsernameTaken          = "username_taken"
+	codeAdminRequired          = "admin_required"
+	codeInvalidRoster          = "invalid_roster"
+	codeAccountInactive        = "account_inactive"
+	codeUserNotFound           = "user_not_found"
+	codeAlreadyDeactivated     = "already_deactivated"
+	codeReceiverInactive       = "receiver_inactive"
+	codeDispositionUnavailable = "disposition_unavailable"
+	codeInvalidResetToken      = "invalid_reset_token"
+	codeMfaRequired            = "mfa_required"
+	codeInvalidMfaCode         = "invalid_mfa_code"
+	codeMfaNotEnrolled         = "mfa_not_enrolled"
+	codeMfaAlreadyEnabled      = "mfa_already_enabled"
+	codeOIDCDisabled           = "oidc_disabled"
+	codeInvalidOIDCState       = "invalid_oidc_state"
+	codeOIDCFailed             = "oidc_failed"
+	codeIdentityConflict       = "identity_conflict"
+	codeInsufficientScope      = "insufficient_scope"
+	codeAPIKeyNotFound         = "api_key_not_found"
+	codeAmountLimitExceeded    = "amount_limit_exceeded"
+	codeDailyLimitExceeded     = "daily_limit_exceeded"
+	codeMonthlyLimitExceeded   = "monthly_limit_exceeded"
+	codeReceiveLimitExceeded   = "receive_limit_exceeded"
+	codeRecipientCooldown      = "recipient_cooldown"
+	codeFraudFlagNotFound      = "fraud_flag_not_found"
+	codeFraudFlagResolved      = "fraud_flag_resolved"
+	codeTransferNotFound       = "transfer_not_found"
+	codeTransferNotPending     = "transfer_not_pending"
+	codeTransferForbidden      = "transfer_forbidden"
+	codeTransactionNotFound    = "transaction_not_found"
+	codeNotReversible          = "not_reversible"
+	codeDisputeWindowExpired   = "dispute_window_expired"
+	codeDisputeExists          = "dispute_exists"
+	codeDisputeNotFound        = "dispute_not_found"
+	codeDisputeClosed          = "dispute_closed"
+	codeScheduleNotFound       = "schedule_not_found"
+	codeScheduleNotActive      = "schedule_not_active"
+	codeScheduleNotPaused      = "schedule_not_paused"
+	codeTooManySchedules       = "too_many_schedules"
+	codeInternalError          = "internal_error"
+)
+
+// Коды ошибок отдельных полей
+const (
+	fieldRequired      = "required"
+	fieldTooShort      = "too_short"
+	fieldTooLong       = "too_long"
+	fieldInvalidFormat = "invalid_format"
+	fieldOutOfRange    = "out_of_range"
+	fieldUnknown       = "unknown_field"
+	fieldInvalid       = "invalid"
+)
+
+// Ошибка обработчика с HTTP-статусом и сообщением для клиента
+type apiError struct {
+	status  int
+	code    string
+	message string
+	details []oas.ErrorDetail
+	// Значение заголовка Retry-After для ответов 429
+	retryAfter time.Duration
+	// Исходная причина; в ответ не попадает, только в лог
+	err error
+}
+
+func (e *apiError) Error() string {
+	if e.err != nil {
+		return e.message + ": " + e.err.Error()
+	}
+	return e.message
+}
+
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(code, message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: code, message: message}
+}
+
+func unauthorized(code, message string) *apiError {
+	return &apiError{status: http.StatusUnauthorized, code: code, message: message}
+}
+
+func forbidden(code, message string) *apiError {
+	return &apiError{status: http.StatusForbidden, code: code, message: message}
+}
+
+func notFound(code, message string) *apiError {
+	return &apiError{status: http.StatusNotFound, code: code, message: message}
+}
+
+func conflict(code, message string) *apiError {
+	return &apiError{status: http.StatusConflict, code: code, message: message}
+}
+
+func tooManyRequests(code, message string, retryAfter time.Duration) *apiError {
+	return &apiError{status: http.StatusTooManyRequests, code: code, message: message, retryAfter: retryAfter}
+}
+
+func internalError(message string, err error) *apiError {
+	return &apiError{status: http.StatusInternalServerError, code: codeInternalError, message: message, err: err}
+}
+
+// Обработчик ошибок сгенерированного сервера: приводит ошибки обработчиков,
+// проверки токена и разбора запроса к ErrorResponse
+func handleAPIError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	var apiErr *apiError
+	var secErr *ogenerrors.SecurityError
+	var decodeErr *ogenerrors.DecodeRequestError
+	var paramsErr *ogenerrors.DecodeParamsError
+
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.status >= http.StatusInternalServerError {
+			requestLogger(ctx).Error(apiErr.message, zap.Error(apiErr.err))
+		}
+	case errors.As(err, &secErr):
+		if r.Header.Get("Authorization") == "" {
+			apiErr = unauthorized(codeMissingToken, "Missing Authorization header")
+		} else {
+			apiErr = unauthorized(codeInvalidToken, "Invalid token")
+		}
+	case errors.As(err, &decodeErr), errors.As(err, &paramsErr):
+		requestLogger(ctx).Debug("Invalid request", zap.Error(err))
+		apiErr = requestError(err)
+	default:
+		code := ogenerrors.ErrorCode(err)
+		if code >= http.StatusInternalServerError {
+			requestLogger(ctx).Error("Unhandled error", zap.Error(err))
+		}
+		apiErr = &apiError{status: code, code: codeInvalidRequest, message: http.StatusText(code)}
+	}
+
+	if apiErr.retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(apiErr.retryAfter)))
+	}
+	respondWithJSON(w, apiErr.status, &oas.ErrorResponse{
+		Errors:  oas.NewOptString(apiErr.message),
+		Code:    oas.NewOptString(apiErr.code),
+		Details: apiErr.details,
+	})
+}
+
+// Разбирает ошибку декодирования запроса на ошибки отдельных полей. Ошибки
+// проверки ogen типизированы, кроме границ чисел; лишние поля декодер
+// отвергает без указания поля, поэтому они ищутся по схеме в теле запроса.
+func requestError(err error) *apiError {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &apiError{
+			status:  http.StatusRequestEntityTooLarge,
+			code:    codePayloadTooLarge,
+			message: fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit),
+		}
+	}
+
+	var schema *jsonSchema
+	var decodeErr *ogenerrors.DecodeRequestError
+	if errors.As(err, &decodeErr) {
+		schema = requestSchema(decodeErr.OperationName())
+	}
+
+	var details []oas.ErrorDetail
+	var validateErr *validate.Error
+	var bodyErr *ogenerrors.DecodeBodyError
+	switch {
+	case errors.As(err, &validateErr):
+		details = fieldErrors(schema, "", validateErr)
+	case errors.As(err, &bodyErr):
+		details = unknownFields(schema, bodyErr.Body)
+	}
+
+	if len(details) == 0 {
+		return &apiError{status: http.StatusBadRequest, code: codeInvalidRequest, message: "Invalid request payload"}
+	}
+	return validationFailed(details...)
+}
+
+// Ошибка проверки полей; сообщение берётся из первой ошибки
+func validationFailed(details ...oas.ErrorDetail) *apiError {
+	return &apiError{
+		status:  http.StatusBadRequest,
+		code:    codeValidationFailed,
+		message: details[0].Field + ": " + details[0].Message,
+		details: details,
+	}
+}
+
+// Ошибки полей из ошибки проверки ogen; вложенные объекты и элементы
+// массивов получают имена вида transfers[0].amount
+func fieldErrors(schema *jsonSchema, prefix string, validateErr *validate.Error) []oas.ErrorDetail {
+	var details []oas.ErrorDetail
+	for _, field := range validateErr.Fields {
+		name := field.Name
+		if prefix != "" && !strings.HasPrefix(name, "[") {
+			name = "." + name
+		}
+		name = prefix + name
+
+		var nested *validate.Error
+		if errors.As(field.Error, &nested) {
+			details = append(details, fieldErrors(schema.field(field.Name), name, nested)...)
+			continue
+		}
+		details = append(details, fieldError(schema.field(field.Name), name, field.Error))
+	}
+	return details
+}
+
+func fieldError(schema *jsonSchema, name string, err error) oas.ErrorDetail {
+	detail := oas.ErrorDetail{Field: name, Code: fieldInvalid, Message: err.Error()}
+
+	var minLen *validate.MinLengthError
+	var maxLen *validate.MaxLengthError
+	var noMatch *validate.NoRegexMatchError
+	switch {
+	case errors.Is(err, validate.ErrFieldRequired):
+		detail.Code, detail.Message = fieldRequired, "field is required"
+	case errors.As(err, &minLen):
+		detail.Code, detail.Message = fieldTooShort, fmt.Sprintf("must be at least %d characters", minLen.MinLength)
+	case errors.As(err, &maxLen):
+		detail.Code, detail.Message = fieldTooLong, fmt.Sprintf("must be at most %d characters", maxLen.MaxLength)
+	case errors.As(err, &noMatch):
+		detail.Code, detail.Message = fieldInvalidFormat, "contains invalid characters"
+	case schema.rangeMessage() != "":
+		// У числовых полей ogen проверяет только границы
+		detail.Code, detail.Message = fieldOutOfRange, schema.rangeMessage()
+	}
+	return detail
+}
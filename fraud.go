@@ -0,0 +1,497 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Виды отметок анализатора
+const (
+	fraudFanIn  = string(oas.FraudFlagKindFanIn)
+	fraudCycle  = string(oas.FraudFlagKindCycle)
+	fraudVolume = string(oas.FraudFlagKindVolume)
+)
+
+// Ключ advisory-блокировки: анализ выполняет один экземпляр за раз
+const fraudScanLockKey = 4_500_045
+
+// Настройки анализатора подозрительных переводов
+type fraudConfig struct {
+	// Период фонового анализа; 0 – только по запросу администратора
+	Interval time.Duration
+	// За какой период анализируются переводы
+	Window time.Duration
+	// Учётная запись считается новой в течение этого времени после создания
+	NewAccountAge time.Duration
+	// Сколько новых учётных записей должны перевести монеты одному получателю
+	FanInSenders int
+	// Максимальная длина цикла переводов
+	MaxCycleLength int
+	// Объём отправленных или полученных за Window монет; 0 – не проверяется
+	VolumeThreshold int
+	// Блокировать отмеченные учётные записи до проверки
+	AutoFreeze bool
+}
+
+var fraud = fraudConfig{
+	Interval:        5 * time.Minute,
+	Window:          24 * time.Hour,
+	NewAccountAge:   24 * time.Hour,
+	FanInSenders:    3,
+	MaxCycleLength:  4,
+	VolumeThreshold: 3000,
+}
+
+// Читает FRAUD_SCAN_INTERVAL, FRAUD_WINDOW, FRAUD_NEW_ACCOUNT_AGE,
+// FRAUD_FAN_IN_SENDERS, FRAUD_MAX_CYCLE_LENGTH, FRAUD_VOLUME_THRESHOLD
+// и FRAUD_AUTO_FREEZE
+func initFraud() error {
+	var env envReader
+	config := fraudConfig{
+		Interval:        env.Duration("FRAUD_SCAN_INTERVAL", fraud.Interval),
+		Window:          env.Duration("FRAUD_WINDOW", fraud.Window),
+		NewAccountAge:   env.Duration("FRAUD_NEW_ACCOUNT_AGE", fraud.NewAccountAge),
+		FanInSenders:    env.Int("FRAUD_FAN_IN_SENDERS", fraud.FanInSenders),
+		MaxCycleLength:  env.Int("FRAUD_MAX_CYCLE_LENGTH", fraud.MaxCycleLength),
+		VolumeThreshold: env.Int("FRAUD_VOLUME_THRESHOLD", fraud.VolumeThreshold),
+		AutoFreeze:      envString("FRAUD_AUTO_FREEZE", "false") == "true",
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if config.Interval < 0 || config.Window <= 0 || config.NewAccountAge <= 0 {
+		return fmt.Errorf("invalid fraud analyzer intervals")
+	}
+	if config.FanInSenders < 2 || config.MaxCycleLength < 3 || config.VolumeThreshold < 0 {
+		return fmt.Errorf("invalid fraud analyzer thresholds")
+	}
+	fraud = config
+	return nil
+}
+
+// Признак, найденный анализатором
+type fraudFinding struct {
+	UserID  int
+	Kind    string
+	Related []int
+	Amount  sql.NullInt64
+}
+
+// Результат анализа
+type fraudScanResult struct {
+	Flagged int
+	Frozen  []int
+}
+
+// Запускает фоновый анализ с периодом Interval до отмены ctx
+func startFraudAnalyzer(ctx context.Context) {
+	if fraud.Interval == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(fraud.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := fraud.scan(ctx); err != nil {
+					logger.Error("Fraud scan failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Ищет признаки в переводах за Window и ставит отметки. Если анализ уже
+// выполняет другой экземпляр, возвращает пустой результат.
+func (c fraudConfig) scan(ctx context.Context) (*fraudScanResult, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", fraudScanLockKey).Scan(&locked); err != nil {
+		return nil, err
+	}
+	if !locked {
+		return &fraudScanResult{}, nil
+	}
+
+	var findings []fraudFinding
+	for _, detect := range []func(context.Context, *sql.Tx) ([]fraudFinding, error){c.fanIn, c.cycles, c.volume} {
+		found, err := detect(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+
+	result := &fraudScanResult{}
+	for _, finding := range findings {
+		flagID, created, err := c.raise(ctx, tx, finding)
+		if err != nil {
+			return nil, err
+		}
+		if !created {
+			continue
+		}
+		result.Flagged++
+		fraudFlagsTotal.WithLabelValues(finding.Kind).Inc()
+		logger.Warn("Suspicious transfers flagged",
+			zap.Int("flag_id", flagID), zap.Int("user_id", finding.UserID),
+			zap.String("kind", finding.Kind), zap.Ints("related_user_ids", finding.Related))
+
+		if c.AutoFreeze {
+			frozen, err := freezeFlagged(ctx, tx, flagID, finding.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if frozen {
+				result.Frozen = append(result.Frozen, finding.UserID)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	for _, userID := range result.Frozen {
+		forgetUserState(userID)
+	}
+	return result, nil
+}
+
+// Получатели монет от нескольких учётных записей, созданных незадолго до
+// перевода. Импортированные из справочника сотрудники новыми не считаются.
+func (c fraudConfig) fanIn(ctx context.Context, tx *sql.Tx) ([]fraudFinding, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT t.receiver_id, array_agg(DISTINCT t.sender_id), SUM(t.amount)
+        FROM transactions t
+        JOIN users s ON s.id = t.sender_id
+        WHERE t.created_at > NOW() - make_interval(secs => $1) AND t.reverses_id IS NULL
+          AND t.created_at < s.created_at + make_interval(secs => $2) AND s.imported_at IS NULL
+        GROUP BY t.receiver_id
+        HAVING COUNT(DISTINCT t.sender_id) >= $3
+    `, c.Window.Seconds(), c.NewAccountAge.Seconds(), c.FanInSenders)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var findings []fraudFinding
+	for rows.Next() {
+		f := fraudFinding{Kind: fraudFanIn}
+		var senders pq.Int64Array
+		if err := rows.Scan(&f.UserID, &senders, &f.Amount); err != nil {
+			return nil, err
+		}
+		f.Related = intsFrom(senders)
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+// Циклы переводов A→B→…→A длиной от 3 до MaxCycleLength. Каждый цикл
+// находится один раз – начиная с участника с наименьшим id – и отмечается
+// у всех участников.
+func (c fraudConfig) cycles(ctx context.Context, tx *sql.Tx) ([]fraudFinding, error) {
+	rows, err := tx.QueryContext(ctx, `
+        WITH RECURSIVE edges AS (
+            SELECT DISTINCT sender_id, receiver_id FROM transactions
+            WHERE created_at > NOW() - make_interval(secs => $1) AND sender_id <> receiver_id AND reverses_id IS NULL
+        ), walk (start_id, last_id, path) AS (
+            SELECT sender_id, receiver_id, ARRAY[sender_id, receiver_id] FROM edges
+            WHERE receiver_id > sender_id
+            UNION ALL
+            SELECT w.start_id, e.receiver_id, w.path || e.receiver_id
+            FROM walk w
+            JOIN edges e ON e.sender_id = w.last_id
+            WHERE w.last_id <> w.start_id
+              AND cardinality(w.path) <= $2
+              AND (e.receiver_id = w.start_id OR (e.receiver_id > w.start_id AND e.receiver_id <> ALL (w.path)))
+        )
+        SELECT path[1:cardinality(path) - 1] FROM walk
+        WHERE last_id = start_id AND cardinality(path) > 3
+    `, c.Window.Seconds(), c.MaxCycleLength)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Пользователь может входить в несколько циклов; отметка одна на всех
+	related := make(map[int]map[int]struct{})
+	var order []int
+	for rows.Next() {
+		var path pq.Int64Array
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		members := intsFrom(path)
+		for _, userID := range members {
+			if related[userID] == nil {
+				related[userID] = make(map[int]struct{})
+				order = append(order, userID)
+			}
+			for _, other := range members {
+				if other != userID {
+					related[userID][other] = struct{}{}
+				}
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	findings := make([]fraudFinding, 0, len(order))
+	for _, userID := range order {
+		f := fraudFinding{UserID: userID, Kind: fraudCycle}
+		for other := range related[userID] {
+			f.Related = append(f.Related, other)
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// Пользователи, отправившие или получившие за Window не меньше VolumeThreshold
+// монет. Сервисные аккаунты не проверяются: большой объём – их назначение.
+func (c fraudConfig) volume(ctx context.Context, tx *sql.Tx) ([]fraudFinding, error) {
+	if c.VolumeThreshold == 0 {
+		return nil, nil
+	}
+	rows, err := tx.QueryContext(ctx, `
+        SELECT v.user_id, MAX(v.total)
+        FROM (
+            SELECT sender_id AS user_id, SUM(amount) AS total FROM transactions
+            WHERE created_at > NOW() - make_interval(secs => $1) AND reverses_id IS NULL
+            GROUP BY sender_id
+            UNION ALL
+            SELECT receiver_id, SUM(amount) FROM transactions
+            WHERE created_at > NOW() - make_interval(secs => $1) AND reverses_id IS NULL
+            GROUP BY receiver_id
+        ) v
+        JOIN users u ON u.id = v.user_id AND u.kind = 'person'
+        WHERE v.total >= $2
+        GROUP BY v.user_id
+    `, c.Window.Seconds(), c.VolumeThreshold)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var findings []fraudFinding
+	for rows.Next() {
+		f := fraudFinding{Kind: fraudVolume}
+		if err := rows.Scan(&f.UserID, &f.Amount); err != nil {
+			return nil, err
+		}
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+// Ставит отметку или обновляет открытую. Признак, отметку по которому
+// администратор закрыл в пределах Window, повторно не отмечается: он
+// основан на тех же переводах.
+func (c fraudConfig) raise(ctx context.Context, tx *sql.Tx, f fraudFinding) (flagID int, created bool, err error) {
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO fraud_flags (user_id, kind, related_user_ids, amount)
+        SELECT $1, $2, $3, $4
+        WHERE NOT EXISTS (
+            SELECT 1 FROM fraud_flags
+            WHERE user_id = $1 AND kind = $2 AND resolved_at > NOW() - make_interval(secs => $5)
+        )
+        ON CONFLICT (user_id, kind) WHERE resolved_at IS NULL
+        DO UPDATE SET related_user_ids = EXCLUDED.related_user_ids, amount = EXCLUDED.amount, last_seen_at = NOW()
+        RETURNING id, xmax = 0
+    `, f.UserID, f.Kind, pq.Array(f.Related), f.Amount, c.Window.Seconds()).Scan(&flagID, &created)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	return flagID, created, err
+}
+
+// Блокирует отмеченную учётную запись до проверки. Администраторы и
+// сервисные аккаунты не блокируются.
+func freezeFlagged(ctx context.Context, tx *sql.Tx, flagID, userID int) (bool, error) {
+	res, err := tx.ExecContext(ctx, `
+        UPDATE users SET status = 'suspended', token_version = token_version + 1
+        WHERE id = $1 AND status = 'active' AND role <> 'admin' AND kind = 'person'
+    `, userID)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE fraud_flags SET frozen = TRUE WHERE id = $1", flagID)
+	return err == nil, err
+}
+
+func intsFrom(values pq.Int64Array) []int {
+	ints := make([]int, len(values))
+	for i, v := range values {
+		ints[i] = int(v)
+	}
+	return ints
+}
+
+// Отметка с именами пользователей для ответа
+const fraudFlagQuery = `
+    SELECT f.id, u.username, f.kind, f.amount, f.frozen, f.created_at, f.last_seen_at,
+        f.resolved_at, r.username, f.resolution,
+        COALESCE((SELECT array_agg(o.username ORDER BY o.username) FROM users o WHERE o.id = ANY (f.related_user_ids)), '{}')
+    FROM fraud_flags f
+    JOIN users u ON u.id = f.user_id
+    LEFT JOIN users r ON r.id = f.resolved_by
+`
+
+func scanFraudFlag(row interface{ Scan(...any) error }) (*oas.FraudFlag, error) {
+	var flag oas.FraudFlag
+	var kind string
+	var amount sql.NullInt64
+	var resolvedAt sql.NullTime
+	var resolvedBy, resolution sql.NullString
+	var related pq.StringArray
+	err := row.Scan(&flag.ID, &flag.Username, &kind, &amount, &flag.Frozen, &flag.CreatedAt, &flag.LastSeenAt,
+		&resolvedAt, &resolvedBy, &resolution, &related)
+	if err != nil {
+		return nil, err
+	}
+	flag.Kind = oas.FraudFlagKind(kind)
+	flag.RelatedUsers = []string(related)
+	if amount.Valid {
+		flag.Amount = oas.NewOptInt(int(amount.Int64))
+	}
+	if resolvedAt.Valid {
+		flag.ResolvedAt = oas.NewOptDateTime(resolvedAt.Time)
+	}
+	if resolvedBy.Valid {
+		flag.ResolvedBy = oas.NewOptString(resolvedBy.String)
+	}
+	if resolution.Valid {
+		flag.Resolution = oas.NewOptFraudFlagResolution(oas.FraudFlagResolution(resolution.String))
+	}
+	return &flag, nil
+}
+
+func (apiHandler) APIAdminFraudFlagsGet(ctx context.Context, params oas.APIAdminFraudFlagsGetParams) (oas.APIAdminFraudFlagsGetRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	resolved := params.Status.Or(oas.FraudFlagStatusOpen) == oas.FraudFlagStatusResolved
+
+	rows, err := db.QueryContext(ctx, fraudFlagQuery+`
+        WHERE (f.resolved_at IS NOT NULL) = $1
+        ORDER BY f.id DESC
+        LIMIT $2
+    `, resolved, params.Limit.Or(100))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	defer rows.Close()
+
+	resp := &oas.FraudFlagList{Flags: []oas.FraudFlag{}}
+	for rows.Next() {
+		flag, err := scanFraudFlag(rows)
+		if err != nil {
+			return nil, internalError("Database error", err)
+		}
+		resp.Flags = append(resp.Flags, *flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, internalError("Database error", err)
+	}
+	return resp, nil
+}
+
+func (apiHandler) APIAdminFraudFlagsScanPost(ctx context.Context) (oas.APIAdminFraudFlagsScanPostRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	result, err := fraud.scan(ctx)
+	if err != nil {
+		return nil, internalError("Fraud scan failed", err)
+	}
+	return &oas.FraudScanResult{Flagged: result.Flagged, Frozen: len(result.Frozen)}, nil
+}
+
+func (apiHandler) APIAdminFraudFlagsFlagIdResolvePost(ctx context.Context, req *oas.FraudFlagResolveRequest, params oas.APIAdminFraudFlagsFlagIdResolvePostParams) (oas.APIAdminFraudFlagsFlagIdResolvePostRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	adminID := claimsFromContext(ctx).UserID
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	var userID int
+	var frozen bool
+	err = tx.QueryRowContext(ctx, `
+        UPDATE fraud_flags SET resolved_at = NOW(), resolved_by = $2, resolution = $3
+        WHERE id = $1 AND resolved_at IS NULL
+        RETURNING user_id, frozen
+    `, params.FlagId, adminID, string(req.Resolution)).Scan(&userID, &frozen)
+	if err == sql.ErrNoRows {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM fraud_flags WHERE id = $1)", params.FlagId).Scan(&exists); err != nil {
+			return nil, internalError("Database error", err)
+		}
+		if exists {
+			return nil, conflict(codeFraudFlagResolved, "Fraud flag is already resolved")
+		}
+		return nil, notFound(codeFraudFlagNotFound, "Fraud flag not found")
+	}
+	if err != nil {
+		return nil, internalError("Failed to resolve fraud flag", err)
+	}
+
+	// Блокировка снимается, если подозрение не подтвердилось и других
+	// открытых отметок с блокировкой у пользователя нет
+	unfrozen := false
+	if frozen && req.Resolution == oas.FraudFlagResolutionDismissed {
+		res, err := tx.ExecContext(ctx, `
+            UPDATE users SET status = 'active'
+            WHERE id = $1 AND status = 'suspended'
+              AND NOT EXISTS (SELECT 1 FROM fraud_flags WHERE user_id = $1 AND frozen AND resolved_at IS NULL)
+        `, userID)
+		if err != nil {
+			return nil, internalError("Failed to update status", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, internalError("Failed to update status", err)
+		}
+		unfrozen = n > 0
+	}
+
+	flag, err := scanFraudFlag(tx.QueryRowContext(ctx, fraudFlagQuery+"WHERE f.id = $1", params.FlagId))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+	if unfrozen {
+		forgetUserState(userID)
+	}
+	requestLogger(ctx).Info("Fraud flag resolved",
+		zap.Int("flag_id", params.FlagId), zap.Int("user_id", userID),
+		zap.String("resolution", string(req.Resolution)), zap.Bool("unfrozen", unfrozen))
+	return flag, nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitFraud(t *testing.T) {
+	defer func(saved fraudConfig) { fraud = saved }(fraud)
+
+	t.Setenv("FRAUD_SCAN_INTERVAL", "0s")
+	t.Setenv("FRAUD_AUTO_FREEZE", "true")
+	assert.NoError(t, initFraud())
+	assert.Equal(t, time.Duration(0), fraud.Interval, "Нулевой период отключает фоновый анализ")
+	assert.True(t, fraud.AutoFreeze)
+	assert.Equal(t, 3, fraud.FanInSenders, "Незаданные значения остаются по умолчанию")
+
+	t.Setenv("FRAUD_MAX_CYCLE_LENGTH", "2")
+	assert.Error(t, initFraud(), "Цикл из двух переводов – обычная взаимная благодарность")
+}
+
+func TestAPIAdminFraudFlagResolve_Validation(t *testing.T) {
+	stubUserState(t, 42, userState{Status: statusActive})
+
+	code, errResponse := performRawRequest(t, "POST", "/api/admin/fraud-flags/1/resolve", signTestToken(t, 42), `{"resolution":"maybe"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Неизвестное решение должно отклоняться до обращения к БД")
+	assert.Equal(t, codeValidationFailed, errResponse.Code.Value)
+}
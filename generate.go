@@ -0,0 +1,4 @@
+package main
+
+// HTTP-слой (модели, роутер, декодирование запросов) генерируется ogen по api/schema.json
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen --config .ogen.yml --target internal/oas --package oas --clean api/schema.json
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Тест того, что код в internal/oas соответствует api/schema.json
+func TestGeneratedCodeMatchesSpec(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Генерация кода пропускается в режиме -short")
+	}
+
+	target := t.TempDir()
+	cmd := exec.Command("go", "run", "github.com/ogen-go/ogen/cmd/ogen",
+		"--config", ".ogen.yml", "--target", target, "--package", "oas", "--clean", "api/schema.json")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Не удалось сгенерировать код: %v\n%s", err, out)
+	}
+
+	generated, err := filepath.Glob(filepath.Join(target, "*.go"))
+	if err != nil {
+		t.Fatalf("Ошибка при чтении сгенерированных файлов: %v", err)
+	}
+	committed, err := filepath.Glob(filepath.Join("internal", "oas", "*.go"))
+	if err != nil {
+		t.Fatalf("Ошибка при чтении файлов internal/oas: %v", err)
+	}
+	assert.Equal(t, len(generated), len(committed), "Набор файлов internal/oas отличается от сгенерированного, запустите go generate")
+
+	for _, path := range generated {
+		expected, _ := os.ReadFile(path)
+		actual, err := os.ReadFile(filepath.Join("internal", "oas", filepath.Base(path)))
+		if err != nil {
+			t.Errorf("Файл %s отсутствует, запустите go generate", filepath.Base(path))
+			continue
+		}
+		assert.Equal(t, string(expected), string(actual), "Файл %s не соответствует api/schema.json, запустите go generate", filepath.Base(path))
+	}
+}
+
+// Тест того, что таблица apiRoutes описывает все операции api/schema.json
+// и каждая из них обрабатывается сгенерированным сервером
+func TestAPIRoutesMatchSpec(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("api", "schema.json"))
+	if err != nil {
+		t.Fatalf("Ошибка при чтении api/schema.json: %v", err)
+	}
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("Ошибка при разборе api/schema.json: %v", err)
+	}
+	var operations []string
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			operations = append(operations, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	server, err := oas.NewServer(apiHandler{}, securityHandler{})
+	if err != nil {
+		t.Fatalf("Не удалось создать сервер: %v", err)
+	}
+	var routes []string
+	for _, route := range apiRoutes {
+		routes = append(routes, route.Method+" "+route.Pattern)
+		found, ok := server.FindRoute(route.Method, pathParamRe.ReplaceAllString(route.Pattern, "1"))
+		if assert.True(t, ok, "Операция %s не найдена в сгенерированном сервере", route.Name) {
+			assert.Equal(t, route.Pattern, found.PathPattern(), route.Name)
+		}
+	}
+	assert.ElementsMatch(t, operations, routes, "apiRoutes должна содержать все операции api/schema.json")
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+const benchUsers = 100000
+
+// Заполняет БД 100k пользователями с историей покупок и переводов. БД
+// задаётся теми же DB_HOST, DB_PORT, DB_USER, DB_PASSWORD и DB_NAME, что и
+// для сервиса; без DB_HOST бенчмарк пропускается.
+func seedBenchData(b *testing.B) {
+	if os.Getenv("DB_HOST") == "" {
+		b.Skip("DB_HOST не задан: бенчмарку нужна БД")
+	}
+
+	var err error
+	db, err = initdb()
+	if err != nil {
+		b.Fatalf("Не удалось инициализировать базу данных: %v", err)
+	}
+
+	// Пользователи и их стартовые партии создаются тем же кодом, что и при
+	// регистрации
+	accounts := make([]newAccount, benchUsers)
+	for i := range accounts {
+		accounts[i] = newAccount{
+			Username: fmt.Sprintf("bench_user_%d", i+1),
+			Password: sql.NullString{String: "password", Valid: true},
+			Coins:    1000,
+		}
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		b.Fatalf("Не удалось заполнить БД: %v", err)
+	}
+	defer tx.Rollback()
+	if _, err := insertAccounts(context.Background(), tx, accounts); err != nil {
+		b.Fatalf("Не удалось заполнить БД: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		b.Fatalf("Не удалось заполнить БД: %v", err)
+	}
+
+	statements := []string{
+		// В среднем по 5 покупок и по 5 отправленных и полученных переводов на пользователя
+		`INSERT INTO purchases (user_id, item_name)
+			SELECT u.id, (ARRAY['t-shirt','cup','book','pen','powerbank','hoody','umbrella','socks','wallet','pink-hoody'])[1 + floor(random() * 10)::int]
+			FROM users u, generate_series(1, 5)
+			WHERE u.username LIKE 'bench_user_%'`,
+		`WITH bench AS (SELECT min(id) AS lo, max(id) AS hi FROM users WHERE username LIKE 'bench_user_%')
+			INSERT INTO transactions (sender_id, receiver_id, amount)
+			SELECT u.id, bench.lo + floor(random() * (bench.hi - bench.lo + 1))::int, 1 + floor(random() * 50)::int
+			FROM users u, bench, generate_series(1, 5)
+			WHERE u.username LIKE 'bench_user_%'`,
+		`ANALYZE users; ANALYZE purchases; ANALYZE transactions; ANALYZE coin_lots`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			b.Fatalf("Не удалось заполнить БД: %v", err)
+		}
+	}
+}
+
+// Подзапрос с ID пользователей бенчмарка
+const benchIDs = `(SELECT id FROM users WHERE username LIKE 'bench_user_%')`
+
+// Удаляет пользователей бенчмарка и все строки, ссылающиеся на них, в
+// порядке внешних ключей
+func cleanupBenchData(b *testing.B) {
+	statements := []string{
+		`DELETE FROM coin_ledger WHERE user_id IN ` + benchIDs,
+		`DELETE FROM coin_lots WHERE user_id IN ` + benchIDs,
+		`DELETE FROM giving_budgets WHERE user_id IN ` + benchIDs,
+		`DELETE FROM notifications WHERE user_id IN ` + benchIDs + `
+			OR schedule_id IN (SELECT id FROM scheduled_transfers WHERE sender_id IN ` + benchIDs + ` OR receiver_id IN ` + benchIDs + `)`,
+		`DELETE FROM scheduled_transfers WHERE sender_id IN ` + benchIDs + ` OR receiver_id IN ` + benchIDs,
+		`DELETE FROM transfer_disputes WHERE resolved_by IN ` + benchIDs + `
+			OR transaction_id IN (SELECT id FROM transactions WHERE sender_id IN ` + benchIDs + ` OR receiver_id IN ` + benchIDs + `)`,
+		`DELETE FROM pending_transfers WHERE sender_id IN ` + benchIDs + ` OR receiver_id IN ` + benchIDs + `
+			OR approver_id IN ` + benchIDs + ` OR approved_by IN ` + benchIDs,
+		`DELETE FROM transactions WHERE sender_id IN ` + benchIDs + ` OR receiver_id IN ` + benchIDs,
+		`DELETE FROM fraud_flags WHERE user_id IN ` + benchIDs + ` OR resolved_by IN ` + benchIDs,
+		`DELETE FROM purchases WHERE user_id IN ` + benchIDs,
+		`DELETE FROM password_resets WHERE user_id IN ` + benchIDs,
+		`DELETE FROM recovery_codes WHERE user_id IN ` + benchIDs,
+		`DELETE FROM totp_credentials WHERE user_id IN ` + benchIDs,
+		`DELETE FROM api_keys WHERE user_id IN ` + benchIDs,
+		`DELETE FROM user_identities WHERE user_id IN ` + benchIDs,
+		`DELETE FROM offboardings WHERE user_id IN ` + benchIDs + ` OR recipient_id IN ` + benchIDs,
+		`UPDATE users SET manager_id = NULL WHERE manager_id IN ` + benchIDs,
+		`DELETE FROM users WHERE username LIKE 'bench_user_%'`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			b.Fatalf("Ошибка при очистке данных: %v", err)
+		}
+	}
+}
+
+// Нагрузочный тест /api/info на 100k пользователей; дополнительно к ns/op
+// сообщает p50, p95 и p99 и падает, если p95 превышает SLI в 50 мс.
+//
+//	DB_HOST=localhost DB_PORT=5432 DB_USER=avito DB_PASSWORD=secret DB_NAME=avito_shop \
+//	    go test -run '^$' -bench BenchmarkApiInfoGet -benchtime 10000x
+func BenchmarkApiInfoGet(b *testing.B) {
+	seedBenchData(b)
+	defer cleanupBenchData(b)
+
+	previousLimits := routeLimits
+	routeLimits = map[string]rateLimit{}
+	defer func() { routeLimits = previousLimits }()
+
+	router := NewRouter()
+	rows, err := db.Query("SELECT id FROM users WHERE username LIKE 'bench_user_%' ORDER BY random() LIMIT 1000")
+	if err != nil {
+		b.Fatalf("Не удалось выбрать пользователей: %v", err)
+	}
+	var tokens []string
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			b.Fatalf("Не удалось выбрать пользователей: %v", err)
+		}
+		tokens = append(tokens, signTestToken(b, userID))
+	}
+	rows.Close()
+
+	var mu sync.Mutex
+	latencies := make([]time.Duration, 0, b.N)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		local := make([]time.Duration, 0, 1024)
+		for pb.Next() {
+			req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+			req.Header.Set("Authorization", tokens[rand.Intn(len(tokens))])
+			recorder := httptest.NewRecorder()
+
+			start := time.Now()
+			router.ServeHTTP(recorder, req)
+			local = append(local, time.Since(start))
+
+			if recorder.Code != http.StatusOK {
+				b.Errorf("Ожидался код 200, получен %d: %s", recorder.Code, recorder.Body.String())
+			}
+		}
+		mu.Lock()
+		latencies = append(latencies, local...)
+		mu.Unlock()
+	})
+	b.StopTimer()
+
+	// Перцентили выводятся рядом с ns/op, чтобы результат прогона можно было
+	// приложить как есть
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p int) time.Duration {
+		return latencies[min(len(latencies)*p/100, len(latencies)-1)]
+	}
+	for _, p := range []int{50, 95, 99} {
+		b.ReportMetric(float64(percentile(p).Microseconds())/1000, fmt.Sprintf("p%d-ms", p))
+	}
+	if p95 := percentile(95); p95 > 50*time.Millisecond {
+		b.Errorf("p95 %v превышает SLI 50 мс", p95)
+	}
+}
@@ -0,0 +1,47 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package oas
+
+// setDefaults set default value of fields.
+func (s *BatchSendCoinRequest) setDefaults() {
+	{
+		val := bool(false)
+		s.Public.SetTo(val)
+	}
+	{
+		val := bool(false)
+		s.UseBudget.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *DisputeResolveRequest) setDefaults() {
+	{
+		val := bool(false)
+		s.AllowNegativeBalance.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *ScheduleRequest) setDefaults() {
+	{
+		val := bool(false)
+		s.Public.SetTo(val)
+	}
+	{
+		val := bool(false)
+		s.UseBudget.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *SendCoinRequest) setDefaults() {
+	{
+		val := bool(false)
+		s.Public.SetTo(val)
+	}
+	{
+		val := bool(false)
+		s.UseBudget.SetTo(val)
+	}
+}
@@ -0,0 +1,182 @@
+// Code generated by ogen, DO NOT EDIT.
+package oas
+
+type API2FATotpConfirmPostRes interface {
+	aPI2FATotpConfirmPostRes()
+}
+
+type API2FATotpDisablePostRes interface {
+	aPI2FATotpDisablePostRes()
+}
+
+type API2FATotpPostRes interface {
+	aPI2FATotpPostRes()
+}
+
+type APIAdminDisputesDisputeIdResolvePostRes interface {
+	aPIAdminDisputesDisputeIdResolvePostRes()
+}
+
+type APIAdminDisputesGetRes interface {
+	aPIAdminDisputesGetRes()
+}
+
+type APIAdminFraudFlagsFlagIdResolvePostRes interface {
+	aPIAdminFraudFlagsFlagIdResolvePostRes()
+}
+
+type APIAdminFraudFlagsGetRes interface {
+	aPIAdminFraudFlagsGetRes()
+}
+
+type APIAdminFraudFlagsScanPostRes interface {
+	aPIAdminFraudFlagsScanPostRes()
+}
+
+type APIAdminImportUsersPostReq interface {
+	aPIAdminImportUsersPostReq()
+}
+
+type APIAdminImportUsersPostRes interface {
+	aPIAdminImportUsersPostRes()
+}
+
+type APIAdminServiceAccountsPostRes interface {
+	aPIAdminServiceAccountsPostRes()
+}
+
+type APIAdminServiceAccountsUsernameKeysGetRes interface {
+	aPIAdminServiceAccountsUsernameKeysGetRes()
+}
+
+type APIAdminServiceAccountsUsernameKeysKeyIdDeleteRes interface {
+	aPIAdminServiceAccountsUsernameKeysKeyIdDeleteRes()
+}
+
+type APIAdminServiceAccountsUsernameKeysPostRes interface {
+	aPIAdminServiceAccountsUsernameKeysPostRes()
+}
+
+type APIAdminUsersUsernameOffboardPostRes interface {
+	aPIAdminUsersUsernameOffboardPostRes()
+}
+
+type APIAdminUsersUsernamePasswordResetPostRes interface {
+	aPIAdminUsersUsernamePasswordResetPostRes()
+}
+
+type APIAdminUsersUsernameStatusPutRes interface {
+	aPIAdminUsersUsernameStatusPutRes()
+}
+
+type APIAuth2FAPostRes interface {
+	aPIAuth2FAPostRes()
+}
+
+type APIAuthOidcCallbackPostRes interface {
+	aPIAuthOidcCallbackPostRes()
+}
+
+type APIAuthOidcGetRes interface {
+	aPIAuthOidcGetRes()
+}
+
+type APIAuthPostRes interface {
+	aPIAuthPostRes()
+}
+
+type APIBuyItemGetRes interface {
+	aPIBuyItemGetRes()
+}
+
+type APIDisputesDisputeIdReturnPostRes interface {
+	aPIDisputesDisputeIdReturnPostRes()
+}
+
+type APIDisputesGetRes interface {
+	aPIDisputesGetRes()
+}
+
+type APIInfoGetRes interface {
+	aPIInfoGetRes()
+}
+
+type APIKudosGetRes interface {
+	aPIKudosGetRes()
+}
+
+type APINotificationsGetRes interface {
+	aPINotificationsGetRes()
+}
+
+type APINotificationsReadPostRes interface {
+	aPINotificationsReadPostRes()
+}
+
+type APIPasswordPostRes interface {
+	aPIPasswordPostRes()
+}
+
+type APIPasswordResetPostRes interface {
+	aPIPasswordResetPostRes()
+}
+
+type APIRegisterPostRes interface {
+	aPIRegisterPostRes()
+}
+
+type APISchedulesGetRes interface {
+	aPISchedulesGetRes()
+}
+
+type APISchedulesPostRes interface {
+	aPISchedulesPostRes()
+}
+
+type APISchedulesScheduleIdCancelPostRes interface {
+	aPISchedulesScheduleIdCancelPostRes()
+}
+
+type APISchedulesScheduleIdPausePostRes interface {
+	aPISchedulesScheduleIdPausePostRes()
+}
+
+type APISchedulesScheduleIdResumePostRes interface {
+	aPISchedulesScheduleIdResumePostRes()
+}
+
+type APISendCoinBatchPostRes interface {
+	aPISendCoinBatchPostRes()
+}
+
+type APISendCoinPostRes interface {
+	aPISendCoinPostRes()
+}
+
+type APITransactionsTransactionIdDisputePostRes interface {
+	aPITransactionsTransactionIdDisputePostRes()
+}
+
+type APITransfersPendingGetRes interface {
+	aPITransfersPendingGetRes()
+}
+
+type APITransfersTransferIdAcceptPostRes interface {
+	aPITransfersTransferIdAcceptPostRes()
+}
+
+type APITransfersTransferIdApprovePostRes interface {
+	aPITransfersTransferIdApprovePostRes()
+}
+
+type APITransfersTransferIdCancelPostRes interface {
+	aPITransfersTransferIdCancelPostRes()
+}
+
+type APITransfersTransferIdDeclinePostRes interface {
+	aPITransfersTransferIdDeclinePostRes()
+}
+
+type APITransfersTransferIdRejectPostRes interface {
+	aPITransfersTransferIdRejectPostRes()
+}
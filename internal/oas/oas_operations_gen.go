@@ -0,0 +1,53 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package oas
+
+// OperationName is the ogen operation name
+type OperationName = string
+
+const (
+	API2FATotpConfirmPostOperation                          OperationName = "API2FATotpConfirmPost"
+	API2FATotpDisablePostOperation                          OperationName = "API2FATotpDisablePost"
+	API2FATotpPostOperation                                 OperationName = "API2FATotpPost"
+	APIAdminDisputesDisputeIdResolvePostOperation           OperationName = "APIAdminDisputesDisputeIdResolvePost"
+	APIAdminDisputesGetOperation                            OperationName = "APIAdminDisputesGet"
+	APIAdminFraudFlagsFlagIdResolvePostOperation            OperationName = "APIAdminFraudFlagsFlagIdResolvePost"
+	APIAdminFraudFlagsGetOperation                          OperationName = "APIAdminFraudFlagsGet"
+	APIAdminFraudFlagsScanPostOperation                     OperationName = "APIAdminFraudFlagsScanPost"
+	APIAdminImportUsersPostOperation                        OperationName = "APIAdminImportUsersPost"
+	APIAdminServiceAccountsPostOperation                    OperationName = "APIAdminServiceAccountsPost"
+	APIAdminServiceAccountsUsernameKeysGetOperation         OperationName = "APIAdminServiceAccountsUsernameKeysGet"
+	APIAdminServiceAccountsUsernameKeysKeyIdDeleteOperation OperationName = "APIAdminServiceAccountsUsernameKeysKeyIdDelete"
+	APIAdminServiceAccountsUsernameKeysPostOperation        OperationName = "APIAdminServiceAccountsUsernameKeysPost"
+	APIAdminUsersUsernameOffboardPostOperation              OperationName = "APIAdminUsersUsernameOffboardPost"
+	APIAdminUsersUsernamePasswordResetPostOperation         OperationName = "APIAdminUsersUsernamePasswordResetPost"
+	APIAdminUsersUsernameStatusPutOperation                 OperationName = "APIAdminUsersUsernameStatusPut"
+	APIAuth2FAPostOperation                                 OperationName = "APIAuth2FAPost"
+	APIAuthOidcCallbackPostOperation                        OperationName = "APIAuthOidcCallbackPost"
+	APIAuthOidcGetOperation                                 OperationName = "APIAuthOidcGet"
+	APIAuthPostOperation                                    OperationName = "APIAuthPost"
+	APIBuyItemGetOperation                                  OperationName = "APIBuyItemGet"
+	APIDisputesDisputeIdReturnPostOperation                 OperationName = "APIDisputesDisputeIdReturnPost"
+	APIDisputesGetOperation                                 OperationName = "APIDisputesGet"
+	APIInfoGetOperation                                     OperationName = "APIInfoGet"
+	APIKudosGetOperation                                    OperationName = "APIKudosGet"
+	APINotificationsGetOperation                            OperationName = "APINotificationsGet"
+	APINotificationsReadPostOperation                       OperationName = "APINotificationsReadPost"
+	APIPasswordPostOperation                                OperationName = "APIPasswordPost"
+	APIPasswordResetPostOperation                           OperationName = "APIPasswordResetPost"
+	APIRegisterPostOperation                                OperationName = "APIRegisterPost"
+	APISchedulesGetOperation                                OperationName = "APISchedulesGet"
+	APISchedulesPostOperation                               OperationName = "APISchedulesPost"
+	APISchedulesScheduleIdCancelPostOperation               OperationName = "APISchedulesScheduleIdCancelPost"
+	APISchedulesScheduleIdPausePostOperation                OperationName = "APISchedulesScheduleIdPausePost"
+	APISchedulesScheduleIdResumePostOperation               OperationName = "APISchedulesScheduleIdResumePost"
+	APISendCoinBatchPostOperation                           OperationName = "APISendCoinBatchPost"
+	APISendCoinPostOperation                                OperationName = "APISendCoinPost"
+	APITransactionsTransactionIdDisputePostOperation        OperationName = "APITransactionsTransactionIdDisputePost"
+	APITransfersPendingGetOperation                         OperationName = "APITransfersPendingGet"
+	APITransfersTransferIdAcceptPostOperation               OperationName = "APITransfersTransferIdAcceptPost"
+	APITransfersTransferIdApprovePostOperation              OperationName = "APITransfersTransferIdApprovePost"
+	APITransfersTransferIdCancelPostOperation               OperationName = "APITransfersTransferIdCancelPost"
+	APITransfersTransferIdDeclinePostOperation              OperationName = "APITransfersTransferIdDeclinePost"
+	APITransfersTransferIdRejectPostOperation               OperationName = "APITransfersTransferIdRejectPost"
+)
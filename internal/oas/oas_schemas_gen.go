@@ -0,0 +1,4959 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package oas
+
+import (
+	"io"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+type API2FATotpConfirmPostBadRequest ErrorResponse
+
+func (*API2FATotpConfirmPostBadRequest) aPI2FATotpConfirmPostRes() {}
+
+type API2FATotpConfirmPostConflict ErrorResponse
+
+func (*API2FATotpConfirmPostConflict) aPI2FATotpConfirmPostRes() {}
+
+type API2FATotpConfirmPostInternalServerError ErrorResponse
+
+func (*API2FATotpConfirmPostInternalServerError) aPI2FATotpConfirmPostRes() {}
+
+type API2FATotpConfirmPostTooManyRequests ErrorResponse
+
+func (*API2FATotpConfirmPostTooManyRequests) aPI2FATotpConfirmPostRes() {}
+
+type API2FATotpConfirmPostUnauthorized ErrorResponse
+
+func (*API2FATotpConfirmPostUnauthorized) aPI2FATotpConfirmPostRes() {}
+
+type API2FATotpDisablePostBadRequest ErrorResponse
+
+func (*API2FATotpDisablePostBadRequest) aPI2FATotpDisablePostRes() {}
+
+type API2FATotpDisablePostInternalServerError ErrorResponse
+
+func (*API2FATotpDisablePostInternalServerError) aPI2FATotpDisablePostRes() {}
+
+type API2FATotpDisablePostTooManyRequests ErrorResponse
+
+func (*API2FATotpDisablePostTooManyRequests) aPI2FATotpDisablePostRes() {}
+
+type API2FATotpDisablePostUnauthorized ErrorResponse
+
+func (*API2FATotpDisablePostUnauthorized) aPI2FATotpDisablePostRes() {}
+
+type API2FATotpPostConflict ErrorResponse
+
+func (*API2FATotpPostConflict) aPI2FATotpPostRes() {}
+
+type API2FATotpPostInternalServerError ErrorResponse
+
+func (*API2FATotpPostInternalServerError) aPI2FATotpPostRes() {}
+
+type API2FATotpPostUnauthorized ErrorResponse
+
+func (*API2FATotpPostUnauthorized) aPI2FATotpPostRes() {}
+
+type APIAdminDisputesDisputeIdResolvePostBadRequest ErrorResponse
+
+func (*APIAdminDisputesDisputeIdResolvePostBadRequest) aPIAdminDisputesDisputeIdResolvePostRes() {}
+
+type APIAdminDisputesDisputeIdResolvePostConflict ErrorResponse
+
+func (*APIAdminDisputesDisputeIdResolvePostConflict) aPIAdminDisputesDisputeIdResolvePostRes() {}
+
+type APIAdminDisputesDisputeIdResolvePostForbidden ErrorResponse
+
+func (*APIAdminDisputesDisputeIdResolvePostForbidden) aPIAdminDisputesDisputeIdResolvePostRes() {}
+
+type APIAdminDisputesDisputeIdResolvePostInternalServerError ErrorResponse
+
+func (*APIAdminDisputesDisputeIdResolvePostInternalServerError) aPIAdminDisputesDisputeIdResolvePostRes() {
+}
+
+type APIAdminDisputesDisputeIdResolvePostNotFound ErrorResponse
+
+func (*APIAdminDisputesDisputeIdResolvePostNotFound) aPIAdminDisputesDisputeIdResolvePostRes() {}
+
+type APIAdminDisputesDisputeIdResolvePostUnauthorized ErrorResponse
+
+func (*APIAdminDisputesDisputeIdResolvePostUnauthorized) aPIAdminDisputesDisputeIdResolvePostRes() {}
+
+type APIAdminDisputesGetBadRequest ErrorResponse
+
+func (*APIAdminDisputesGetBadRequest) aPIAdminDisputesGetRes() {}
+
+type APIAdminDisputesGetForbidden ErrorResponse
+
+func (*APIAdminDisputesGetForbidden) aPIAdminDisputesGetRes() {}
+
+type APIAdminDisputesGetInternalServerError ErrorResponse
+
+func (*APIAdminDisputesGetInternalServerError) aPIAdminDisputesGetRes() {}
+
+type APIAdminDisputesGetUnauthorized ErrorResponse
+
+func (*APIAdminDisputesGetUnauthorized) aPIAdminDisputesGetRes() {}
+
+type APIAdminFraudFlagsFlagIdResolvePostBadRequest ErrorResponse
+
+func (*APIAdminFraudFlagsFlagIdResolvePostBadRequest) aPIAdminFraudFlagsFlagIdResolvePostRes() {}
+
+type APIAdminFraudFlagsFlagIdResolvePostConflict ErrorResponse
+
+func (*APIAdminFraudFlagsFlagIdResolvePostConflict) aPIAdminFraudFlagsFlagIdResolvePostRes() {}
+
+type APIAdminFraudFlagsFlagIdResolvePostForbidden ErrorResponse
+
+func (*APIAdminFraudFlagsFlagIdResolvePostForbidden) aPIAdminFraudFlagsFlagIdResolvePostRes() {}
+
+type APIAdminFraudFlagsFlagIdResolvePostInternalServerError ErrorResponse
+
+func (*APIAdminFraudFlagsFlagIdResolvePostInternalServerError) aPIAdminFraudFlagsFlagIdResolvePostRes() {
+}
+
+type APIAdminFraudFlagsFlagIdResolvePostNotFound ErrorResponse
+
+func (*APIAdminFraudFlagsFlagIdResolvePostNotFound) aPIAdminFraudFlagsFlagIdResolvePostRes() {}
+
+type APIAdminFraudFlagsFlagIdResolvePostUnauthorized ErrorResponse
+
+func (*APIAdminFraudFlagsFlagIdResolvePostUnauthorized) aPIAdminFraudFlagsFlagIdResolvePostRes() {}
+
+type APIAdminFraudFlagsGetBadRequest ErrorResponse
+
+func (*APIAdminFraudFlagsGetBadRequest) aPIAdminFraudFlagsGetRes() {}
+
+type APIAdminFraudFlagsGetForbidden ErrorResponse
+
+func (*APIAdminFraudFlagsGetForbidden) aPIAdminFraudFlagsGetRes() {}
+
+type APIAdminFraudFlagsGetInternalServerError ErrorResponse
+
+func (*APIAdminFraudFlagsGetInternalServerError) aPIAdminFraudFlagsGetRes() {}
+
+type APIAdminFraudFlagsGetUnauthorized ErrorResponse
+
+func (*APIAdminFraudFlagsGetUnauthorized) aPIAdminFraudFlagsGetRes() {}
+
+type APIAdminFraudFlagsScanPostForbidden ErrorResponse
+
+func (*APIAdminFraudFlagsScanPostForbidden) aPIAdminFraudFlagsScanPostRes() {}
+
+type APIAdminFraudFlagsScanPostInternalServerError ErrorResponse
+
+func (*APIAdminFraudFlagsScanPostInternalServerError) aPIAdminFraudFlagsScanPostRes() {}
+
+type APIAdminFraudFlagsScanPostUnauthorized ErrorResponse
+
+func (*APIAdminFraudFlagsScanPostUnauthorized) aPIAdminFraudFlagsScanPostRes() {}
+
+type APIAdminImportUsersPostBadRequest ErrorResponse
+
+func (*APIAdminImportUsersPostBadRequest) aPIAdminImportUsersPostRes() {}
+
+type APIAdminImportUsersPostForbidden ErrorResponse
+
+func (*APIAdminImportUsersPostForbidden) aPIAdminImportUsersPostRes() {}
+
+type APIAdminImportUsersPostInternalServerError ErrorResponse
+
+func (*APIAdminImportUsersPostInternalServerError) aPIAdminImportUsersPostRes() {}
+
+type APIAdminImportUsersPostReqApplicationJSON []EmployeeRecord
+
+func (*APIAdminImportUsersPostReqApplicationJSON) aPIAdminImportUsersPostReq() {}
+
+type APIAdminImportUsersPostReqTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s APIAdminImportUsersPostReqTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*APIAdminImportUsersPostReqTextCsv) aPIAdminImportUsersPostReq() {}
+
+type APIAdminImportUsersPostUnauthorized ErrorResponse
+
+func (*APIAdminImportUsersPostUnauthorized) aPIAdminImportUsersPostRes() {}
+
+type APIAdminServiceAccountsPostBadRequest ErrorResponse
+
+func (*APIAdminServiceAccountsPostBadRequest) aPIAdminServiceAccountsPostRes() {}
+
+type APIAdminServiceAccountsPostConflict ErrorResponse
+
+func (*APIAdminServiceAccountsPostConflict) aPIAdminServiceAccountsPostRes() {}
+
+type APIAdminServiceAccountsPostForbidden ErrorResponse
+
+func (*APIAdminServiceAccountsPostForbidden) aPIAdminServiceAccountsPostRes() {}
+
+type APIAdminServiceAccountsPostInternalServerError ErrorResponse
+
+func (*APIAdminServiceAccountsPostInternalServerError) aPIAdminServiceAccountsPostRes() {}
+
+type APIAdminServiceAccountsPostUnauthorized ErrorResponse
+
+func (*APIAdminServiceAccountsPostUnauthorized) aPIAdminServiceAccountsPostRes() {}
+
+type APIAdminServiceAccountsUsernameKeysGetForbidden ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysGetForbidden) aPIAdminServiceAccountsUsernameKeysGetRes() {}
+
+type APIAdminServiceAccountsUsernameKeysGetInternalServerError ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysGetInternalServerError) aPIAdminServiceAccountsUsernameKeysGetRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysGetNotFound ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysGetNotFound) aPIAdminServiceAccountsUsernameKeysGetRes() {}
+
+type APIAdminServiceAccountsUsernameKeysGetUnauthorized ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysGetUnauthorized) aPIAdminServiceAccountsUsernameKeysGetRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysKeyIdDeleteForbidden ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysKeyIdDeleteForbidden) aPIAdminServiceAccountsUsernameKeysKeyIdDeleteRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysKeyIdDeleteInternalServerError ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysKeyIdDeleteInternalServerError) aPIAdminServiceAccountsUsernameKeysKeyIdDeleteRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysKeyIdDeleteNotFound ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysKeyIdDeleteNotFound) aPIAdminServiceAccountsUsernameKeysKeyIdDeleteRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysKeyIdDeleteUnauthorized ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysKeyIdDeleteUnauthorized) aPIAdminServiceAccountsUsernameKeysKeyIdDeleteRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysPostBadRequest ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysPostBadRequest) aPIAdminServiceAccountsUsernameKeysPostRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysPostForbidden ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysPostForbidden) aPIAdminServiceAccountsUsernameKeysPostRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysPostInternalServerError ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysPostInternalServerError) aPIAdminServiceAccountsUsernameKeysPostRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysPostNotFound ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysPostNotFound) aPIAdminServiceAccountsUsernameKeysPostRes() {
+}
+
+type APIAdminServiceAccountsUsernameKeysPostUnauthorized ErrorResponse
+
+func (*APIAdminServiceAccountsUsernameKeysPostUnauthorized) aPIAdminServiceAccountsUsernameKeysPostRes() {
+}
+
+type APIAdminUsersUsernameOffboardPostBadRequest ErrorResponse
+
+func (*APIAdminUsersUsernameOffboardPostBadRequest) aPIAdminUsersUsernameOffboardPostRes() {}
+
+type APIAdminUsersUsernameOffboardPostConflict ErrorResponse
+
+func (*APIAdminUsersUsernameOffboardPostConflict) aPIAdminUsersUsernameOffboardPostRes() {}
+
+type APIAdminUsersUsernameOffboardPostForbidden ErrorResponse
+
+func (*APIAdminUsersUsernameOffboardPostForbidden) aPIAdminUsersUsernameOffboardPostRes() {}
+
+type APIAdminUsersUsernameOffboardPostInternalServerError ErrorResponse
+
+func (*APIAdminUsersUsernameOffboardPostInternalServerError) aPIAdminUsersUsernameOffboardPostRes() {}
+
+type APIAdminUsersUsernameOffboardPostNotFound ErrorResponse
+
+func (*APIAdminUsersUsernameOffboardPostNotFound) aPIAdminUsersUsernameOffboardPostRes() {}
+
+type APIAdminUsersUsernameOffboardPostUnauthorized ErrorResponse
+
+func (*APIAdminUsersUsernameOffboardPostUnauthorized) aPIAdminUsersUsernameOffboardPostRes() {}
+
+type APIAdminUsersUsernamePasswordResetPostForbidden ErrorResponse
+
+func (*APIAdminUsersUsernamePasswordResetPostForbidden) aPIAdminUsersUsernamePasswordResetPostRes() {}
+
+type APIAdminUsersUsernamePasswordResetPostInternalServerError ErrorResponse
+
+func (*APIAdminUsersUsernamePasswordResetPostInternalServerError) aPIAdminUsersUsernamePasswordResetPostRes() {
+}
+
+type APIAdminUsersUsernamePasswordResetPostNotFound ErrorResponse
+
+func (*APIAdminUsersUsernamePasswordResetPostNotFound) aPIAdminUsersUsernamePasswordResetPostRes() {}
+
+type APIAdminUsersUsernamePasswordResetPostUnauthorized ErrorResponse
+
+func (*APIAdminUsersUsernamePasswordResetPostUnauthorized) aPIAdminUsersUsernamePasswordResetPostRes() {
+}
+
+type APIAdminUsersUsernameStatusPutBadRequest ErrorResponse
+
+func (*APIAdminUsersUsernameStatusPutBadRequest) aPIAdminUsersUsernameStatusPutRes() {}
+
+type APIAdminUsersUsernameStatusPutForbidden ErrorResponse
+
+func (*APIAdminUsersUsernameStatusPutForbidden) aPIAdminUsersUsernameStatusPutRes() {}
+
+type APIAdminUsersUsernameStatusPutInternalServerError ErrorResponse
+
+func (*APIAdminUsersUsernameStatusPutInternalServerError) aPIAdminUsersUsernameStatusPutRes() {}
+
+type APIAdminUsersUsernameStatusPutNotFound ErrorResponse
+
+func (*APIAdminUsersUsernameStatusPutNotFound) aPIAdminUsersUsernameStatusPutRes() {}
+
+type APIAdminUsersUsernameStatusPutUnauthorized ErrorResponse
+
+func (*APIAdminUsersUsernameStatusPutUnauthorized) aPIAdminUsersUsernameStatusPutRes() {}
+
+type APIAuth2FAPostBadRequest ErrorResponse
+
+func (*APIAuth2FAPostBadRequest) aPIAuth2FAPostRes() {}
+
+type APIAuth2FAPostInternalServerError ErrorResponse
+
+func (*APIAuth2FAPostInternalServerError) aPIAuth2FAPostRes() {}
+
+type APIAuth2FAPostTooManyRequests ErrorResponse
+
+func (*APIAuth2FAPostTooManyRequests) aPIAuth2FAPostRes() {}
+
+type APIAuth2FAPostUnauthorized ErrorResponse
+
+func (*APIAuth2FAPostUnauthorized) aPIAuth2FAPostRes() {}
+
+type APIAuthOidcCallbackPostBadRequest ErrorResponse
+
+func (*APIAuthOidcCallbackPostBadRequest) aPIAuthOidcCallbackPostRes() {}
+
+type APIAuthOidcCallbackPostConflict ErrorResponse
+
+func (*APIAuthOidcCallbackPostConflict) aPIAuthOidcCallbackPostRes() {}
+
+type APIAuthOidcCallbackPostForbidden ErrorResponse
+
+func (*APIAuthOidcCallbackPostForbidden) aPIAuthOidcCallbackPostRes() {}
+
+type APIAuthOidcCallbackPostInternalServerError ErrorResponse
+
+func (*APIAuthOidcCallbackPostInternalServerError) aPIAuthOidcCallbackPostRes() {}
+
+type APIAuthOidcCallbackPostNotFound ErrorResponse
+
+func (*APIAuthOidcCallbackPostNotFound) aPIAuthOidcCallbackPostRes() {}
+
+type APIAuthOidcCallbackPostTooManyRequests ErrorResponse
+
+func (*APIAuthOidcCallbackPostTooManyRequests) aPIAuthOidcCallbackPostRes() {}
+
+type APIAuthOidcCallbackPostUnauthorized ErrorResponse
+
+func (*APIAuthOidcCallbackPostUnauthorized) aPIAuthOidcCallbackPostRes() {}
+
+type APIAuthOidcGetInternalServerError ErrorResponse
+
+func (*APIAuthOidcGetInternalServerError) aPIAuthOidcGetRes() {}
+
+type APIAuthOidcGetNotFound ErrorResponse
+
+func (*APIAuthOidcGetNotFound) aPIAuthOidcGetRes() {}
+
+type APIAuthPostBadRequest ErrorResponse
+
+func (*APIAuthPostBadRequest) aPIAuthPostRes() {}
+
+type APIAuthPostForbidden ErrorResponse
+
+func (*APIAuthPostForbidden) aPIAuthPostRes() {}
+
+type APIAuthPostInternalServerError ErrorResponse
+
+func (*APIAuthPostInternalServerError) aPIAuthPostRes() {}
+
+type APIAuthPostUnauthorized ErrorResponse
+
+func (*APIAuthPostUnauthorized) aPIAuthPostRes() {}
+
+type APIBuyItemGetBadRequest ErrorResponse
+
+func (*APIBuyItemGetBadRequest) aPIBuyItemGetRes() {}
+
+type APIBuyItemGetInternalServerError ErrorResponse
+
+func (*APIBuyItemGetInternalServerError) aPIBuyItemGetRes() {}
+
+type APIBuyItemGetUnauthorized ErrorResponse
+
+func (*APIBuyItemGetUnauthorized) aPIBuyItemGetRes() {}
+
+type APIDisputesDisputeIdReturnPostBadRequest ErrorResponse
+
+func (*APIDisputesDisputeIdReturnPostBadRequest) aPIDisputesDisputeIdReturnPostRes() {}
+
+type APIDisputesDisputeIdReturnPostConflict ErrorResponse
+
+func (*APIDisputesDisputeIdReturnPostConflict) aPIDisputesDisputeIdReturnPostRes() {}
+
+type APIDisputesDisputeIdReturnPostInternalServerError ErrorResponse
+
+func (*APIDisputesDisputeIdReturnPostInternalServerError) aPIDisputesDisputeIdReturnPostRes() {}
+
+type APIDisputesDisputeIdReturnPostNotFound ErrorResponse
+
+func (*APIDisputesDisputeIdReturnPostNotFound) aPIDisputesDisputeIdReturnPostRes() {}
+
+type APIDisputesDisputeIdReturnPostUnauthorized ErrorResponse
+
+func (*APIDisputesDisputeIdReturnPostUnauthorized) aPIDisputesDisputeIdReturnPostRes() {}
+
+type APIDisputesGetInternalServerError ErrorResponse
+
+func (*APIDisputesGetInternalServerError) aPIDisputesGetRes() {}
+
+type APIDisputesGetUnauthorized ErrorResponse
+
+func (*APIDisputesGetUnauthorized) aPIDisputesGetRes() {}
+
+type APIInfoGetBadRequest ErrorResponse
+
+func (*APIInfoGetBadRequest) aPIInfoGetRes() {}
+
+type APIInfoGetInternalServerError ErrorResponse
+
+func (*APIInfoGetInternalServerError) aPIInfoGetRes() {}
+
+type APIInfoGetUnauthorized ErrorResponse
+
+func (*APIInfoGetUnauthorized) aPIInfoGetRes() {}
+
+type APIKudosGetBadRequest ErrorResponse
+
+func (*APIKudosGetBadRequest) aPIKudosGetRes() {}
+
+type APIKudosGetInternalServerError ErrorResponse
+
+func (*APIKudosGetInternalServerError) aPIKudosGetRes() {}
+
+type APIKudosGetUnauthorized ErrorResponse
+
+func (*APIKudosGetUnauthorized) aPIKudosGetRes() {}
+
+type APINotificationsGetBadRequest ErrorResponse
+
+func (*APINotificationsGetBadRequest) aPINotificationsGetRes() {}
+
+type APINotificationsGetInternalServerError ErrorResponse
+
+func (*APINotificationsGetInternalServerError) aPINotificationsGetRes() {}
+
+type APINotificationsGetUnauthorized ErrorResponse
+
+func (*APINotificationsGetUnauthorized) aPINotificationsGetRes() {}
+
+type APINotificationsReadPostInternalServerError ErrorResponse
+
+func (*APINotificationsReadPostInternalServerError) aPINotificationsReadPostRes() {}
+
+type APINotificationsReadPostUnauthorized ErrorResponse
+
+func (*APINotificationsReadPostUnauthorized) aPINotificationsReadPostRes() {}
+
+type APIPasswordPostBadRequest ErrorResponse
+
+func (*APIPasswordPostBadRequest) aPIPasswordPostRes() {}
+
+type APIPasswordPostForbidden ErrorResponse
+
+func (*APIPasswordPostForbidden) aPIPasswordPostRes() {}
+
+type APIPasswordPostInternalServerError ErrorResponse
+
+func (*APIPasswordPostInternalServerError) aPIPasswordPostRes() {}
+
+type APIPasswordPostTooManyRequests ErrorResponse
+
+func (*APIPasswordPostTooManyRequests) aPIPasswordPostRes() {}
+
+type APIPasswordPostUnauthorized ErrorResponse
+
+func (*APIPasswordPostUnauthorized) aPIPasswordPostRes() {}
+
+type APIPasswordResetPostBadRequest ErrorResponse
+
+func (*APIPasswordResetPostBadRequest) aPIPasswordResetPostRes() {}
+
+type APIPasswordResetPostInternalServerError ErrorResponse
+
+func (*APIPasswordResetPostInternalServerError) aPIPasswordResetPostRes() {}
+
+type APIRegisterPostBadRequest ErrorResponse
+
+func (*APIRegisterPostBadRequest) aPIRegisterPostRes() {}
+
+type APIRegisterPostConflict ErrorResponse
+
+func (*APIRegisterPostConflict) aPIRegisterPostRes() {}
+
+type APIRegisterPostForbidden ErrorResponse
+
+func (*APIRegisterPostForbidden) aPIRegisterPostRes() {}
+
+type APIRegisterPostInternalServerError ErrorResponse
+
+func (*APIRegisterPostInternalServerError) aPIRegisterPostRes() {}
+
+type APISchedulesGetInternalServerError ErrorResponse
+
+func (*APISchedulesGetInternalServerError) aPISchedulesGetRes() {}
+
+type APISchedulesGetUnauthorized ErrorResponse
+
+func (*APISchedulesGetUnauthorized) aPISchedulesGetRes() {}
+
+type APISchedulesPostBadRequest ErrorResponse
+
+func (*APISchedulesPostBadRequest) aPISchedulesPostRes() {}
+
+type APISchedulesPostConflict ErrorResponse
+
+func (*APISchedulesPostConflict) aPISchedulesPostRes() {}
+
+type APISchedulesPostForbidden ErrorResponse
+
+func (*APISchedulesPostForbidden) aPISchedulesPostRes() {}
+
+type APISchedulesPostInternalServerError ErrorResponse
+
+func (*APISchedulesPostInternalServerError) aPISchedulesPostRes() {}
+
+type APISchedulesPostUnauthorized ErrorResponse
+
+func (*APISchedulesPostUnauthorized) aPISchedulesPostRes() {}
+
+type APISchedulesScheduleIdCancelPostConflict ErrorResponse
+
+func (*APISchedulesScheduleIdCancelPostConflict) aPISchedulesScheduleIdCancelPostRes() {}
+
+type APISchedulesScheduleIdCancelPostInternalServerError ErrorResponse
+
+func (*APISchedulesScheduleIdCancelPostInternalServerError) aPISchedulesScheduleIdCancelPostRes() {}
+
+type APISchedulesScheduleIdCancelPostNotFound ErrorResponse
+
+func (*APISchedulesScheduleIdCancelPostNotFound) aPISchedulesScheduleIdCancelPostRes() {}
+
+type APISchedulesScheduleIdCancelPostUnauthorized ErrorResponse
+
+func (*APISchedulesScheduleIdCancelPostUnauthorized) aPISchedulesScheduleIdCancelPostRes() {}
+
+type APISchedulesScheduleIdPausePostConflict ErrorResponse
+
+func (*APISchedulesScheduleIdPausePostConflict) aPISchedulesScheduleIdPausePostRes() {}
+
+type APISchedulesScheduleIdPausePostInternalServerError ErrorResponse
+
+func (*APISchedulesScheduleIdPausePostInternalServerError) aPISchedulesScheduleIdPausePostRes() {}
+
+type APISchedulesScheduleIdPausePostNotFound ErrorResponse
+
+func (*APISchedulesScheduleIdPausePostNotFound) aPISchedulesScheduleIdPausePostRes() {}
+
+type APISchedulesScheduleIdPausePostUnauthorized ErrorResponse
+
+func (*APISchedulesScheduleIdPausePostUnauthorized) aPISchedulesScheduleIdPausePostRes() {}
+
+type APISchedulesScheduleIdResumePostConflict ErrorResponse
+
+func (*APISchedulesScheduleIdResumePostConflict) aPISchedulesScheduleIdResumePostRes() {}
+
+type APISchedulesScheduleIdResumePostInternalServerError ErrorResponse
+
+func (*APISchedulesScheduleIdResumePostInternalServerError) aPISchedulesScheduleIdResumePostRes() {}
+
+type APISchedulesScheduleIdResumePostNotFound ErrorResponse
+
+func (*APISchedulesScheduleIdResumePostNotFound) aPISchedulesScheduleIdResumePostRes() {}
+
+type APISchedulesScheduleIdResumePostUnauthorized ErrorResponse
+
+func (*APISchedulesScheduleIdResumePostUnauthorized) aPISchedulesScheduleIdResumePostRes() {}
+
+type APISendCoinBatchPostBadRequest ErrorResponse
+
+func (*APISendCoinBatchPostBadRequest) aPISendCoinBatchPostRes() {}
+
+type APISendCoinBatchPostForbidden ErrorResponse
+
+func (*APISendCoinBatchPostForbidden) aPISendCoinBatchPostRes() {}
+
+type APISendCoinBatchPostInternalServerError ErrorResponse
+
+func (*APISendCoinBatchPostInternalServerError) aPISendCoinBatchPostRes() {}
+
+type APISendCoinBatchPostTooManyRequests ErrorResponse
+
+func (*APISendCoinBatchPostTooManyRequests) aPISendCoinBatchPostRes() {}
+
+type APISendCoinBatchPostUnauthorized ErrorResponse
+
+func (*APISendCoinBatchPostUnauthorized) aPISendCoinBatchPostRes() {}
+
+type APISendCoinPostBadRequest ErrorResponse
+
+func (*APISendCoinPostBadRequest) aPISendCoinPostRes() {}
+
+type APISendCoinPostForbidden ErrorResponse
+
+func (*APISendCoinPostForbidden) aPISendCoinPostRes() {}
+
+type APISendCoinPostInternalServerError ErrorResponse
+
+func (*APISendCoinPostInternalServerError) aPISendCoinPostRes() {}
+
+type APISendCoinPostTooManyRequests ErrorResponse
+
+func (*APISendCoinPostTooManyRequests) aPISendCoinPostRes() {}
+
+type APISendCoinPostUnauthorized ErrorResponse
+
+func (*APISendCoinPostUnauthorized) aPISendCoinPostRes() {}
+
+type APITransactionsTransactionIdDisputePostBadRequest ErrorResponse
+
+func (*APITransactionsTransactionIdDisputePostBadRequest) aPITransactionsTransactionIdDisputePostRes() {
+}
+
+type APITransactionsTransactionIdDisputePostConflict ErrorResponse
+
+func (*APITransactionsTransactionIdDisputePostConflict) aPITransactionsTransactionIdDisputePostRes() {
+}
+
+type APITransactionsTransactionIdDisputePostInternalServerError ErrorResponse
+
+func (*APITransactionsTransactionIdDisputePostInternalServerError) aPITransactionsTransactionIdDisputePostRes() {
+}
+
+type APITransactionsTransactionIdDisputePostNotFound ErrorResponse
+
+func (*APITransactionsTransactionIdDisputePostNotFound) aPITransactionsTransactionIdDisputePostRes() {
+}
+
+type APITransactionsTransactionIdDisputePostUnauthorized ErrorResponse
+
+func (*APITransactionsTransactionIdDisputePostUnauthorized) aPITransactionsTransactionIdDisputePostRes() {
+}
+
+type APITransfersPendingGetInternalServerError ErrorResponse
+
+func (*APITransfersPendingGetInternalServerError) aPITransfersPendingGetRes() {}
+
+type APITransfersPendingGetUnauthorized ErrorResponse
+
+func (*APITransfersPendingGetUnauthorized) aPITransfersPendingGetRes() {}
+
+type APITransfersTransferIdAcceptPostBadRequest ErrorResponse
+
+func (*APITransfersTransferIdAcceptPostBadRequest) aPITransfersTransferIdAcceptPostRes() {}
+
+type APITransfersTransferIdAcceptPostConflict ErrorResponse
+
+func (*APITransfersTransferIdAcceptPostConflict) aPITransfersTransferIdAcceptPostRes() {}
+
+type APITransfersTransferIdAcceptPostInternalServerError ErrorResponse
+
+func (*APITransfersTransferIdAcceptPostInternalServerError) aPITransfersTransferIdAcceptPostRes() {}
+
+type APITransfersTransferIdAcceptPostNotFound ErrorResponse
+
+func (*APITransfersTransferIdAcceptPostNotFound) aPITransfersTransferIdAcceptPostRes() {}
+
+type APITransfersTransferIdAcceptPostUnauthorized ErrorResponse
+
+func (*APITransfersTransferIdAcceptPostUnauthorized) aPITransfersTransferIdAcceptPostRes() {}
+
+type APITransfersTransferIdApprovePostBadRequest ErrorResponse
+
+func (*APITransfersTransferIdApprovePostBadRequest) aPITransfersTransferIdApprovePostRes() {}
+
+type APITransfersTransferIdApprovePostConflict ErrorResponse
+
+func (*APITransfersTransferIdApprovePostConflict) aPITransfersTransferIdApprovePostRes() {}
+
+type APITransfersTransferIdApprovePostInternalServerError ErrorResponse
+
+func (*APITransfersTransferIdApprovePostInternalServerError) aPITransfersTransferIdApprovePostRes() {}
+
+type APITransfersTransferIdApprovePostNotFound ErrorResponse
+
+func (*APITransfersTransferIdApprovePostNotFound) aPITransfersTransferIdApprovePostRes() {}
+
+type APITransfersTransferIdApprovePostUnauthorized ErrorResponse
+
+func (*APITransfersTransferIdApprovePostUnauthorized) aPITransfersTransferIdApprovePostRes() {}
+
+type APITransfersTransferIdCancelPostBadRequest ErrorResponse
+
+func (*APITransfersTransferIdCancelPostBadRequest) aPITransfersTransferIdCancelPostRes() {}
+
+type APITransfersTransferIdCancelPostConflict ErrorResponse
+
+func (*APITransfersTransferIdCancelPostConflict) aPITransfersTransferIdCancelPostRes() {}
+
+type APITransfersTransferIdCancelPostInternalServerError ErrorResponse
+
+func (*APITransfersTransferIdCancelPostInternalServerError) aPITransfersTransferIdCancelPostRes() {}
+
+type APITransfersTransferIdCancelPostNotFound ErrorResponse
+
+func (*APITransfersTransferIdCancelPostNotFound) aPITransfersTransferIdCancelPostRes() {}
+
+type APITransfersTransferIdCancelPostUnauthorized ErrorResponse
+
+func (*APITransfersTransferIdCancelPostUnauthorized) aPITransfersTransferIdCancelPostRes() {}
+
+type APITransfersTransferIdDeclinePostBadRequest ErrorResponse
+
+func (*APITransfersTransferIdDeclinePostBadRequest) aPITransfersTransferIdDeclinePostRes() {}
+
+type APITransfersTransferIdDeclinePostConflict ErrorResponse
+
+func (*APITransfersTransferIdDeclinePostConflict) aPITransfersTransferIdDeclinePostRes() {}
+
+type APITransfersTransferIdDeclinePostInternalServerError ErrorResponse
+
+func (*APITransfersTransferIdDeclinePostInternalServerError) aPITransfersTransferIdDeclinePostRes() {}
+
+type APITransfersTransferIdDeclinePostNotFound ErrorResponse
+
+func (*APITransfersTransferIdDeclinePostNotFound) aPITransfersTransferIdDeclinePostRes() {}
+
+type APITransfersTransferIdDeclinePostUnauthorized ErrorResponse
+
+func (*APITransfersTransferIdDeclinePostUnauthorized) aPITransfersTransferIdDeclinePostRes() {}
+
+type APITransfersTransferIdRejectPostBadRequest ErrorResponse
+
+func (*APITransfersTransferIdRejectPostBadRequest) aPITransfersTransferIdRejectPostRes() {}
+
+type APITransfersTransferIdRejectPostConflict ErrorResponse
+
+func (*APITransfersTransferIdRejectPostConflict) aPITransfersTransferIdRejectPostRes() {}
+
+type APITransfersTransferIdRejectPostInternalServerError ErrorResponse
+
+func (*APITransfersTransferIdRejectPostInternalServerError) aPITransfersTransferIdRejectPostRes() {}
+
+type APITransfersTransferIdRejectPostNotFound ErrorResponse
+
+func (*APITransfersTransferIdRejectPostNotFound) aPITransfersTransferIdRejectPostRes() {}
+
+type APITransfersTransferIdRejectPostUnauthorized ErrorResponse
+
+func (*APITransfersTransferIdRejectPostUnauthorized) aPITransfersTransferIdRejectPostRes() {}
+
+// Статус учётной записи.
+// Ref: #/components/schemas/AccountStatus
+type AccountStatus string
+
+const (
+	AccountStatusActive      AccountStatus = "active"
+	AccountStatusSuspended   AccountStatus = "suspended"
+	AccountStatusDeactivated AccountStatus = "deactivated"
+)
+
+// AllValues returns all AccountStatus values.
+func (AccountStatus) AllValues() []AccountStatus {
+	return []AccountStatus{
+		AccountStatusActive,
+		AccountStatusSuspended,
+		AccountStatusDeactivated,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s AccountStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case AccountStatusActive:
+		return []byte(s), nil
+	case AccountStatusSuspended:
+		return []byte(s), nil
+	case AccountStatusDeactivated:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *AccountStatus) UnmarshalText(data []byte) error {
+	switch AccountStatus(data) {
+	case AccountStatusActive:
+		*s = AccountStatusActive
+		return nil
+	case AccountStatusSuspended:
+		*s = AccountStatusSuspended
+		return nil
+	case AccountStatusDeactivated:
+		*s = AccountStatusDeactivated
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/AdminDisputeList
+type AdminDisputeList struct {
+	Disputes []Dispute `json:"disputes"`
+}
+
+// GetDisputes returns the value of Disputes.
+func (s *AdminDisputeList) GetDisputes() []Dispute {
+	return s.Disputes
+}
+
+// SetDisputes sets the value of Disputes.
+func (s *AdminDisputeList) SetDisputes(val []Dispute) {
+	s.Disputes = val
+}
+
+func (*AdminDisputeList) aPIAdminDisputesGetRes() {}
+
+// Ref: #/components/schemas/ApiKey
+type ApiKey struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Начало ключа для опознания; сам ключ не хранится.
+	Prefix     string        `json:"prefix"`
+	Scopes     []ApiKeyScope `json:"scopes"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	ExpiresAt  OptDateTime   `json:"expiresAt"`
+	LastUsedAt OptDateTime   `json:"lastUsedAt"`
+	RevokedAt  OptDateTime   `json:"revokedAt"`
+}
+
+// GetID returns the value of ID.
+func (s *ApiKey) GetID() int {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *ApiKey) GetName() string {
+	return s.Name
+}
+
+// GetPrefix returns the value of Prefix.
+func (s *ApiKey) GetPrefix() string {
+	return s.Prefix
+}
+
+// GetScopes returns the value of Scopes.
+func (s *ApiKey) GetScopes() []ApiKeyScope {
+	return s.Scopes
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *ApiKey) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *ApiKey) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// GetLastUsedAt returns the value of LastUsedAt.
+func (s *ApiKey) GetLastUsedAt() OptDateTime {
+	return s.LastUsedAt
+}
+
+// GetRevokedAt returns the value of RevokedAt.
+func (s *ApiKey) GetRevokedAt() OptDateTime {
+	return s.RevokedAt
+}
+
+// SetID sets the value of ID.
+func (s *ApiKey) SetID(val int) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *ApiKey) SetName(val string) {
+	s.Name = val
+}
+
+// SetPrefix sets the value of Prefix.
+func (s *ApiKey) SetPrefix(val string) {
+	s.Prefix = val
+}
+
+// SetScopes sets the value of Scopes.
+func (s *ApiKey) SetScopes(val []ApiKeyScope) {
+	s.Scopes = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *ApiKey) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *ApiKey) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// SetLastUsedAt sets the value of LastUsedAt.
+func (s *ApiKey) SetLastUsedAt(val OptDateTime) {
+	s.LastUsedAt = val
+}
+
+// SetRevokedAt sets the value of RevokedAt.
+func (s *ApiKey) SetRevokedAt(val OptDateTime) {
+	s.RevokedAt = val
+}
+
+func (*ApiKey) aPIAdminServiceAccountsUsernameKeysKeyIdDeleteRes() {}
+
+// Ref: #/components/schemas/ApiKeyCreatedResponse
+type ApiKeyCreatedResponse struct {
+	// API-ключ; передаётся как Bearer-токен и больше не
+	// показывается.
+	Key    string `json:"key"`
+	ApiKey ApiKey `json:"apiKey"`
+}
+
+// GetKey returns the value of Key.
+func (s *ApiKeyCreatedResponse) GetKey() string {
+	return s.Key
+}
+
+// GetApiKey returns the value of ApiKey.
+func (s *ApiKeyCreatedResponse) GetApiKey() ApiKey {
+	return s.ApiKey
+}
+
+// SetKey sets the value of Key.
+func (s *ApiKeyCreatedResponse) SetKey(val string) {
+	s.Key = val
+}
+
+// SetApiKey sets the value of ApiKey.
+func (s *ApiKeyCreatedResponse) SetApiKey(val ApiKey) {
+	s.ApiKey = val
+}
+
+func (*ApiKeyCreatedResponse) aPIAdminServiceAccountsUsernameKeysPostRes() {}
+
+// Ref: #/components/schemas/ApiKeyList
+type ApiKeyList struct {
+	Keys []ApiKey `json:"keys"`
+}
+
+// GetKeys returns the value of Keys.
+func (s *ApiKeyList) GetKeys() []ApiKey {
+	return s.Keys
+}
+
+// SetKeys sets the value of Keys.
+func (s *ApiKeyList) SetKeys(val []ApiKey) {
+	s.Keys = val
+}
+
+func (*ApiKeyList) aPIAdminServiceAccountsUsernameKeysGetRes() {}
+
+// Ref: #/components/schemas/ApiKeyRequest
+type ApiKeyRequest struct {
+	// Назначение ключа.
+	Name   string        `json:"name"`
+	Scopes []ApiKeyScope `json:"scopes"`
+	// Срок действия; без него ключ бессрочный.
+	ExpiresAt OptDateTime `json:"expiresAt"`
+}
+
+// GetName returns the value of Name.
+func (s *ApiKeyRequest) GetName() string {
+	return s.Name
+}
+
+// GetScopes returns the value of Scopes.
+func (s *ApiKeyRequest) GetScopes() []ApiKeyScope {
+	return s.Scopes
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *ApiKeyRequest) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// SetName sets the value of Name.
+func (s *ApiKeyRequest) SetName(val string) {
+	s.Name = val
+}
+
+// SetScopes sets the value of Scopes.
+func (s *ApiKeyRequest) SetScopes(val []ApiKeyScope) {
+	s.Scopes = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *ApiKeyRequest) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// Разрешение API-ключа: read:info – /api/info, grant:coins – /api/sendCoin,
+// buy:merch – /api/buy/{item}.
+// Ref: #/components/schemas/ApiKeyScope
+type ApiKeyScope string
+
+const (
+	ApiKeyScopeReadInfo   ApiKeyScope = "read:info"
+	ApiKeyScopeGrantCoins ApiKeyScope = "grant:coins"
+	ApiKeyScopeBuyMerch   ApiKeyScope = "buy:merch"
+)
+
+// AllValues returns all ApiKeyScope values.
+func (ApiKeyScope) AllValues() []ApiKeyScope {
+	return []ApiKeyScope{
+		ApiKeyScopeReadInfo,
+		ApiKeyScopeGrantCoins,
+		ApiKeyScopeBuyMerch,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ApiKeyScope) MarshalText() ([]byte, error) {
+	switch s {
+	case ApiKeyScopeReadInfo:
+		return []byte(s), nil
+	case ApiKeyScopeGrantCoins:
+		return []byte(s), nil
+	case ApiKeyScopeBuyMerch:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ApiKeyScope) UnmarshalText(data []byte) error {
+	switch ApiKeyScope(data) {
+	case ApiKeyScopeReadInfo:
+		*s = ApiKeyScopeReadInfo
+		return nil
+	case ApiKeyScopeGrantCoins:
+		*s = ApiKeyScopeGrantCoins
+		return nil
+	case ApiKeyScopeBuyMerch:
+		*s = ApiKeyScopeBuyMerch
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/AuthRequest
+type AuthRequest struct {
+	// Имя пользователя для аутентификации.
+	Username string `json:"username"`
+	// Пароль для аутентификации.
+	Password string `json:"password"`
+}
+
+// GetUsername returns the value of Username.
+func (s *AuthRequest) GetUsername() string {
+	return s.Username
+}
+
+// GetPassword returns the value of Password.
+func (s *AuthRequest) GetPassword() string {
+	return s.Password
+}
+
+// SetUsername sets the value of Username.
+func (s *AuthRequest) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetPassword sets the value of Password.
+func (s *AuthRequest) SetPassword(val string) {
+	s.Password = val
+}
+
+// Ref: #/components/schemas/AuthResponse
+type AuthResponse struct {
+	// JWT-токен для доступа к защищенным ресурсам.
+	Token OptString `json:"token"`
+	// Требуется второй шаг входа через /api/auth/2fa.
+	MfaRequired OptBool `json:"mfaRequired"`
+	// Короткоживущий токен второго шага входа; не даёт
+	// доступа к API.
+	MfaToken OptString `json:"mfaToken"`
+}
+
+// GetToken returns the value of Token.
+func (s *AuthResponse) GetToken() OptString {
+	return s.Token
+}
+
+// GetMfaRequired returns the value of MfaRequired.
+func (s *AuthResponse) GetMfaRequired() OptBool {
+	return s.MfaRequired
+}
+
+// GetMfaToken returns the value of MfaToken.
+func (s *AuthResponse) GetMfaToken() OptString {
+	return s.MfaToken
+}
+
+// SetToken sets the value of Token.
+func (s *AuthResponse) SetToken(val OptString) {
+	s.Token = val
+}
+
+// SetMfaRequired sets the value of MfaRequired.
+func (s *AuthResponse) SetMfaRequired(val OptBool) {
+	s.MfaRequired = val
+}
+
+// SetMfaToken sets the value of MfaToken.
+func (s *AuthResponse) SetMfaToken(val OptString) {
+	s.MfaToken = val
+}
+
+func (*AuthResponse) aPIAuth2FAPostRes()          {}
+func (*AuthResponse) aPIAuthOidcCallbackPostRes() {}
+func (*AuthResponse) aPIAuthPostRes()             {}
+func (*AuthResponse) aPIPasswordPostRes()         {}
+func (*AuthResponse) aPIPasswordResetPostRes()    {}
+func (*AuthResponse) aPIRegisterPostRes()         {}
+
+// Куда передаётся остаток баланса: благотворительный
+// счёт или казна.
+// Ref: #/components/schemas/BalanceDisposition
+type BalanceDisposition string
+
+const (
+	BalanceDispositionCharity  BalanceDisposition = "charity"
+	BalanceDispositionTreasury BalanceDisposition = "treasury"
+)
+
+// AllValues returns all BalanceDisposition values.
+func (BalanceDisposition) AllValues() []BalanceDisposition {
+	return []BalanceDisposition{
+		BalanceDispositionCharity,
+		BalanceDispositionTreasury,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BalanceDisposition) MarshalText() ([]byte, error) {
+	switch s {
+	case BalanceDispositionCharity:
+		return []byte(s), nil
+	case BalanceDispositionTreasury:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *BalanceDisposition) UnmarshalText(data []byte) error {
+	switch BalanceDisposition(data) {
+	case BalanceDispositionCharity:
+		*s = BalanceDispositionCharity
+		return nil
+	case BalanceDispositionTreasury:
+		*s = BalanceDispositionTreasury
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/BatchSendCoinRequest
+type BatchSendCoinRequest struct {
+	// Получатели. Каждый получатель указывается один раз.
+	Transfers []BatchTransfer `json:"transfers"`
+	// Сумма, которая делится поровну между получателями;
+	// остаток от деления достаётся первым получателям
+	// списка по одной монете.
+	TotalAmount OptInt `json:"totalAmount"`
+	// Сообщение, общее для всех получателей.
+	Message  OptString           `json:"message"`
+	Category OptTransferCategory `json:"category"`
+	// Показывать перевод в ленте благодарностей /api/kudos. По
+	// умолчанию перевод виден только участникам.
+	Public OptBool `json:"public"`
+	// Сначала расходовать бюджет на благодарности, а
+	// недостающее – с личного баланса. По умолчанию
+	// перевод идёт с личного баланса.
+	UseBudget OptBool `json:"useBudget"`
+}
+
+// GetTransfers returns the value of Transfers.
+func (s *BatchSendCoinRequest) GetTransfers() []BatchTransfer {
+	return s.Transfers
+}
+
+// GetTotalAmount returns the value of TotalAmount.
+func (s *BatchSendCoinRequest) GetTotalAmount() OptInt {
+	return s.TotalAmount
+}
+
+// GetMessage returns the value of Message.
+func (s *BatchSendCoinRequest) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCategory returns the value of Category.
+func (s *BatchSendCoinRequest) GetCategory() OptTransferCategory {
+	return s.Category
+}
+
+// GetPublic returns the value of Public.
+func (s *BatchSendCoinRequest) GetPublic() OptBool {
+	return s.Public
+}
+
+// GetUseBudget returns the value of UseBudget.
+func (s *BatchSendCoinRequest) GetUseBudget() OptBool {
+	return s.UseBudget
+}
+
+// SetTransfers sets the value of Transfers.
+func (s *BatchSendCoinRequest) SetTransfers(val []BatchTransfer) {
+	s.Transfers = val
+}
+
+// SetTotalAmount sets the value of TotalAmount.
+func (s *BatchSendCoinRequest) SetTotalAmount(val OptInt) {
+	s.TotalAmount = val
+}
+
+// SetMessage sets the value of Message.
+func (s *BatchSendCoinRequest) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCategory sets the value of Category.
+func (s *BatchSendCoinRequest) SetCategory(val OptTransferCategory) {
+	s.Category = val
+}
+
+// SetPublic sets the value of Public.
+func (s *BatchSendCoinRequest) SetPublic(val OptBool) {
+	s.Public = val
+}
+
+// SetUseBudget sets the value of UseBudget.
+func (s *BatchSendCoinRequest) SetUseBudget(val OptBool) {
+	s.UseBudget = val
+}
+
+// Ref: #/components/schemas/BatchSendCoinResponse
+type BatchSendCoinResponse struct {
+	// Сумма всех переводов.
+	TotalAmount int                   `json:"totalAmount"`
+	Transfers   []BatchTransferResult `json:"transfers"`
+}
+
+// GetTotalAmount returns the value of TotalAmount.
+func (s *BatchSendCoinResponse) GetTotalAmount() int {
+	return s.TotalAmount
+}
+
+// GetTransfers returns the value of Transfers.
+func (s *BatchSendCoinResponse) GetTransfers() []BatchTransferResult {
+	return s.Transfers
+}
+
+// SetTotalAmount sets the value of TotalAmount.
+func (s *BatchSendCoinResponse) SetTotalAmount(val int) {
+	s.TotalAmount = val
+}
+
+// SetTransfers sets the value of Transfers.
+func (s *BatchSendCoinResponse) SetTransfers(val []BatchTransferResult) {
+	s.Transfers = val
+}
+
+func (*BatchSendCoinResponse) aPISendCoinBatchPostRes() {}
+
+// Ref: #/components/schemas/BatchTransfer
+type BatchTransfer struct {
+	// Имя пользователя, которому нужно отправить монеты.
+	ToUser string `json:"toUser"`
+	// Количество монет получателю. Не указывается, если
+	// задан totalAmount.
+	Amount OptInt `json:"amount"`
+}
+
+// GetToUser returns the value of ToUser.
+func (s *BatchTransfer) GetToUser() string {
+	return s.ToUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *BatchTransfer) GetAmount() OptInt {
+	return s.Amount
+}
+
+// SetToUser sets the value of ToUser.
+func (s *BatchTransfer) SetToUser(val string) {
+	s.ToUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *BatchTransfer) SetAmount(val OptInt) {
+	s.Amount = val
+}
+
+// Ref: #/components/schemas/BatchTransferResult
+type BatchTransferResult struct {
+	// Имя получателя.
+	ToUser string `json:"toUser"`
+	// Количество отправленных монет.
+	Amount int `json:"amount"`
+	// Идентификатор перевода; только для выполненных
+	// переводов.
+	TransactionId OptInt         `json:"transactionId"`
+	Status        TransferStatus `json:"status"`
+	// Идентификатор ожидающего перевода.
+	PendingTransferId OptInt `json:"pendingTransferId"`
+	// Сколько монет перевода взято из бюджета на
+	// благодарности.
+	FromBudget OptInt `json:"fromBudget"`
+}
+
+// GetToUser returns the value of ToUser.
+func (s *BatchTransferResult) GetToUser() string {
+	return s.ToUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *BatchTransferResult) GetAmount() int {
+	return s.Amount
+}
+
+// GetTransactionId returns the value of TransactionId.
+func (s *BatchTransferResult) GetTransactionId() OptInt {
+	return s.TransactionId
+}
+
+// GetStatus returns the value of Status.
+func (s *BatchTransferResult) GetStatus() TransferStatus {
+	return s.Status
+}
+
+// GetPendingTransferId returns the value of PendingTransferId.
+func (s *BatchTransferResult) GetPendingTransferId() OptInt {
+	return s.PendingTransferId
+}
+
+// GetFromBudget returns the value of FromBudget.
+func (s *BatchTransferResult) GetFromBudget() OptInt {
+	return s.FromBudget
+}
+
+// SetToUser sets the value of ToUser.
+func (s *BatchTransferResult) SetToUser(val string) {
+	s.ToUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *BatchTransferResult) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetTransactionId sets the value of TransactionId.
+func (s *BatchTransferResult) SetTransactionId(val OptInt) {
+	s.TransactionId = val
+}
+
+// SetStatus sets the value of Status.
+func (s *BatchTransferResult) SetStatus(val TransferStatus) {
+	s.Status = val
+}
+
+// SetPendingTransferId sets the value of PendingTransferId.
+func (s *BatchTransferResult) SetPendingTransferId(val OptInt) {
+	s.PendingTransferId = val
+}
+
+// SetFromBudget sets the value of FromBudget.
+func (s *BatchTransferResult) SetFromBudget(val OptInt) {
+	s.FromBudget = val
+}
+
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// Ref: #/components/schemas/ChangePasswordRequest
+type ChangePasswordRequest struct {
+	// Текущий пароль.
+	OldPassword string `json:"oldPassword"`
+	// Новый пароль.
+	NewPassword string `json:"newPassword"`
+}
+
+// GetOldPassword returns the value of OldPassword.
+func (s *ChangePasswordRequest) GetOldPassword() string {
+	return s.OldPassword
+}
+
+// GetNewPassword returns the value of NewPassword.
+func (s *ChangePasswordRequest) GetNewPassword() string {
+	return s.NewPassword
+}
+
+// SetOldPassword sets the value of OldPassword.
+func (s *ChangePasswordRequest) SetOldPassword(val string) {
+	s.OldPassword = val
+}
+
+// SetNewPassword sets the value of NewPassword.
+func (s *ChangePasswordRequest) SetNewPassword(val string) {
+	s.NewPassword = val
+}
+
+// Ref: #/components/schemas/Dispute
+type Dispute struct {
+	ID int `json:"id"`
+	// Оспариваемый перевод.
+	TransactionId int `json:"transactionId"`
+	// Отправитель перевода, открывший спор.
+	FromUser string `json:"fromUser"`
+	// Получатель перевода.
+	ToUser string `json:"toUser"`
+	// Сумма перевода.
+	Amount int           `json:"amount"`
+	Reason string        `json:"reason"`
+	Status DisputeStatus `json:"status"`
+	// Сколько монет возвращено отправителю.
+	ReversedAmount OptInt `json:"reversedAmount"`
+	// Сколько монет не удалось вернуть, потому что
+	// получатель их уже потратил.
+	Shortfall OptInt `json:"shortfall"`
+	// Компенсирующий перевод.
+	ReversalTransactionId OptInt      `json:"reversalTransactionId"`
+	CreatedAt             time.Time   `json:"createdAt"`
+	ResolvedAt            OptDateTime `json:"resolvedAt"`
+	ResolvedBy            OptString   `json:"resolvedBy"`
+	Note                  OptString   `json:"note"`
+}
+
+// GetID returns the value of ID.
+func (s *Dispute) GetID() int {
+	return s.ID
+}
+
+// GetTransactionId returns the value of TransactionId.
+func (s *Dispute) GetTransactionId() int {
+	return s.TransactionId
+}
+
+// GetFromUser returns the value of FromUser.
+func (s *Dispute) GetFromUser() string {
+	return s.FromUser
+}
+
+// GetToUser returns the value of ToUser.
+func (s *Dispute) GetToUser() string {
+	return s.ToUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *Dispute) GetAmount() int {
+	return s.Amount
+}
+
+// GetReason returns the value of Reason.
+func (s *Dispute) GetReason() string {
+	return s.Reason
+}
+
+// GetStatus returns the value of Status.
+func (s *Dispute) GetStatus() DisputeStatus {
+	return s.Status
+}
+
+// GetReversedAmount returns the value of ReversedAmount.
+func (s *Dispute) GetReversedAmount() OptInt {
+	return s.ReversedAmount
+}
+
+// GetShortfall returns the value of Shortfall.
+func (s *Dispute) GetShortfall() OptInt {
+	return s.Shortfall
+}
+
+// GetReversalTransactionId returns the value of ReversalTransactionId.
+func (s *Dispute) GetReversalTransactionId() OptInt {
+	return s.ReversalTransactionId
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Dispute) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetResolvedAt returns the value of ResolvedAt.
+func (s *Dispute) GetResolvedAt() OptDateTime {
+	return s.ResolvedAt
+}
+
+// GetResolvedBy returns the value of ResolvedBy.
+func (s *Dispute) GetResolvedBy() OptString {
+	return s.ResolvedBy
+}
+
+// GetNote returns the value of Note.
+func (s *Dispute) GetNote() OptString {
+	return s.Note
+}
+
+// SetID sets the value of ID.
+func (s *Dispute) SetID(val int) {
+	s.ID = val
+}
+
+// SetTransactionId sets the value of TransactionId.
+func (s *Dispute) SetTransactionId(val int) {
+	s.TransactionId = val
+}
+
+// SetFromUser sets the value of FromUser.
+func (s *Dispute) SetFromUser(val string) {
+	s.FromUser = val
+}
+
+// SetToUser sets the value of ToUser.
+func (s *Dispute) SetToUser(val string) {
+	s.ToUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *Dispute) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetReason sets the value of Reason.
+func (s *Dispute) SetReason(val string) {
+	s.Reason = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Dispute) SetStatus(val DisputeStatus) {
+	s.Status = val
+}
+
+// SetReversedAmount sets the value of ReversedAmount.
+func (s *Dispute) SetReversedAmount(val OptInt) {
+	s.ReversedAmount = val
+}
+
+// SetShortfall sets the value of Shortfall.
+func (s *Dispute) SetShortfall(val OptInt) {
+	s.Shortfall = val
+}
+
+// SetReversalTransactionId sets the value of ReversalTransactionId.
+func (s *Dispute) SetReversalTransactionId(val OptInt) {
+	s.ReversalTransactionId = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Dispute) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetResolvedAt sets the value of ResolvedAt.
+func (s *Dispute) SetResolvedAt(val OptDateTime) {
+	s.ResolvedAt = val
+}
+
+// SetResolvedBy sets the value of ResolvedBy.
+func (s *Dispute) SetResolvedBy(val OptString) {
+	s.ResolvedBy = val
+}
+
+// SetNote sets the value of Note.
+func (s *Dispute) SetNote(val OptString) {
+	s.Note = val
+}
+
+func (*Dispute) aPIAdminDisputesDisputeIdResolvePostRes()    {}
+func (*Dispute) aPIDisputesDisputeIdReturnPostRes()          {}
+func (*Dispute) aPITransactionsTransactionIdDisputePostRes() {}
+
+// Reverse – отменить перевод; reject – отказать.
+// Ref: #/components/schemas/DisputeDecision
+type DisputeDecision string
+
+const (
+	DisputeDecisionReverse DisputeDecision = "reverse"
+	DisputeDecisionReject  DisputeDecision = "reject"
+)
+
+// AllValues returns all DisputeDecision values.
+func (DisputeDecision) AllValues() []DisputeDecision {
+	return []DisputeDecision{
+		DisputeDecisionReverse,
+		DisputeDecisionReject,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s DisputeDecision) MarshalText() ([]byte, error) {
+	switch s {
+	case DisputeDecisionReverse:
+		return []byte(s), nil
+	case DisputeDecisionReject:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *DisputeDecision) UnmarshalText(data []byte) error {
+	switch DisputeDecision(data) {
+	case DisputeDecisionReverse:
+		*s = DisputeDecisionReverse
+		return nil
+	case DisputeDecisionReject:
+		*s = DisputeDecisionReject
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/DisputeList
+type DisputeList struct {
+	// Споры по переводам пользователя.
+	Opened []Dispute `json:"opened"`
+	// Споры по переводам пользователю.
+	Received []Dispute `json:"received"`
+}
+
+// GetOpened returns the value of Opened.
+func (s *DisputeList) GetOpened() []Dispute {
+	return s.Opened
+}
+
+// GetReceived returns the value of Received.
+func (s *DisputeList) GetReceived() []Dispute {
+	return s.Received
+}
+
+// SetOpened sets the value of Opened.
+func (s *DisputeList) SetOpened(val []Dispute) {
+	s.Opened = val
+}
+
+// SetReceived sets the value of Received.
+func (s *DisputeList) SetReceived(val []Dispute) {
+	s.Received = val
+}
+
+func (*DisputeList) aPIDisputesGetRes() {}
+
+// Ref: #/components/schemas/DisputeRequest
+type DisputeRequest struct {
+	// Почему перевод нужно отменить.
+	Reason string `json:"reason"`
+}
+
+// GetReason returns the value of Reason.
+func (s *DisputeRequest) GetReason() string {
+	return s.Reason
+}
+
+// SetReason sets the value of Reason.
+func (s *DisputeRequest) SetReason(val string) {
+	s.Reason = val
+}
+
+// Ref: #/components/schemas/DisputeResolveRequest
+type DisputeResolveRequest struct {
+	Decision DisputeDecision `json:"decision"`
+	// Если получатель уже потратил монеты, списать всю
+	// сумму, уведя баланс в минус. По умолчанию списывается
+	// только остаток баланса.
+	AllowNegativeBalance OptBool `json:"allowNegativeBalance"`
+	// Комментарий администратора.
+	Note OptString `json:"note"`
+}
+
+// GetDecision returns the value of Decision.
+func (s *DisputeResolveRequest) GetDecision() DisputeDecision {
+	return s.Decision
+}
+
+// GetAllowNegativeBalance returns the value of AllowNegativeBalance.
+func (s *DisputeResolveRequest) GetAllowNegativeBalance() OptBool {
+	return s.AllowNegativeBalance
+}
+
+// GetNote returns the value of Note.
+func (s *DisputeResolveRequest) GetNote() OptString {
+	return s.Note
+}
+
+// SetDecision sets the value of Decision.
+func (s *DisputeResolveRequest) SetDecision(val DisputeDecision) {
+	s.Decision = val
+}
+
+// SetAllowNegativeBalance sets the value of AllowNegativeBalance.
+func (s *DisputeResolveRequest) SetAllowNegativeBalance(val OptBool) {
+	s.AllowNegativeBalance = val
+}
+
+// SetNote sets the value of Note.
+func (s *DisputeResolveRequest) SetNote(val OptString) {
+	s.Note = val
+}
+
+// Open – ожидает решения; returned – получатель вернул
+// монеты; reversed – администратор отменил перевод; rejected –
+// администратор отказал в отмене.
+// Ref: #/components/schemas/DisputeStatus
+type DisputeStatus string
+
+const (
+	DisputeStatusOpen     DisputeStatus = "open"
+	DisputeStatusReturned DisputeStatus = "returned"
+	DisputeStatusReversed DisputeStatus = "reversed"
+	DisputeStatusRejected DisputeStatus = "rejected"
+)
+
+// AllValues returns all DisputeStatus values.
+func (DisputeStatus) AllValues() []DisputeStatus {
+	return []DisputeStatus{
+		DisputeStatusOpen,
+		DisputeStatusReturned,
+		DisputeStatusReversed,
+		DisputeStatusRejected,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s DisputeStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case DisputeStatusOpen:
+		return []byte(s), nil
+	case DisputeStatusReturned:
+		return []byte(s), nil
+	case DisputeStatusReversed:
+		return []byte(s), nil
+	case DisputeStatusRejected:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *DisputeStatus) UnmarshalText(data []byte) error {
+	switch DisputeStatus(data) {
+	case DisputeStatusOpen:
+		*s = DisputeStatusOpen
+		return nil
+	case DisputeStatusReturned:
+		*s = DisputeStatusReturned
+		return nil
+	case DisputeStatusReversed:
+		*s = DisputeStatusReversed
+		return nil
+	case DisputeStatusRejected:
+		*s = DisputeStatusRejected
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/EmployeeChange
+type EmployeeChange struct {
+	Username string        `json:"username"`
+	Changes  []FieldChange `json:"changes"`
+}
+
+// GetUsername returns the value of Username.
+func (s *EmployeeChange) GetUsername() string {
+	return s.Username
+}
+
+// GetChanges returns the value of Changes.
+func (s *EmployeeChange) GetChanges() []FieldChange {
+	return s.Changes
+}
+
+// SetUsername sets the value of Username.
+func (s *EmployeeChange) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetChanges sets the value of Changes.
+func (s *EmployeeChange) SetChanges(val []FieldChange) {
+	s.Changes = val
+}
+
+// Ref: #/components/schemas/EmployeeRecord
+type EmployeeRecord struct {
+	// Имя пользователя сотрудника.
+	Username string `json:"username"`
+	// Отображаемое имя.
+	DisplayName OptString `json:"displayName"`
+	// Подразделение.
+	Department OptString `json:"department"`
+	// Имя пользователя руководителя.
+	Manager OptString `json:"manager"`
+	// Дата найма.
+	HireDate OptDate `json:"hireDate"`
+}
+
+// GetUsername returns the value of Username.
+func (s *EmployeeRecord) GetUsername() string {
+	return s.Username
+}
+
+// GetDisplayName returns the value of DisplayName.
+func (s *EmployeeRecord) GetDisplayName() OptString {
+	return s.DisplayName
+}
+
+// GetDepartment returns the value of Department.
+func (s *EmployeeRecord) GetDepartment() OptString {
+	return s.Department
+}
+
+// GetManager returns the value of Manager.
+func (s *EmployeeRecord) GetManager() OptString {
+	return s.Manager
+}
+
+// GetHireDate returns the value of HireDate.
+func (s *EmployeeRecord) GetHireDate() OptDate {
+	return s.HireDate
+}
+
+// SetUsername sets the value of Username.
+func (s *EmployeeRecord) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetDisplayName sets the value of DisplayName.
+func (s *EmployeeRecord) SetDisplayName(val OptString) {
+	s.DisplayName = val
+}
+
+// SetDepartment sets the value of Department.
+func (s *EmployeeRecord) SetDepartment(val OptString) {
+	s.Department = val
+}
+
+// SetManager sets the value of Manager.
+func (s *EmployeeRecord) SetManager(val OptString) {
+	s.Manager = val
+}
+
+// SetHireDate sets the value of HireDate.
+func (s *EmployeeRecord) SetHireDate(val OptDate) {
+	s.HireDate = val
+}
+
+// Ошибка в поле запроса.
+// Ref: #/components/schemas/ErrorDetail
+type ErrorDetail struct {
+	// Имя поля.
+	Field string `json:"field"`
+	// Машиночитаемый код ошибки поля.
+	Code string `json:"code"`
+	// Описание ошибки.
+	Message string `json:"message"`
+}
+
+// GetField returns the value of Field.
+func (s *ErrorDetail) GetField() string {
+	return s.Field
+}
+
+// GetCode returns the value of Code.
+func (s *ErrorDetail) GetCode() string {
+	return s.Code
+}
+
+// GetMessage returns the value of Message.
+func (s *ErrorDetail) GetMessage() string {
+	return s.Message
+}
+
+// SetField sets the value of Field.
+func (s *ErrorDetail) SetField(val string) {
+	s.Field = val
+}
+
+// SetCode sets the value of Code.
+func (s *ErrorDetail) SetCode(val string) {
+	s.Code = val
+}
+
+// SetMessage sets the value of Message.
+func (s *ErrorDetail) SetMessage(val string) {
+	s.Message = val
+}
+
+// Ref: #/components/schemas/ErrorResponse
+type ErrorResponse struct {
+	// Сообщение об ошибке, описывающее проблему.
+	Errors OptString `json:"errors"`
+	// Машиночитаемый код ошибки (расширение схемы из
+	// задания).
+	Code OptString `json:"code"`
+	// Ошибки отдельных полей запроса (расширение схемы из
+	// задания).
+	Details []ErrorDetail `json:"details"`
+}
+
+// GetErrors returns the value of Errors.
+func (s *ErrorResponse) GetErrors() OptString {
+	return s.Errors
+}
+
+// GetCode returns the value of Code.
+func (s *ErrorResponse) GetCode() OptString {
+	return s.Code
+}
+
+// GetDetails returns the value of Details.
+func (s *ErrorResponse) GetDetails() []ErrorDetail {
+	return s.Details
+}
+
+// SetErrors sets the value of Errors.
+func (s *ErrorResponse) SetErrors(val OptString) {
+	s.Errors = val
+}
+
+// SetCode sets the value of Code.
+func (s *ErrorResponse) SetCode(val OptString) {
+	s.Code = val
+}
+
+// SetDetails sets the value of Details.
+func (s *ErrorResponse) SetDetails(val []ErrorDetail) {
+	s.Details = val
+}
+
+// Ref: #/components/schemas/FieldChange
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// GetField returns the value of Field.
+func (s *FieldChange) GetField() string {
+	return s.Field
+}
+
+// GetOld returns the value of Old.
+func (s *FieldChange) GetOld() string {
+	return s.Old
+}
+
+// GetNew returns the value of New.
+func (s *FieldChange) GetNew() string {
+	return s.New
+}
+
+// SetField sets the value of Field.
+func (s *FieldChange) SetField(val string) {
+	s.Field = val
+}
+
+// SetOld sets the value of Old.
+func (s *FieldChange) SetOld(val string) {
+	s.Old = val
+}
+
+// SetNew sets the value of New.
+func (s *FieldChange) SetNew(val string) {
+	s.New = val
+}
+
+// Ref: #/components/schemas/FraudFlag
+type FraudFlag struct {
+	ID int `json:"id"`
+	// Пользователь, к которому относится отметка.
+	Username string        `json:"username"`
+	Kind     FraudFlagKind `json:"kind"`
+	// Другие участники: отправители для fan_in, остальные
+	// пользователи цикла для cycle.
+	RelatedUsers []string `json:"relatedUsers"`
+	// Сумма переводов, на основании которых поставлена
+	// отметка.
+	Amount OptInt `json:"amount"`
+	// Учётная запись заблокирована анализатором до
+	// проверки.
+	Frozen    bool      `json:"frozen"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Когда признак обнаружен последний раз.
+	LastSeenAt time.Time              `json:"lastSeenAt"`
+	ResolvedAt OptDateTime            `json:"resolvedAt"`
+	ResolvedBy OptString              `json:"resolvedBy"`
+	Resolution OptFraudFlagResolution `json:"resolution"`
+}
+
+// GetID returns the value of ID.
+func (s *FraudFlag) GetID() int {
+	return s.ID
+}
+
+// GetUsername returns the value of Username.
+func (s *FraudFlag) GetUsername() string {
+	return s.Username
+}
+
+// GetKind returns the value of Kind.
+func (s *FraudFlag) GetKind() FraudFlagKind {
+	return s.Kind
+}
+
+// GetRelatedUsers returns the value of RelatedUsers.
+func (s *FraudFlag) GetRelatedUsers() []string {
+	return s.RelatedUsers
+}
+
+// GetAmount returns the value of Amount.
+func (s *FraudFlag) GetAmount() OptInt {
+	return s.Amount
+}
+
+// GetFrozen returns the value of Frozen.
+func (s *FraudFlag) GetFrozen() bool {
+	return s.Frozen
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *FraudFlag) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetLastSeenAt returns the value of LastSeenAt.
+func (s *FraudFlag) GetLastSeenAt() time.Time {
+	return s.LastSeenAt
+}
+
+// GetResolvedAt returns the value of ResolvedAt.
+func (s *FraudFlag) GetResolvedAt() OptDateTime {
+	return s.ResolvedAt
+}
+
+// GetResolvedBy returns the value of ResolvedBy.
+func (s *FraudFlag) GetResolvedBy() OptString {
+	return s.ResolvedBy
+}
+
+// GetResolution returns the value of Resolution.
+func (s *FraudFlag) GetResolution() OptFraudFlagResolution {
+	return s.Resolution
+}
+
+// SetID sets the value of ID.
+func (s *FraudFlag) SetID(val int) {
+	s.ID = val
+}
+
+// SetUsername sets the value of Username.
+func (s *FraudFlag) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetKind sets the value of Kind.
+func (s *FraudFlag) SetKind(val FraudFlagKind) {
+	s.Kind = val
+}
+
+// SetRelatedUsers sets the value of RelatedUsers.
+func (s *FraudFlag) SetRelatedUsers(val []string) {
+	s.RelatedUsers = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *FraudFlag) SetAmount(val OptInt) {
+	s.Amount = val
+}
+
+// SetFrozen sets the value of Frozen.
+func (s *FraudFlag) SetFrozen(val bool) {
+	s.Frozen = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *FraudFlag) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetLastSeenAt sets the value of LastSeenAt.
+func (s *FraudFlag) SetLastSeenAt(val time.Time) {
+	s.LastSeenAt = val
+}
+
+// SetResolvedAt sets the value of ResolvedAt.
+func (s *FraudFlag) SetResolvedAt(val OptDateTime) {
+	s.ResolvedAt = val
+}
+
+// SetResolvedBy sets the value of ResolvedBy.
+func (s *FraudFlag) SetResolvedBy(val OptString) {
+	s.ResolvedBy = val
+}
+
+// SetResolution sets the value of Resolution.
+func (s *FraudFlag) SetResolution(val OptFraudFlagResolution) {
+	s.Resolution = val
+}
+
+func (*FraudFlag) aPIAdminFraudFlagsFlagIdResolvePostRes() {}
+
+// Признак: fan_in – переводы от только что созданных
+// учётных записей, cycle – круговые переводы, volume –
+// необычно большой объём переводов.
+// Ref: #/components/schemas/FraudFlagKind
+type FraudFlagKind string
+
+const (
+	FraudFlagKindFanIn  FraudFlagKind = "fan_in"
+	FraudFlagKindCycle  FraudFlagKind = "cycle"
+	FraudFlagKindVolume FraudFlagKind = "volume"
+)
+
+// AllValues returns all FraudFlagKind values.
+func (FraudFlagKind) AllValues() []FraudFlagKind {
+	return []FraudFlagKind{
+		FraudFlagKindFanIn,
+		FraudFlagKindCycle,
+		FraudFlagKindVolume,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s FraudFlagKind) MarshalText() ([]byte, error) {
+	switch s {
+	case FraudFlagKindFanIn:
+		return []byte(s), nil
+	case FraudFlagKindCycle:
+		return []byte(s), nil
+	case FraudFlagKindVolume:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *FraudFlagKind) UnmarshalText(data []byte) error {
+	switch FraudFlagKind(data) {
+	case FraudFlagKindFanIn:
+		*s = FraudFlagKindFanIn
+		return nil
+	case FraudFlagKindCycle:
+		*s = FraudFlagKindCycle
+		return nil
+	case FraudFlagKindVolume:
+		*s = FraudFlagKindVolume
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/FraudFlagList
+type FraudFlagList struct {
+	Flags []FraudFlag `json:"flags"`
+}
+
+// GetFlags returns the value of Flags.
+func (s *FraudFlagList) GetFlags() []FraudFlag {
+	return s.Flags
+}
+
+// SetFlags sets the value of Flags.
+func (s *FraudFlagList) SetFlags(val []FraudFlag) {
+	s.Flags = val
+}
+
+func (*FraudFlagList) aPIAdminFraudFlagsGetRes() {}
+
+// Dismissed – подозрение не подтвердилось, confirmed –
+// подтвердилось.
+// Ref: #/components/schemas/FraudFlagResolution
+type FraudFlagResolution string
+
+const (
+	FraudFlagResolutionDismissed FraudFlagResolution = "dismissed"
+	FraudFlagResolutionConfirmed FraudFlagResolution = "confirmed"
+)
+
+// AllValues returns all FraudFlagResolution values.
+func (FraudFlagResolution) AllValues() []FraudFlagResolution {
+	return []FraudFlagResolution{
+		FraudFlagResolutionDismissed,
+		FraudFlagResolutionConfirmed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s FraudFlagResolution) MarshalText() ([]byte, error) {
+	switch s {
+	case FraudFlagResolutionDismissed:
+		return []byte(s), nil
+	case FraudFlagResolutionConfirmed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *FraudFlagResolution) UnmarshalText(data []byte) error {
+	switch FraudFlagResolution(data) {
+	case FraudFlagResolutionDismissed:
+		*s = FraudFlagResolutionDismissed
+		return nil
+	case FraudFlagResolutionConfirmed:
+		*s = FraudFlagResolutionConfirmed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/FraudFlagResolveRequest
+type FraudFlagResolveRequest struct {
+	Resolution FraudFlagResolution `json:"resolution"`
+}
+
+// GetResolution returns the value of Resolution.
+func (s *FraudFlagResolveRequest) GetResolution() FraudFlagResolution {
+	return s.Resolution
+}
+
+// SetResolution sets the value of Resolution.
+func (s *FraudFlagResolveRequest) SetResolution(val FraudFlagResolution) {
+	s.Resolution = val
+}
+
+// Ref: #/components/schemas/FraudFlagStatus
+type FraudFlagStatus string
+
+const (
+	FraudFlagStatusOpen     FraudFlagStatus = "open"
+	FraudFlagStatusResolved FraudFlagStatus = "resolved"
+)
+
+// AllValues returns all FraudFlagStatus values.
+func (FraudFlagStatus) AllValues() []FraudFlagStatus {
+	return []FraudFlagStatus{
+		FraudFlagStatusOpen,
+		FraudFlagStatusResolved,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s FraudFlagStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case FraudFlagStatusOpen:
+		return []byte(s), nil
+	case FraudFlagStatusResolved:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *FraudFlagStatus) UnmarshalText(data []byte) error {
+	switch FraudFlagStatus(data) {
+	case FraudFlagStatusOpen:
+		*s = FraudFlagStatusOpen
+		return nil
+	case FraudFlagStatusResolved:
+		*s = FraudFlagStatusResolved
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/FraudScanResult
+type FraudScanResult struct {
+	// Новые отметки.
+	Flagged int `json:"flagged"`
+	// Учётные записи, заблокированные до проверки.
+	Frozen int `json:"frozen"`
+}
+
+// GetFlagged returns the value of Flagged.
+func (s *FraudScanResult) GetFlagged() int {
+	return s.Flagged
+}
+
+// GetFrozen returns the value of Frozen.
+func (s *FraudScanResult) GetFrozen() int {
+	return s.Frozen
+}
+
+// SetFlagged sets the value of Flagged.
+func (s *FraudScanResult) SetFlagged(val int) {
+	s.Flagged = val
+}
+
+// SetFrozen sets the value of Frozen.
+func (s *FraudScanResult) SetFrozen(val int) {
+	s.Frozen = val
+}
+
+func (*FraudScanResult) aPIAdminFraudFlagsScanPostRes() {}
+
+// Ref: #/components/schemas/ImportUsersResponse
+type ImportUsersResponse struct {
+	// Изменения применены; false – только дифф (dry run).
+	Applied  bool             `json:"applied"`
+	Created  []EmployeeRecord `json:"created"`
+	Updated  []EmployeeChange `json:"updated"`
+	Departed []string         `json:"departed"`
+}
+
+// GetApplied returns the value of Applied.
+func (s *ImportUsersResponse) GetApplied() bool {
+	return s.Applied
+}
+
+// GetCreated returns the value of Created.
+func (s *ImportUsersResponse) GetCreated() []EmployeeRecord {
+	return s.Created
+}
+
+// GetUpdated returns the value of Updated.
+func (s *ImportUsersResponse) GetUpdated() []EmployeeChange {
+	return s.Updated
+}
+
+// GetDeparted returns the value of Departed.
+func (s *ImportUsersResponse) GetDeparted() []string {
+	return s.Departed
+}
+
+// SetApplied sets the value of Applied.
+func (s *ImportUsersResponse) SetApplied(val bool) {
+	s.Applied = val
+}
+
+// SetCreated sets the value of Created.
+func (s *ImportUsersResponse) SetCreated(val []EmployeeRecord) {
+	s.Created = val
+}
+
+// SetUpdated sets the value of Updated.
+func (s *ImportUsersResponse) SetUpdated(val []EmployeeChange) {
+	s.Updated = val
+}
+
+// SetDeparted sets the value of Departed.
+func (s *ImportUsersResponse) SetDeparted(val []string) {
+	s.Departed = val
+}
+
+func (*ImportUsersResponse) aPIAdminImportUsersPostRes() {}
+
+// Ref: #/components/schemas/InfoResponse
+type InfoResponse struct {
+	// Количество доступных монет.
+	Coins       OptInt                      `json:"coins"`
+	Inventory   []InfoResponseInventoryItem `json:"inventory"`
+	CoinHistory OptInfoResponseCoinHistory  `json:"coinHistory"`
+	// Бюджет на благодарности текущего периода. Его можно
+	// только переводить другим пользователям; остаток
+	// сгорает в конце периода.
+	GivingBudget OptInfoResponseGivingBudget `json:"givingBudget"`
+	// Ближайшие сгорания монет: сумма, которая сгорит в
+	// этот день, если её не потратить. Монеты тратятся
+	// начиная с самых старых.
+	ExpiringCoins []InfoResponseExpiringCoinsItem `json:"expiringCoins"`
+}
+
+// GetCoins returns the value of Coins.
+func (s *InfoResponse) GetCoins() OptInt {
+	return s.Coins
+}
+
+// GetInventory returns the value of Inventory.
+func (s *InfoResponse) GetInventory() []InfoResponseInventoryItem {
+	return s.Inventory
+}
+
+// GetCoinHistory returns the value of CoinHistory.
+func (s *InfoResponse) GetCoinHistory() OptInfoResponseCoinHistory {
+	return s.CoinHistory
+}
+
+// GetGivingBudget returns the value of GivingBudget.
+func (s *InfoResponse) GetGivingBudget() OptInfoResponseGivingBudget {
+	return s.GivingBudget
+}
+
+// GetExpiringCoins returns the value of ExpiringCoins.
+func (s *InfoResponse) GetExpiringCoins() []InfoResponseExpiringCoinsItem {
+	return s.ExpiringCoins
+}
+
+// SetCoins sets the value of Coins.
+func (s *InfoResponse) SetCoins(val OptInt) {
+	s.Coins = val
+}
+
+// SetInventory sets the value of Inventory.
+func (s *InfoResponse) SetInventory(val []InfoResponseInventoryItem) {
+	s.Inventory = val
+}
+
+// SetCoinHistory sets the value of CoinHistory.
+func (s *InfoResponse) SetCoinHistory(val OptInfoResponseCoinHistory) {
+	s.CoinHistory = val
+}
+
+// SetGivingBudget sets the value of GivingBudget.
+func (s *InfoResponse) SetGivingBudget(val OptInfoResponseGivingBudget) {
+	s.GivingBudget = val
+}
+
+// SetExpiringCoins sets the value of ExpiringCoins.
+func (s *InfoResponse) SetExpiringCoins(val []InfoResponseExpiringCoinsItem) {
+	s.ExpiringCoins = val
+}
+
+func (*InfoResponse) aPIInfoGetRes() {}
+
+type InfoResponseCoinHistory struct {
+	Received []InfoResponseCoinHistoryReceivedItem `json:"received"`
+	Sent     []InfoResponseCoinHistorySentItem     `json:"sent"`
+}
+
+// GetReceived returns the value of Received.
+func (s *InfoResponseCoinHistory) GetReceived() []InfoResponseCoinHistoryReceivedItem {
+	return s.Received
+}
+
+// GetSent returns the value of Sent.
+func (s *InfoResponseCoinHistory) GetSent() []InfoResponseCoinHistorySentItem {
+	return s.Sent
+}
+
+// SetReceived sets the value of Received.
+func (s *InfoResponseCoinHistory) SetReceived(val []InfoResponseCoinHistoryReceivedItem) {
+	s.Received = val
+}
+
+// SetSent sets the value of Sent.
+func (s *InfoResponseCoinHistory) SetSent(val []InfoResponseCoinHistorySentItem) {
+	s.Sent = val
+}
+
+type InfoResponseCoinHistoryReceivedItem struct {
+	// Идентификатор перевода; по нему отправитель
+	// открывает спор.
+	ID OptInt `json:"id"`
+	// Имя пользователя, который отправил монеты.
+	FromUser OptString `json:"fromUser"`
+	// Количество полученных монет.
+	Amount OptInt `json:"amount"`
+	// Сообщение к переводу.
+	Message  OptString           `json:"message"`
+	Category OptTransferCategory `json:"category"`
+	// Перевод, который отменяет этот компенсирующий
+	// перевод.
+	ReversalOf OptInt `json:"reversalOf"`
+}
+
+// GetID returns the value of ID.
+func (s *InfoResponseCoinHistoryReceivedItem) GetID() OptInt {
+	return s.ID
+}
+
+// GetFromUser returns the value of FromUser.
+func (s *InfoResponseCoinHistoryReceivedItem) GetFromUser() OptString {
+	return s.FromUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *InfoResponseCoinHistoryReceivedItem) GetAmount() OptInt {
+	return s.Amount
+}
+
+// GetMessage returns the value of Message.
+func (s *InfoResponseCoinHistoryReceivedItem) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCategory returns the value of Category.
+func (s *InfoResponseCoinHistoryReceivedItem) GetCategory() OptTransferCategory {
+	return s.Category
+}
+
+// GetReversalOf returns the value of ReversalOf.
+func (s *InfoResponseCoinHistoryReceivedItem) GetReversalOf() OptInt {
+	return s.ReversalOf
+}
+
+// SetID sets the value of ID.
+func (s *InfoResponseCoinHistoryReceivedItem) SetID(val OptInt) {
+	s.ID = val
+}
+
+// SetFromUser sets the value of FromUser.
+func (s *InfoResponseCoinHistoryReceivedItem) SetFromUser(val OptString) {
+	s.FromUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *InfoResponseCoinHistoryReceivedItem) SetAmount(val OptInt) {
+	s.Amount = val
+}
+
+// SetMessage sets the value of Message.
+func (s *InfoResponseCoinHistoryReceivedItem) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCategory sets the value of Category.
+func (s *InfoResponseCoinHistoryReceivedItem) SetCategory(val OptTransferCategory) {
+	s.Category = val
+}
+
+// SetReversalOf sets the value of ReversalOf.
+func (s *InfoResponseCoinHistoryReceivedItem) SetReversalOf(val OptInt) {
+	s.ReversalOf = val
+}
+
+type InfoResponseCoinHistorySentItem struct {
+	// Идентификатор перевода; по нему отправитель
+	// открывает спор.
+	ID OptInt `json:"id"`
+	// Имя пользователя, которому отправлены монеты.
+	ToUser OptString `json:"toUser"`
+	// Количество отправленных монет.
+	Amount OptInt `json:"amount"`
+	// Сообщение к переводу.
+	Message  OptString           `json:"message"`
+	Category OptTransferCategory `json:"category"`
+	// Перевод, который отменяет этот компенсирующий
+	// перевод.
+	ReversalOf OptInt `json:"reversalOf"`
+	// Сколько монет перевода взято из бюджета на
+	// благодарности.
+	FromBudget OptInt `json:"fromBudget"`
+}
+
+// GetID returns the value of ID.
+func (s *InfoResponseCoinHistorySentItem) GetID() OptInt {
+	return s.ID
+}
+
+// GetToUser returns the value of ToUser.
+func (s *InfoResponseCoinHistorySentItem) GetToUser() OptString {
+	return s.ToUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *InfoResponseCoinHistorySentItem) GetAmount() OptInt {
+	return s.Amount
+}
+
+// GetMessage returns the value of Message.
+func (s *InfoResponseCoinHistorySentItem) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCategory returns the value of Category.
+func (s *InfoResponseCoinHistorySentItem) GetCategory() OptTransferCategory {
+	return s.Category
+}
+
+// GetReversalOf returns the value of ReversalOf.
+func (s *InfoResponseCoinHistorySentItem) GetReversalOf() OptInt {
+	return s.ReversalOf
+}
+
+// GetFromBudget returns the value of FromBudget.
+func (s *InfoResponseCoinHistorySentItem) GetFromBudget() OptInt {
+	return s.FromBudget
+}
+
+// SetID sets the value of ID.
+func (s *InfoResponseCoinHistorySentItem) SetID(val OptInt) {
+	s.ID = val
+}
+
+// SetToUser sets the value of ToUser.
+func (s *InfoResponseCoinHistorySentItem) SetToUser(val OptString) {
+	s.ToUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *InfoResponseCoinHistorySentItem) SetAmount(val OptInt) {
+	s.Amount = val
+}
+
+// SetMessage sets the value of Message.
+func (s *InfoResponseCoinHistorySentItem) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCategory sets the value of Category.
+func (s *InfoResponseCoinHistorySentItem) SetCategory(val OptTransferCategory) {
+	s.Category = val
+}
+
+// SetReversalOf sets the value of ReversalOf.
+func (s *InfoResponseCoinHistorySentItem) SetReversalOf(val OptInt) {
+	s.ReversalOf = val
+}
+
+// SetFromBudget sets the value of FromBudget.
+func (s *InfoResponseCoinHistorySentItem) SetFromBudget(val OptInt) {
+	s.FromBudget = val
+}
+
+type InfoResponseExpiringCoinsItem struct {
+	// Сколько монет сгорит.
+	Amount int `json:"amount"`
+	// Когда монеты сгорят.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// GetAmount returns the value of Amount.
+func (s *InfoResponseExpiringCoinsItem) GetAmount() int {
+	return s.Amount
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *InfoResponseExpiringCoinsItem) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// SetAmount sets the value of Amount.
+func (s *InfoResponseExpiringCoinsItem) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *InfoResponseExpiringCoinsItem) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+// Бюджет на благодарности текущего периода. Его можно
+// только переводить другим пользователям; остаток
+// сгорает в конце периода.
+type InfoResponseGivingBudget struct {
+	// Начислено на период.
+	Granted int `json:"granted"`
+	// Осталось.
+	Remaining int `json:"remaining"`
+	// Конец периода.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// GetGranted returns the value of Granted.
+func (s *InfoResponseGivingBudget) GetGranted() int {
+	return s.Granted
+}
+
+// GetRemaining returns the value of Remaining.
+func (s *InfoResponseGivingBudget) GetRemaining() int {
+	return s.Remaining
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *InfoResponseGivingBudget) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// SetGranted sets the value of Granted.
+func (s *InfoResponseGivingBudget) SetGranted(val int) {
+	s.Granted = val
+}
+
+// SetRemaining sets the value of Remaining.
+func (s *InfoResponseGivingBudget) SetRemaining(val int) {
+	s.Remaining = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *InfoResponseGivingBudget) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+type InfoResponseInventoryItem struct {
+	// Тип предмета.
+	Type OptString `json:"type"`
+	// Количество предметов.
+	Quantity OptInt `json:"quantity"`
+}
+
+// GetType returns the value of Type.
+func (s *InfoResponseInventoryItem) GetType() OptString {
+	return s.Type
+}
+
+// GetQuantity returns the value of Quantity.
+func (s *InfoResponseInventoryItem) GetQuantity() OptInt {
+	return s.Quantity
+}
+
+// SetType sets the value of Type.
+func (s *InfoResponseInventoryItem) SetType(val OptString) {
+	s.Type = val
+}
+
+// SetQuantity sets the value of Quantity.
+func (s *InfoResponseInventoryItem) SetQuantity(val OptInt) {
+	s.Quantity = val
+}
+
+// Ref: #/components/schemas/Kudos
+type Kudos struct {
+	ID        int                 `json:"id"`
+	FromUser  string              `json:"fromUser"`
+	ToUser    string              `json:"toUser"`
+	Message   OptString           `json:"message"`
+	Category  OptTransferCategory `json:"category"`
+	CreatedAt time.Time           `json:"createdAt"`
+}
+
+// GetID returns the value of ID.
+func (s *Kudos) GetID() int {
+	return s.ID
+}
+
+// GetFromUser returns the value of FromUser.
+func (s *Kudos) GetFromUser() string {
+	return s.FromUser
+}
+
+// GetToUser returns the value of ToUser.
+func (s *Kudos) GetToUser() string {
+	return s.ToUser
+}
+
+// GetMessage returns the value of Message.
+func (s *Kudos) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCategory returns the value of Category.
+func (s *Kudos) GetCategory() OptTransferCategory {
+	return s.Category
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Kudos) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *Kudos) SetID(val int) {
+	s.ID = val
+}
+
+// SetFromUser sets the value of FromUser.
+func (s *Kudos) SetFromUser(val string) {
+	s.FromUser = val
+}
+
+// SetToUser sets the value of ToUser.
+func (s *Kudos) SetToUser(val string) {
+	s.ToUser = val
+}
+
+// SetMessage sets the value of Message.
+func (s *Kudos) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCategory sets the value of Category.
+func (s *Kudos) SetCategory(val OptTransferCategory) {
+	s.Category = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Kudos) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/components/schemas/KudosFeed
+type KudosFeed struct {
+	Items []Kudos `json:"items"`
+	// Значение cursor для следующей страницы; отсутствует на
+	// последней странице.
+	NextCursor OptInt `json:"nextCursor"`
+}
+
+// GetItems returns the value of Items.
+func (s *KudosFeed) GetItems() []Kudos {
+	return s.Items
+}
+
+// GetNextCursor returns the value of NextCursor.
+func (s *KudosFeed) GetNextCursor() OptInt {
+	return s.NextCursor
+}
+
+// SetItems sets the value of Items.
+func (s *KudosFeed) SetItems(val []Kudos) {
+	s.Items = val
+}
+
+// SetNextCursor sets the value of NextCursor.
+func (s *KudosFeed) SetNextCursor(val OptInt) {
+	s.NextCursor = val
+}
+
+func (*KudosFeed) aPIKudosGetRes() {}
+
+// Сообщение об успешном выполнении операции
+// (расширение схемы из задания).
+// Ref: #/components/schemas/MessageResponse
+type MessageResponse struct {
+	// Текст сообщения.
+	Message OptString `json:"message"`
+}
+
+// GetMessage returns the value of Message.
+func (s *MessageResponse) GetMessage() OptString {
+	return s.Message
+}
+
+// SetMessage sets the value of Message.
+func (s *MessageResponse) SetMessage(val OptString) {
+	s.Message = val
+}
+
+func (*MessageResponse) aPIBuyItemGetRes()            {}
+func (*MessageResponse) aPINotificationsReadPostRes() {}
+func (*MessageResponse) aPISendCoinPostRes()          {}
+
+// Ref: #/components/schemas/MfaCodeRequest
+type MfaCodeRequest struct {
+	// Шестизначный TOTP-код или код восстановления.
+	Code string `json:"code"`
+}
+
+// GetCode returns the value of Code.
+func (s *MfaCodeRequest) GetCode() string {
+	return s.Code
+}
+
+// SetCode sets the value of Code.
+func (s *MfaCodeRequest) SetCode(val string) {
+	s.Code = val
+}
+
+// Ref: #/components/schemas/MfaLoginRequest
+type MfaLoginRequest struct {
+	MfaToken string `json:"mfaToken"`
+	// Шестизначный TOTP-код или код восстановления.
+	Code string `json:"code"`
+}
+
+// GetMfaToken returns the value of MfaToken.
+func (s *MfaLoginRequest) GetMfaToken() string {
+	return s.MfaToken
+}
+
+// GetCode returns the value of Code.
+func (s *MfaLoginRequest) GetCode() string {
+	return s.Code
+}
+
+// SetMfaToken sets the value of MfaToken.
+func (s *MfaLoginRequest) SetMfaToken(val string) {
+	s.MfaToken = val
+}
+
+// SetCode sets the value of Code.
+func (s *MfaLoginRequest) SetCode(val string) {
+	s.Code = val
+}
+
+// Ref: #/components/schemas/MfaStatusResponse
+type MfaStatusResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetEnabled returns the value of Enabled.
+func (s *MfaStatusResponse) GetEnabled() bool {
+	return s.Enabled
+}
+
+// SetEnabled sets the value of Enabled.
+func (s *MfaStatusResponse) SetEnabled(val bool) {
+	s.Enabled = val
+}
+
+func (*MfaStatusResponse) aPI2FATotpDisablePostRes() {}
+
+// Ref: #/components/schemas/Notification
+type Notification struct {
+	ID int `json:"id"`
+	// Schedule_failed – запланированный перевод не выполнен;
+	// schedule_paused – расписание приостановлено после
+	// нескольких неудач подряд. coins_expired – монеты сгорели по
+	// истечении срока.
+	Kind       NotificationKind `json:"kind"`
+	Message    string           `json:"message"`
+	ScheduleId OptInt           `json:"scheduleId"`
+	Read       bool             `json:"read"`
+	CreatedAt  time.Time        `json:"createdAt"`
+}
+
+// GetID returns the value of ID.
+func (s *Notification) GetID() int {
+	return s.ID
+}
+
+// GetKind returns the value of Kind.
+func (s *Notification) GetKind() NotificationKind {
+	return s.Kind
+}
+
+// GetMessage returns the value of Message.
+func (s *Notification) GetMessage() string {
+	return s.Message
+}
+
+// GetScheduleId returns the value of ScheduleId.
+func (s *Notification) GetScheduleId() OptInt {
+	return s.ScheduleId
+}
+
+// GetRead returns the value of Read.
+func (s *Notification) GetRead() bool {
+	return s.Read
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Notification) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *Notification) SetID(val int) {
+	s.ID = val
+}
+
+// SetKind sets the value of Kind.
+func (s *Notification) SetKind(val NotificationKind) {
+	s.Kind = val
+}
+
+// SetMessage sets the value of Message.
+func (s *Notification) SetMessage(val string) {
+	s.Message = val
+}
+
+// SetScheduleId sets the value of ScheduleId.
+func (s *Notification) SetScheduleId(val OptInt) {
+	s.ScheduleId = val
+}
+
+// SetRead sets the value of Read.
+func (s *Notification) SetRead(val bool) {
+	s.Read = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Notification) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Schedule_failed – запланированный перевод не выполнен;
+// schedule_paused – расписание приостановлено после
+// нескольких неудач подряд. coins_expired – монеты сгорели по
+// истечении срока.
+type NotificationKind string
+
+const (
+	NotificationKindScheduleFailed NotificationKind = "schedule_failed"
+	NotificationKindSchedulePaused NotificationKind = "schedule_paused"
+	NotificationKindCoinsExpired   NotificationKind = "coins_expired"
+)
+
+// AllValues returns all NotificationKind values.
+func (NotificationKind) AllValues() []NotificationKind {
+	return []NotificationKind{
+		NotificationKindScheduleFailed,
+		NotificationKindSchedulePaused,
+		NotificationKindCoinsExpired,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s NotificationKind) MarshalText() ([]byte, error) {
+	switch s {
+	case NotificationKindScheduleFailed:
+		return []byte(s), nil
+	case NotificationKindSchedulePaused:
+		return []byte(s), nil
+	case NotificationKindCoinsExpired:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *NotificationKind) UnmarshalText(data []byte) error {
+	switch NotificationKind(data) {
+	case NotificationKindScheduleFailed:
+		*s = NotificationKindScheduleFailed
+		return nil
+	case NotificationKindSchedulePaused:
+		*s = NotificationKindSchedulePaused
+		return nil
+	case NotificationKindCoinsExpired:
+		*s = NotificationKindCoinsExpired
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/NotificationList
+type NotificationList struct {
+	// Последние уведомления, от новых к старым.
+	Notifications []Notification `json:"notifications"`
+	// Число непрочитанных уведомлений.
+	Unread int `json:"unread"`
+}
+
+// GetNotifications returns the value of Notifications.
+func (s *NotificationList) GetNotifications() []Notification {
+	return s.Notifications
+}
+
+// GetUnread returns the value of Unread.
+func (s *NotificationList) GetUnread() int {
+	return s.Unread
+}
+
+// SetNotifications sets the value of Notifications.
+func (s *NotificationList) SetNotifications(val []Notification) {
+	s.Notifications = val
+}
+
+// SetUnread sets the value of Unread.
+func (s *NotificationList) SetUnread(val int) {
+	s.Unread = val
+}
+
+func (*NotificationList) aPINotificationsGetRes() {}
+
+// Ref: #/components/schemas/OffboardRequest
+type OffboardRequest struct {
+	Disposition OptBalanceDisposition `json:"disposition"`
+}
+
+// GetDisposition returns the value of Disposition.
+func (s *OffboardRequest) GetDisposition() OptBalanceDisposition {
+	return s.Disposition
+}
+
+// SetDisposition sets the value of Disposition.
+func (s *OffboardRequest) SetDisposition(val OptBalanceDisposition) {
+	s.Disposition = val
+}
+
+// Ref: #/components/schemas/OffboardResponse
+type OffboardResponse struct {
+	Username    string             `json:"username"`
+	Status      AccountStatus      `json:"status"`
+	Disposition BalanceDisposition `json:"disposition"`
+	// Переданный остаток баланса.
+	Amount int `json:"amount"`
+	// Получатель остатка; отсутствует, если монеты списаны
+	// в казну без счёта.
+	Recipient OptString `json:"recipient"`
+}
+
+// GetUsername returns the value of Username.
+func (s *OffboardResponse) GetUsername() string {
+	return s.Username
+}
+
+// GetStatus returns the value of Status.
+func (s *OffboardResponse) GetStatus() AccountStatus {
+	return s.Status
+}
+
+// GetDisposition returns the value of Disposition.
+func (s *OffboardResponse) GetDisposition() BalanceDisposition {
+	return s.Disposition
+}
+
+// GetAmount returns the value of Amount.
+func (s *OffboardResponse) GetAmount() int {
+	return s.Amount
+}
+
+// GetRecipient returns the value of Recipient.
+func (s *OffboardResponse) GetRecipient() OptString {
+	return s.Recipient
+}
+
+// SetUsername sets the value of Username.
+func (s *OffboardResponse) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetStatus sets the value of Status.
+func (s *OffboardResponse) SetStatus(val AccountStatus) {
+	s.Status = val
+}
+
+// SetDisposition sets the value of Disposition.
+func (s *OffboardResponse) SetDisposition(val BalanceDisposition) {
+	s.Disposition = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *OffboardResponse) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetRecipient sets the value of Recipient.
+func (s *OffboardResponse) SetRecipient(val OptString) {
+	s.Recipient = val
+}
+
+func (*OffboardResponse) aPIAdminUsersUsernameOffboardPostRes() {}
+
+// Ref: #/components/schemas/OidcCallbackRequest
+type OidcCallbackRequest struct {
+	// Код авторизации от провайдера.
+	Code string `json:"code"`
+	// Значение state из ответа /api/auth/oidc.
+	State string `json:"state"`
+}
+
+// GetCode returns the value of Code.
+func (s *OidcCallbackRequest) GetCode() string {
+	return s.Code
+}
+
+// GetState returns the value of State.
+func (s *OidcCallbackRequest) GetState() string {
+	return s.State
+}
+
+// SetCode sets the value of Code.
+func (s *OidcCallbackRequest) SetCode(val string) {
+	s.Code = val
+}
+
+// SetState sets the value of State.
+func (s *OidcCallbackRequest) SetState(val string) {
+	s.State = val
+}
+
+// Ref: #/components/schemas/OidcLoginResponse
+type OidcLoginResponse struct {
+	// Адрес страницы входа провайдера.
+	AuthorizationUrl string `json:"authorizationUrl"`
+	// Значение state, которое провайдер вернёт вместе с кодом.
+	State string `json:"state"`
+}
+
+// GetAuthorizationUrl returns the value of AuthorizationUrl.
+func (s *OidcLoginResponse) GetAuthorizationUrl() string {
+	return s.AuthorizationUrl
+}
+
+// GetState returns the value of State.
+func (s *OidcLoginResponse) GetState() string {
+	return s.State
+}
+
+// SetAuthorizationUrl sets the value of AuthorizationUrl.
+func (s *OidcLoginResponse) SetAuthorizationUrl(val string) {
+	s.AuthorizationUrl = val
+}
+
+// SetState sets the value of State.
+func (s *OidcLoginResponse) SetState(val string) {
+	s.State = val
+}
+
+func (*OidcLoginResponse) aPIAuthOidcGetRes() {}
+
+// NewOptBalanceDisposition returns new OptBalanceDisposition with value set to v.
+func NewOptBalanceDisposition(v BalanceDisposition) OptBalanceDisposition {
+	return OptBalanceDisposition{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBalanceDisposition is optional BalanceDisposition.
+type OptBalanceDisposition struct {
+	Value BalanceDisposition
+	Set   bool
+}
+
+// IsSet returns true if OptBalanceDisposition was set.
+func (o OptBalanceDisposition) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBalanceDisposition) Reset() {
+	var v BalanceDisposition
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBalanceDisposition) SetTo(v BalanceDisposition) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBalanceDisposition) Get() (v BalanceDisposition, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBalanceDisposition) Or(d BalanceDisposition) BalanceDisposition {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDate returns new OptDate with value set to v.
+func NewOptDate(v time.Time) OptDate {
+	return OptDate{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDate is optional time.Time.
+type OptDate struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDate was set.
+func (o OptDate) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDate) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDate) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDate) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDate) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDisputeStatus returns new OptDisputeStatus with value set to v.
+func NewOptDisputeStatus(v DisputeStatus) OptDisputeStatus {
+	return OptDisputeStatus{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDisputeStatus is optional DisputeStatus.
+type OptDisputeStatus struct {
+	Value DisputeStatus
+	Set   bool
+}
+
+// IsSet returns true if OptDisputeStatus was set.
+func (o OptDisputeStatus) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDisputeStatus) Reset() {
+	var v DisputeStatus
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDisputeStatus) SetTo(v DisputeStatus) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDisputeStatus) Get() (v DisputeStatus, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDisputeStatus) Or(d DisputeStatus) DisputeStatus {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFraudFlagResolution returns new OptFraudFlagResolution with value set to v.
+func NewOptFraudFlagResolution(v FraudFlagResolution) OptFraudFlagResolution {
+	return OptFraudFlagResolution{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFraudFlagResolution is optional FraudFlagResolution.
+type OptFraudFlagResolution struct {
+	Value FraudFlagResolution
+	Set   bool
+}
+
+// IsSet returns true if OptFraudFlagResolution was set.
+func (o OptFraudFlagResolution) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFraudFlagResolution) Reset() {
+	var v FraudFlagResolution
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFraudFlagResolution) SetTo(v FraudFlagResolution) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFraudFlagResolution) Get() (v FraudFlagResolution, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFraudFlagResolution) Or(d FraudFlagResolution) FraudFlagResolution {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFraudFlagStatus returns new OptFraudFlagStatus with value set to v.
+func NewOptFraudFlagStatus(v FraudFlagStatus) OptFraudFlagStatus {
+	return OptFraudFlagStatus{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFraudFlagStatus is optional FraudFlagStatus.
+type OptFraudFlagStatus struct {
+	Value FraudFlagStatus
+	Set   bool
+}
+
+// IsSet returns true if OptFraudFlagStatus was set.
+func (o OptFraudFlagStatus) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFraudFlagStatus) Reset() {
+	var v FraudFlagStatus
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFraudFlagStatus) SetTo(v FraudFlagStatus) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFraudFlagStatus) Get() (v FraudFlagStatus, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFraudFlagStatus) Or(d FraudFlagStatus) FraudFlagStatus {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInfoResponseCoinHistory returns new OptInfoResponseCoinHistory with value set to v.
+func NewOptInfoResponseCoinHistory(v InfoResponseCoinHistory) OptInfoResponseCoinHistory {
+	return OptInfoResponseCoinHistory{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInfoResponseCoinHistory is optional InfoResponseCoinHistory.
+type OptInfoResponseCoinHistory struct {
+	Value InfoResponseCoinHistory
+	Set   bool
+}
+
+// IsSet returns true if OptInfoResponseCoinHistory was set.
+func (o OptInfoResponseCoinHistory) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInfoResponseCoinHistory) Reset() {
+	var v InfoResponseCoinHistory
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInfoResponseCoinHistory) SetTo(v InfoResponseCoinHistory) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInfoResponseCoinHistory) Get() (v InfoResponseCoinHistory, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInfoResponseCoinHistory) Or(d InfoResponseCoinHistory) InfoResponseCoinHistory {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInfoResponseGivingBudget returns new OptInfoResponseGivingBudget with value set to v.
+func NewOptInfoResponseGivingBudget(v InfoResponseGivingBudget) OptInfoResponseGivingBudget {
+	return OptInfoResponseGivingBudget{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInfoResponseGivingBudget is optional InfoResponseGivingBudget.
+type OptInfoResponseGivingBudget struct {
+	Value InfoResponseGivingBudget
+	Set   bool
+}
+
+// IsSet returns true if OptInfoResponseGivingBudget was set.
+func (o OptInfoResponseGivingBudget) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInfoResponseGivingBudget) Reset() {
+	var v InfoResponseGivingBudget
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInfoResponseGivingBudget) SetTo(v InfoResponseGivingBudget) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInfoResponseGivingBudget) Get() (v InfoResponseGivingBudget, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInfoResponseGivingBudget) Or(d InfoResponseGivingBudget) InfoResponseGivingBudget {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptOffboardRequest returns new OptOffboardRequest with value set to v.
+func NewOptOffboardRequest(v OffboardRequest) OptOffboardRequest {
+	return OptOffboardRequest{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptOffboardRequest is optional OffboardRequest.
+type OptOffboardRequest struct {
+	Value OffboardRequest
+	Set   bool
+}
+
+// IsSet returns true if OptOffboardRequest was set.
+func (o OptOffboardRequest) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptOffboardRequest) Reset() {
+	var v OffboardRequest
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptOffboardRequest) SetTo(v OffboardRequest) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptOffboardRequest) Get() (v OffboardRequest, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptOffboardRequest) Or(d OffboardRequest) OffboardRequest {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptRecurrence returns new OptRecurrence with value set to v.
+func NewOptRecurrence(v Recurrence) OptRecurrence {
+	return OptRecurrence{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptRecurrence is optional Recurrence.
+type OptRecurrence struct {
+	Value Recurrence
+	Set   bool
+}
+
+// IsSet returns true if OptRecurrence was set.
+func (o OptRecurrence) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptRecurrence) Reset() {
+	var v Recurrence
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptRecurrence) SetTo(v Recurrence) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptRecurrence) Get() (v Recurrence, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptRecurrence) Or(d Recurrence) Recurrence {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTransferCategory returns new OptTransferCategory with value set to v.
+func NewOptTransferCategory(v TransferCategory) OptTransferCategory {
+	return OptTransferCategory{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTransferCategory is optional TransferCategory.
+type OptTransferCategory struct {
+	Value TransferCategory
+	Set   bool
+}
+
+// IsSet returns true if OptTransferCategory was set.
+func (o OptTransferCategory) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTransferCategory) Reset() {
+	var v TransferCategory
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTransferCategory) SetTo(v TransferCategory) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTransferCategory) Get() (v TransferCategory, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTransferCategory) Or(d TransferCategory) TransferCategory {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Ref: #/components/schemas/PasswordResetTokenResponse
+type PasswordResetTokenResponse struct {
+	// Одноразовый токен сброса пароля.
+	ResetToken string    `json:"resetToken"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// GetResetToken returns the value of ResetToken.
+func (s *PasswordResetTokenResponse) GetResetToken() string {
+	return s.ResetToken
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *PasswordResetTokenResponse) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// SetResetToken sets the value of ResetToken.
+func (s *PasswordResetTokenResponse) SetResetToken(val string) {
+	s.ResetToken = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *PasswordResetTokenResponse) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+func (*PasswordResetTokenResponse) aPIAdminUsersUsernamePasswordResetPostRes() {}
+
+// Ref: #/components/schemas/PendingTransfer
+type PendingTransfer struct {
+	ID       int                 `json:"id"`
+	FromUser string              `json:"fromUser"`
+	ToUser   string              `json:"toUser"`
+	Amount   int                 `json:"amount"`
+	Message  OptString           `json:"message"`
+	Category OptTransferCategory `json:"category"`
+	Public   bool                `json:"public"`
+	Status   TransferStatus      `json:"status"`
+	// Руководитель, который должен одобрить перевод; если
+	// не указан, перевод одобряет администратор.
+	Approver  OptString `json:"approver"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Когда неподтверждённый перевод вернётся отправителю.
+	ExpiresAt  time.Time   `json:"expiresAt"`
+	ResolvedAt OptDateTime `json:"resolvedAt"`
+	// Идентификатор выполненного перевода после
+	// подтверждения.
+	TransactionId OptInt `json:"transactionId"`
+	// Сколько монет перевода взято из бюджета на
+	// благодарности.
+	FromBudget OptInt `json:"fromBudget"`
+}
+
+// GetID returns the value of ID.
+func (s *PendingTransfer) GetID() int {
+	return s.ID
+}
+
+// GetFromUser returns the value of FromUser.
+func (s *PendingTransfer) GetFromUser() string {
+	return s.FromUser
+}
+
+// GetToUser returns the value of ToUser.
+func (s *PendingTransfer) GetToUser() string {
+	return s.ToUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *PendingTransfer) GetAmount() int {
+	return s.Amount
+}
+
+// GetMessage returns the value of Message.
+func (s *PendingTransfer) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCategory returns the value of Category.
+func (s *PendingTransfer) GetCategory() OptTransferCategory {
+	return s.Category
+}
+
+// GetPublic returns the value of Public.
+func (s *PendingTransfer) GetPublic() bool {
+	return s.Public
+}
+
+// GetStatus returns the value of Status.
+func (s *PendingTransfer) GetStatus() TransferStatus {
+	return s.Status
+}
+
+// GetApprover returns the value of Approver.
+func (s *PendingTransfer) GetApprover() OptString {
+	return s.Approver
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PendingTransfer) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *PendingTransfer) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// GetResolvedAt returns the value of ResolvedAt.
+func (s *PendingTransfer) GetResolvedAt() OptDateTime {
+	return s.ResolvedAt
+}
+
+// GetTransactionId returns the value of TransactionId.
+func (s *PendingTransfer) GetTransactionId() OptInt {
+	return s.TransactionId
+}
+
+// GetFromBudget returns the value of FromBudget.
+func (s *PendingTransfer) GetFromBudget() OptInt {
+	return s.FromBudget
+}
+
+// SetID sets the value of ID.
+func (s *PendingTransfer) SetID(val int) {
+	s.ID = val
+}
+
+// SetFromUser sets the value of FromUser.
+func (s *PendingTransfer) SetFromUser(val string) {
+	s.FromUser = val
+}
+
+// SetToUser sets the value of ToUser.
+func (s *PendingTransfer) SetToUser(val string) {
+	s.ToUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *PendingTransfer) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetMessage sets the value of Message.
+func (s *PendingTransfer) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCategory sets the value of Category.
+func (s *PendingTransfer) SetCategory(val OptTransferCategory) {
+	s.Category = val
+}
+
+// SetPublic sets the value of Public.
+func (s *PendingTransfer) SetPublic(val bool) {
+	s.Public = val
+}
+
+// SetStatus sets the value of Status.
+func (s *PendingTransfer) SetStatus(val TransferStatus) {
+	s.Status = val
+}
+
+// SetApprover sets the value of Approver.
+func (s *PendingTransfer) SetApprover(val OptString) {
+	s.Approver = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PendingTransfer) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *PendingTransfer) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+// SetResolvedAt sets the value of ResolvedAt.
+func (s *PendingTransfer) SetResolvedAt(val OptDateTime) {
+	s.ResolvedAt = val
+}
+
+// SetTransactionId sets the value of TransactionId.
+func (s *PendingTransfer) SetTransactionId(val OptInt) {
+	s.TransactionId = val
+}
+
+// SetFromBudget sets the value of FromBudget.
+func (s *PendingTransfer) SetFromBudget(val OptInt) {
+	s.FromBudget = val
+}
+
+func (*PendingTransfer) aPISendCoinPostRes()                   {}
+func (*PendingTransfer) aPITransfersTransferIdAcceptPostRes()  {}
+func (*PendingTransfer) aPITransfersTransferIdApprovePostRes() {}
+func (*PendingTransfer) aPITransfersTransferIdCancelPostRes()  {}
+func (*PendingTransfer) aPITransfersTransferIdDeclinePostRes() {}
+func (*PendingTransfer) aPITransfersTransferIdRejectPostRes()  {}
+
+// Ref: #/components/schemas/PendingTransferList
+type PendingTransferList struct {
+	// Переводы пользователю, ожидающие его подтверждения.
+	Incoming []PendingTransfer `json:"incoming"`
+	// Ожидающие переводы пользователя.
+	Outgoing []PendingTransfer `json:"outgoing"`
+	// Переводы, ожидающие одобрения пользователя как
+	// руководителя (для администраторов – все переводы без
+	// руководителя).
+	Approvals []PendingTransfer `json:"approvals"`
+}
+
+// GetIncoming returns the value of Incoming.
+func (s *PendingTransferList) GetIncoming() []PendingTransfer {
+	return s.Incoming
+}
+
+// GetOutgoing returns the value of Outgoing.
+func (s *PendingTransferList) GetOutgoing() []PendingTransfer {
+	return s.Outgoing
+}
+
+// GetApprovals returns the value of Approvals.
+func (s *PendingTransferList) GetApprovals() []PendingTransfer {
+	return s.Approvals
+}
+
+// SetIncoming sets the value of Incoming.
+func (s *PendingTransferList) SetIncoming(val []PendingTransfer) {
+	s.Incoming = val
+}
+
+// SetOutgoing sets the value of Outgoing.
+func (s *PendingTransferList) SetOutgoing(val []PendingTransfer) {
+	s.Outgoing = val
+}
+
+// SetApprovals sets the value of Approvals.
+func (s *PendingTransferList) SetApprovals(val []PendingTransfer) {
+	s.Approvals = val
+}
+
+func (*PendingTransferList) aPITransfersPendingGetRes() {}
+
+// Ref: #/components/schemas/RecoveryCodesResponse
+type RecoveryCodesResponse struct {
+	// Одноразовые коды восстановления; показываются
+	// только один раз.
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// GetRecoveryCodes returns the value of RecoveryCodes.
+func (s *RecoveryCodesResponse) GetRecoveryCodes() []string {
+	return s.RecoveryCodes
+}
+
+// SetRecoveryCodes sets the value of RecoveryCodes.
+func (s *RecoveryCodesResponse) SetRecoveryCodes(val []string) {
+	s.RecoveryCodes = val
+}
+
+func (*RecoveryCodesResponse) aPI2FATotpConfirmPostRes() {}
+
+// Once – один раз; weekly – каждую неделю; monthly – каждый
+// месяц в тот же день (в коротких месяцах – в последний
+// день).
+// Ref: #/components/schemas/Recurrence
+type Recurrence string
+
+const (
+	RecurrenceOnce    Recurrence = "once"
+	RecurrenceWeekly  Recurrence = "weekly"
+	RecurrenceMonthly Recurrence = "monthly"
+)
+
+// AllValues returns all Recurrence values.
+func (Recurrence) AllValues() []Recurrence {
+	return []Recurrence{
+		RecurrenceOnce,
+		RecurrenceWeekly,
+		RecurrenceMonthly,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Recurrence) MarshalText() ([]byte, error) {
+	switch s {
+	case RecurrenceOnce:
+		return []byte(s), nil
+	case RecurrenceWeekly:
+		return []byte(s), nil
+	case RecurrenceMonthly:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Recurrence) UnmarshalText(data []byte) error {
+	switch Recurrence(data) {
+	case RecurrenceOnce:
+		*s = RecurrenceOnce
+		return nil
+	case RecurrenceWeekly:
+		*s = RecurrenceWeekly
+		return nil
+	case RecurrenceMonthly:
+		*s = RecurrenceMonthly
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/ResetPasswordRequest
+type ResetPasswordRequest struct {
+	// Одноразовый токен сброса пароля.
+	ResetToken string `json:"resetToken"`
+	// Новый пароль.
+	NewPassword string `json:"newPassword"`
+}
+
+// GetResetToken returns the value of ResetToken.
+func (s *ResetPasswordRequest) GetResetToken() string {
+	return s.ResetToken
+}
+
+// GetNewPassword returns the value of NewPassword.
+func (s *ResetPasswordRequest) GetNewPassword() string {
+	return s.NewPassword
+}
+
+// SetResetToken sets the value of ResetToken.
+func (s *ResetPasswordRequest) SetResetToken(val string) {
+	s.ResetToken = val
+}
+
+// SetNewPassword sets the value of NewPassword.
+func (s *ResetPasswordRequest) SetNewPassword(val string) {
+	s.NewPassword = val
+}
+
+// Ref: #/components/schemas/ScheduleRequest
+type ScheduleRequest struct {
+	// Имя пользователя, которому нужно отправить монеты.
+	ToUser string `json:"toUser"`
+	// Количество монет, которые необходимо отправить.
+	Amount int `json:"amount"`
+	// Сообщение получателю. Управляющие символы удаляются,
+	// пробелы схлопываются.
+	Message  OptString           `json:"message"`
+	Category OptTransferCategory `json:"category"`
+	// Показывать перевод в ленте благодарностей /api/kudos. По
+	// умолчанию перевод виден только участникам.
+	Public OptBool `json:"public"`
+	// Время первого перевода; должно быть в будущем.
+	StartAt    time.Time     `json:"startAt"`
+	Recurrence OptRecurrence `json:"recurrence"`
+	// Сначала расходовать бюджет на благодарности, а
+	// недостающее – с личного баланса. По умолчанию
+	// перевод идёт с личного баланса.
+	UseBudget OptBool `json:"useBudget"`
+}
+
+// GetToUser returns the value of ToUser.
+func (s *ScheduleRequest) GetToUser() string {
+	return s.ToUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *ScheduleRequest) GetAmount() int {
+	return s.Amount
+}
+
+// GetMessage returns the value of Message.
+func (s *ScheduleRequest) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCategory returns the value of Category.
+func (s *ScheduleRequest) GetCategory() OptTransferCategory {
+	return s.Category
+}
+
+// GetPublic returns the value of Public.
+func (s *ScheduleRequest) GetPublic() OptBool {
+	return s.Public
+}
+
+// GetStartAt returns the value of StartAt.
+func (s *ScheduleRequest) GetStartAt() time.Time {
+	return s.StartAt
+}
+
+// GetRecurrence returns the value of Recurrence.
+func (s *ScheduleRequest) GetRecurrence() OptRecurrence {
+	return s.Recurrence
+}
+
+// GetUseBudget returns the value of UseBudget.
+func (s *ScheduleRequest) GetUseBudget() OptBool {
+	return s.UseBudget
+}
+
+// SetToUser sets the value of ToUser.
+func (s *ScheduleRequest) SetToUser(val string) {
+	s.ToUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *ScheduleRequest) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetMessage sets the value of Message.
+func (s *ScheduleRequest) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCategory sets the value of Category.
+func (s *ScheduleRequest) SetCategory(val OptTransferCategory) {
+	s.Category = val
+}
+
+// SetPublic sets the value of Public.
+func (s *ScheduleRequest) SetPublic(val OptBool) {
+	s.Public = val
+}
+
+// SetStartAt sets the value of StartAt.
+func (s *ScheduleRequest) SetStartAt(val time.Time) {
+	s.StartAt = val
+}
+
+// SetRecurrence sets the value of Recurrence.
+func (s *ScheduleRequest) SetRecurrence(val OptRecurrence) {
+	s.Recurrence = val
+}
+
+// SetUseBudget sets the value of UseBudget.
+func (s *ScheduleRequest) SetUseBudget(val OptBool) {
+	s.UseBudget = val
+}
+
+// Active – ожидает выполнения; paused – приостановлен; cancelled
+// – отменён; completed – разовый перевод выполнен; failed –
+// разовый перевод не выполнен.
+// Ref: #/components/schemas/ScheduleStatus
+type ScheduleStatus string
+
+const (
+	ScheduleStatusActive    ScheduleStatus = "active"
+	ScheduleStatusPaused    ScheduleStatus = "paused"
+	ScheduleStatusCancelled ScheduleStatus = "cancelled"
+	ScheduleStatusCompleted ScheduleStatus = "completed"
+	ScheduleStatusFailed    ScheduleStatus = "failed"
+)
+
+// AllValues returns all ScheduleStatus values.
+func (ScheduleStatus) AllValues() []ScheduleStatus {
+	return []ScheduleStatus{
+		ScheduleStatusActive,
+		ScheduleStatusPaused,
+		ScheduleStatusCancelled,
+		ScheduleStatusCompleted,
+		ScheduleStatusFailed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ScheduleStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case ScheduleStatusActive:
+		return []byte(s), nil
+	case ScheduleStatusPaused:
+		return []byte(s), nil
+	case ScheduleStatusCancelled:
+		return []byte(s), nil
+	case ScheduleStatusCompleted:
+		return []byte(s), nil
+	case ScheduleStatusFailed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ScheduleStatus) UnmarshalText(data []byte) error {
+	switch ScheduleStatus(data) {
+	case ScheduleStatusActive:
+		*s = ScheduleStatusActive
+		return nil
+	case ScheduleStatusPaused:
+		*s = ScheduleStatusPaused
+		return nil
+	case ScheduleStatusCancelled:
+		*s = ScheduleStatusCancelled
+		return nil
+	case ScheduleStatusCompleted:
+		*s = ScheduleStatusCompleted
+		return nil
+	case ScheduleStatusFailed:
+		*s = ScheduleStatusFailed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/ScheduledTransfer
+type ScheduledTransfer struct {
+	ID         int                 `json:"id"`
+	ToUser     string              `json:"toUser"`
+	Amount     int                 `json:"amount"`
+	Message    OptString           `json:"message"`
+	Category   OptTransferCategory `json:"category"`
+	Public     bool                `json:"public"`
+	Recurrence Recurrence          `json:"recurrence"`
+	Status     ScheduleStatus      `json:"status"`
+	// Время следующего перевода; нет у завершённых и
+	// отменённых.
+	NextRunAt OptDateTime `json:"nextRunAt"`
+	LastRunAt OptDateTime `json:"lastRunAt"`
+	// Код ошибки последнего неудачного перевода.
+	LastError OptString `json:"lastError"`
+	// Неудачных переводов подряд.
+	Failures  int       `json:"failures"`
+	CreatedAt time.Time `json:"createdAt"`
+	UseBudget bool      `json:"useBudget"`
+}
+
+// GetID returns the value of ID.
+func (s *ScheduledTransfer) GetID() int {
+	return s.ID
+}
+
+// GetToUser returns the value of ToUser.
+func (s *ScheduledTransfer) GetToUser() string {
+	return s.ToUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *ScheduledTransfer) GetAmount() int {
+	return s.Amount
+}
+
+// GetMessage returns the value of Message.
+func (s *ScheduledTransfer) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCategory returns the value of Category.
+func (s *ScheduledTransfer) GetCategory() OptTransferCategory {
+	return s.Category
+}
+
+// GetPublic returns the value of Public.
+func (s *ScheduledTransfer) GetPublic() bool {
+	return s.Public
+}
+
+// GetRecurrence returns the value of Recurrence.
+func (s *ScheduledTransfer) GetRecurrence() Recurrence {
+	return s.Recurrence
+}
+
+// GetStatus returns the value of Status.
+func (s *ScheduledTransfer) GetStatus() ScheduleStatus {
+	return s.Status
+}
+
+// GetNextRunAt returns the value of NextRunAt.
+func (s *ScheduledTransfer) GetNextRunAt() OptDateTime {
+	return s.NextRunAt
+}
+
+// GetLastRunAt returns the value of LastRunAt.
+func (s *ScheduledTransfer) GetLastRunAt() OptDateTime {
+	return s.LastRunAt
+}
+
+// GetLastError returns the value of LastError.
+func (s *ScheduledTransfer) GetLastError() OptString {
+	return s.LastError
+}
+
+// GetFailures returns the value of Failures.
+func (s *ScheduledTransfer) GetFailures() int {
+	return s.Failures
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *ScheduledTransfer) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUseBudget returns the value of UseBudget.
+func (s *ScheduledTransfer) GetUseBudget() bool {
+	return s.UseBudget
+}
+
+// SetID sets the value of ID.
+func (s *ScheduledTransfer) SetID(val int) {
+	s.ID = val
+}
+
+// SetToUser sets the value of ToUser.
+func (s *ScheduledTransfer) SetToUser(val string) {
+	s.ToUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *ScheduledTransfer) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetMessage sets the value of Message.
+func (s *ScheduledTransfer) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCategory sets the value of Category.
+func (s *ScheduledTransfer) SetCategory(val OptTransferCategory) {
+	s.Category = val
+}
+
+// SetPublic sets the value of Public.
+func (s *ScheduledTransfer) SetPublic(val bool) {
+	s.Public = val
+}
+
+// SetRecurrence sets the value of Recurrence.
+func (s *ScheduledTransfer) SetRecurrence(val Recurrence) {
+	s.Recurrence = val
+}
+
+// SetStatus sets the value of Status.
+func (s *ScheduledTransfer) SetStatus(val ScheduleStatus) {
+	s.Status = val
+}
+
+// SetNextRunAt sets the value of NextRunAt.
+func (s *ScheduledTransfer) SetNextRunAt(val OptDateTime) {
+	s.NextRunAt = val
+}
+
+// SetLastRunAt sets the value of LastRunAt.
+func (s *ScheduledTransfer) SetLastRunAt(val OptDateTime) {
+	s.LastRunAt = val
+}
+
+// SetLastError sets the value of LastError.
+func (s *ScheduledTransfer) SetLastError(val OptString) {
+	s.LastError = val
+}
+
+// SetFailures sets the value of Failures.
+func (s *ScheduledTransfer) SetFailures(val int) {
+	s.Failures = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *ScheduledTransfer) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetUseBudget sets the value of UseBudget.
+func (s *ScheduledTransfer) SetUseBudget(val bool) {
+	s.UseBudget = val
+}
+
+func (*ScheduledTransfer) aPISchedulesPostRes()                 {}
+func (*ScheduledTransfer) aPISchedulesScheduleIdCancelPostRes() {}
+func (*ScheduledTransfer) aPISchedulesScheduleIdPausePostRes()  {}
+func (*ScheduledTransfer) aPISchedulesScheduleIdResumePostRes() {}
+
+// Ref: #/components/schemas/ScheduledTransferList
+type ScheduledTransferList struct {
+	Schedules []ScheduledTransfer `json:"schedules"`
+}
+
+// GetSchedules returns the value of Schedules.
+func (s *ScheduledTransferList) GetSchedules() []ScheduledTransfer {
+	return s.Schedules
+}
+
+// SetSchedules sets the value of Schedules.
+func (s *ScheduledTransferList) SetSchedules(val []ScheduledTransfer) {
+	s.Schedules = val
+}
+
+func (*ScheduledTransferList) aPISchedulesGetRes() {}
+
+// Ref: #/components/schemas/SendCoinRequest
+type SendCoinRequest struct {
+	// Имя пользователя, которому нужно отправить монеты.
+	ToUser string `json:"toUser"`
+	// Количество монет, которые необходимо отправить.
+	Amount int `json:"amount"`
+	// Сообщение получателю. Управляющие символы удаляются,
+	// пробелы схлопываются.
+	Message  OptString           `json:"message"`
+	Category OptTransferCategory `json:"category"`
+	// Показывать перевод в ленте благодарностей /api/kudos. По
+	// умолчанию перевод виден только участникам.
+	Public OptBool `json:"public"`
+	// Сначала расходовать бюджет на благодарности, а
+	// недостающее – с личного баланса. По умолчанию
+	// перевод идёт с личного баланса.
+	UseBudget OptBool `json:"useBudget"`
+}
+
+// GetToUser returns the value of ToUser.
+func (s *SendCoinRequest) GetToUser() string {
+	return s.ToUser
+}
+
+// GetAmount returns the value of Amount.
+func (s *SendCoinRequest) GetAmount() int {
+	return s.Amount
+}
+
+// GetMessage returns the value of Message.
+func (s *SendCoinRequest) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCategory returns the value of Category.
+func (s *SendCoinRequest) GetCategory() OptTransferCategory {
+	return s.Category
+}
+
+// GetPublic returns the value of Public.
+func (s *SendCoinRequest) GetPublic() OptBool {
+	return s.Public
+}
+
+// GetUseBudget returns the value of UseBudget.
+func (s *SendCoinRequest) GetUseBudget() OptBool {
+	return s.UseBudget
+}
+
+// SetToUser sets the value of ToUser.
+func (s *SendCoinRequest) SetToUser(val string) {
+	s.ToUser = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *SendCoinRequest) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetMessage sets the value of Message.
+func (s *SendCoinRequest) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCategory sets the value of Category.
+func (s *SendCoinRequest) SetCategory(val OptTransferCategory) {
+	s.Category = val
+}
+
+// SetPublic sets the value of Public.
+func (s *SendCoinRequest) SetPublic(val OptBool) {
+	s.Public = val
+}
+
+// SetUseBudget sets the value of UseBudget.
+func (s *SendCoinRequest) SetUseBudget(val OptBool) {
+	s.UseBudget = val
+}
+
+// Ref: #/components/schemas/ServiceAccountRequest
+type ServiceAccountRequest struct {
+	// Имя сервисного аккаунта.
+	Username    string    `json:"username"`
+	DisplayName OptString `json:"displayName"`
+	// Начальный баланс.
+	Coins OptInt `json:"coins"`
+}
+
+// GetUsername returns the value of Username.
+func (s *ServiceAccountRequest) GetUsername() string {
+	return s.Username
+}
+
+// GetDisplayName returns the value of DisplayName.
+func (s *ServiceAccountRequest) GetDisplayName() OptString {
+	return s.DisplayName
+}
+
+// GetCoins returns the value of Coins.
+func (s *ServiceAccountRequest) GetCoins() OptInt {
+	return s.Coins
+}
+
+// SetUsername sets the value of Username.
+func (s *ServiceAccountRequest) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetDisplayName sets the value of DisplayName.
+func (s *ServiceAccountRequest) SetDisplayName(val OptString) {
+	s.DisplayName = val
+}
+
+// SetCoins sets the value of Coins.
+func (s *ServiceAccountRequest) SetCoins(val OptInt) {
+	s.Coins = val
+}
+
+// Ref: #/components/schemas/ServiceAccountResponse
+type ServiceAccountResponse struct {
+	Username string `json:"username"`
+	Coins    int    `json:"coins"`
+}
+
+// GetUsername returns the value of Username.
+func (s *ServiceAccountResponse) GetUsername() string {
+	return s.Username
+}
+
+// GetCoins returns the value of Coins.
+func (s *ServiceAccountResponse) GetCoins() int {
+	return s.Coins
+}
+
+// SetUsername sets the value of Username.
+func (s *ServiceAccountResponse) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetCoins sets the value of Coins.
+func (s *ServiceAccountResponse) SetCoins(val int) {
+	s.Coins = val
+}
+
+func (*ServiceAccountResponse) aPIAdminServiceAccountsPostRes() {}
+
+// Ref: #/components/schemas/TotpEnrollResponse
+type TotpEnrollResponse struct {
+	// Секрет в base32 для ручного ввода.
+	Secret string `json:"secret"`
+	// URI otpauth://totp/... для QR-кода.
+	OtpauthUri string `json:"otpauthUri"`
+}
+
+// GetSecret returns the value of Secret.
+func (s *TotpEnrollResponse) GetSecret() string {
+	return s.Secret
+}
+
+// GetOtpauthUri returns the value of OtpauthUri.
+func (s *TotpEnrollResponse) GetOtpauthUri() string {
+	return s.OtpauthUri
+}
+
+// SetSecret sets the value of Secret.
+func (s *TotpEnrollResponse) SetSecret(val string) {
+	s.Secret = val
+}
+
+// SetOtpauthUri sets the value of OtpauthUri.
+func (s *TotpEnrollResponse) SetOtpauthUri(val string) {
+	s.OtpauthUri = val
+}
+
+func (*TotpEnrollResponse) aPI2FATotpPostRes() {}
+
+// Повод для перевода.
+// Ref: #/components/schemas/TransferCategory
+type TransferCategory string
+
+const (
+	TransferCategoryThanks      TransferCategory = "thanks"
+	TransferCategoryTeamwork    TransferCategory = "teamwork"
+	TransferCategoryHelp        TransferCategory = "help"
+	TransferCategoryMentoring   TransferCategory = "mentoring"
+	TransferCategoryCelebration TransferCategory = "celebration"
+	TransferCategoryOther       TransferCategory = "other"
+)
+
+// AllValues returns all TransferCategory values.
+func (TransferCategory) AllValues() []TransferCategory {
+	return []TransferCategory{
+		TransferCategoryThanks,
+		TransferCategoryTeamwork,
+		TransferCategoryHelp,
+		TransferCategoryMentoring,
+		TransferCategoryCelebration,
+		TransferCategoryOther,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TransferCategory) MarshalText() ([]byte, error) {
+	switch s {
+	case TransferCategoryThanks:
+		return []byte(s), nil
+	case TransferCategoryTeamwork:
+		return []byte(s), nil
+	case TransferCategoryHelp:
+		return []byte(s), nil
+	case TransferCategoryMentoring:
+		return []byte(s), nil
+	case TransferCategoryCelebration:
+		return []byte(s), nil
+	case TransferCategoryOther:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TransferCategory) UnmarshalText(data []byte) error {
+	switch TransferCategory(data) {
+	case TransferCategoryThanks:
+		*s = TransferCategoryThanks
+		return nil
+	case TransferCategoryTeamwork:
+		*s = TransferCategoryTeamwork
+		return nil
+	case TransferCategoryHelp:
+		*s = TransferCategoryHelp
+		return nil
+	case TransferCategoryMentoring:
+		*s = TransferCategoryMentoring
+		return nil
+	case TransferCategoryCelebration:
+		*s = TransferCategoryCelebration
+		return nil
+	case TransferCategoryOther:
+		*s = TransferCategoryOther
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Completed – перевод выполнен; awaiting_approval – ждёт одобрения
+// руководителя отправителя; awaiting_acceptance – ждёт
+// подтверждения получателя; accepted, declined, rejected, cancelled, expired
+// – ожидающий перевод принят получателем, отклонён
+// получателем, отклонён руководителем, отменён
+// отправителем или истёк. Во всех случаях, кроме accepted,
+// монеты возвращаются отправителю.
+// Ref: #/components/schemas/TransferStatus
+type TransferStatus string
+
+const (
+	TransferStatusCompleted          TransferStatus = "completed"
+	TransferStatusAwaitingApproval   TransferStatus = "awaiting_approval"
+	TransferStatusAwaitingAcceptance TransferStatus = "awaiting_acceptance"
+	TransferStatusAccepted           TransferStatus = "accepted"
+	TransferStatusDeclined           TransferStatus = "declined"
+	TransferStatusRejected           TransferStatus = "rejected"
+	TransferStatusCancelled          TransferStatus = "cancelled"
+	TransferStatusExpired            TransferStatus = "expired"
+)
+
+// AllValues returns all TransferStatus values.
+func (TransferStatus) AllValues() []TransferStatus {
+	return []TransferStatus{
+		TransferStatusCompleted,
+		TransferStatusAwaitingApproval,
+		TransferStatusAwaitingAcceptance,
+		TransferStatusAccepted,
+		TransferStatusDeclined,
+		TransferStatusRejected,
+		TransferStatusCancelled,
+		TransferStatusExpired,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TransferStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case TransferStatusCompleted:
+		return []byte(s), nil
+	case TransferStatusAwaitingApproval:
+		return []byte(s), nil
+	case TransferStatusAwaitingAcceptance:
+		return []byte(s), nil
+	case TransferStatusAccepted:
+		return []byte(s), nil
+	case TransferStatusDeclined:
+		return []byte(s), nil
+	case TransferStatusRejected:
+		return []byte(s), nil
+	case TransferStatusCancelled:
+		return []byte(s), nil
+	case TransferStatusExpired:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TransferStatus) UnmarshalText(data []byte) error {
+	switch TransferStatus(data) {
+	case TransferStatusCompleted:
+		*s = TransferStatusCompleted
+		return nil
+	case TransferStatusAwaitingApproval:
+		*s = TransferStatusAwaitingApproval
+		return nil
+	case TransferStatusAwaitingAcceptance:
+		*s = TransferStatusAwaitingAcceptance
+		return nil
+	case TransferStatusAccepted:
+		*s = TransferStatusAccepted
+		return nil
+	case TransferStatusDeclined:
+		*s = TransferStatusDeclined
+		return nil
+	case TransferStatusRejected:
+		*s = TransferStatusRejected
+		return nil
+	case TransferStatusCancelled:
+		*s = TransferStatusCancelled
+		return nil
+	case TransferStatusExpired:
+		*s = TransferStatusExpired
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/UserStatusRequest
+type UserStatusRequest struct {
+	Status AccountStatus `json:"status"`
+}
+
+// GetStatus returns the value of Status.
+func (s *UserStatusRequest) GetStatus() AccountStatus {
+	return s.Status
+}
+
+// SetStatus sets the value of Status.
+func (s *UserStatusRequest) SetStatus(val AccountStatus) {
+	s.Status = val
+}
+
+// Ref: #/components/schemas/UserStatusResponse
+type UserStatusResponse struct {
+	Username string        `json:"username"`
+	Status   AccountStatus `json:"status"`
+}
+
+// GetUsername returns the value of Username.
+func (s *UserStatusResponse) GetUsername() string {
+	return s.Username
+}
+
+// GetStatus returns the value of Status.
+func (s *UserStatusResponse) GetStatus() AccountStatus {
+	return s.Status
+}
+
+// SetUsername sets the value of Username.
+func (s *UserStatusResponse) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetStatus sets the value of Status.
+func (s *UserStatusResponse) SetStatus(val AccountStatus) {
+	s.Status = val
+}
+
+func (*UserStatusResponse) aPIAdminUsersUsernameStatusPutRes() {}
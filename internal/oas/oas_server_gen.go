@@ -0,0 +1,329 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package oas
+
+import (
+	"context"
+)
+
+// Handler handles operations described by OpenAPI v3 specification.
+type Handler interface {
+	// API2FATotpConfirmPost implements POST /api/2fa/totp/confirm operation.
+	//
+	// Подтверждение подключения TOTP первым кодом из
+	// приложения.
+	//
+	// POST /api/2fa/totp/confirm
+	API2FATotpConfirmPost(ctx context.Context, req *MfaCodeRequest) (API2FATotpConfirmPostRes, error)
+	// API2FATotpDisablePost implements POST /api/2fa/totp/disable operation.
+	//
+	// Отключение двухфакторной аутентификации; требуется
+	// TOTP-код или код восстановления.
+	//
+	// POST /api/2fa/totp/disable
+	API2FATotpDisablePost(ctx context.Context, req *MfaCodeRequest) (API2FATotpDisablePostRes, error)
+	// API2FATotpPost implements POST /api/2fa/totp operation.
+	//
+	// Начало подключения TOTP: выдаётся новый секрет.
+	// Двухфакторная аутентификация включается после
+	// подтверждения кодом.
+	//
+	// POST /api/2fa/totp
+	API2FATotpPost(ctx context.Context) (API2FATotpPostRes, error)
+	// APIAdminDisputesDisputeIdResolvePost implements POST /api/admin/disputes/{disputeId}/resolve operation.
+	//
+	// Решить спор: отменить перевод или отказать (только
+	// для администраторов).
+	//
+	// POST /api/admin/disputes/{disputeId}/resolve
+	APIAdminDisputesDisputeIdResolvePost(ctx context.Context, req *DisputeResolveRequest, params APIAdminDisputesDisputeIdResolvePostParams) (APIAdminDisputesDisputeIdResolvePostRes, error)
+	// APIAdminDisputesGet implements GET /api/admin/disputes operation.
+	//
+	// Споры по переводам (только для администраторов).
+	//
+	// GET /api/admin/disputes
+	APIAdminDisputesGet(ctx context.Context, params APIAdminDisputesGetParams) (APIAdminDisputesGetRes, error)
+	// APIAdminFraudFlagsFlagIdResolvePost implements POST /api/admin/fraud-flags/{flagId}/resolve operation.
+	//
+	// Закрыть отметку по итогам проверки (только для
+	// администраторов). Отклонение отметки снимает
+	// блокировку, наложенную анализатором.
+	//
+	// POST /api/admin/fraud-flags/{flagId}/resolve
+	APIAdminFraudFlagsFlagIdResolvePost(ctx context.Context, req *FraudFlagResolveRequest, params APIAdminFraudFlagsFlagIdResolvePostParams) (APIAdminFraudFlagsFlagIdResolvePostRes, error)
+	// APIAdminFraudFlagsGet implements GET /api/admin/fraud-flags operation.
+	//
+	// Подозрительные переводы, найденные анализатором
+	// (только для администраторов).
+	//
+	// GET /api/admin/fraud-flags
+	APIAdminFraudFlagsGet(ctx context.Context, params APIAdminFraudFlagsGetParams) (APIAdminFraudFlagsGetRes, error)
+	// APIAdminFraudFlagsScanPost implements POST /api/admin/fraud-flags/scan operation.
+	//
+	// Запустить анализ переводов вне расписания (только
+	// для администраторов).
+	//
+	// POST /api/admin/fraud-flags/scan
+	APIAdminFraudFlagsScanPost(ctx context.Context) (APIAdminFraudFlagsScanPostRes, error)
+	// APIAdminImportUsersPost implements POST /api/admin/import-users operation.
+	//
+	// Импорт справочника сотрудников (только для
+	// администраторов).
+	//
+	// POST /api/admin/import-users
+	APIAdminImportUsersPost(ctx context.Context, req APIAdminImportUsersPostReq, params APIAdminImportUsersPostParams) (APIAdminImportUsersPostRes, error)
+	// APIAdminServiceAccountsPost implements POST /api/admin/service-accounts operation.
+	//
+	// Создание сервисного аккаунта для интеграций (только
+	// для администраторов).
+	//
+	// POST /api/admin/service-accounts
+	APIAdminServiceAccountsPost(ctx context.Context, req *ServiceAccountRequest) (APIAdminServiceAccountsPostRes, error)
+	// APIAdminServiceAccountsUsernameKeysGet implements GET /api/admin/service-accounts/{username}/keys operation.
+	//
+	// Список API-ключей сервисного аккаунта (только для
+	// администраторов).
+	//
+	// GET /api/admin/service-accounts/{username}/keys
+	APIAdminServiceAccountsUsernameKeysGet(ctx context.Context, params APIAdminServiceAccountsUsernameKeysGetParams) (APIAdminServiceAccountsUsernameKeysGetRes, error)
+	// APIAdminServiceAccountsUsernameKeysKeyIdDelete implements DELETE /api/admin/service-accounts/{username}/keys/{keyId} operation.
+	//
+	// Отзыв API-ключа (только для администраторов).
+	//
+	// DELETE /api/admin/service-accounts/{username}/keys/{keyId}
+	APIAdminServiceAccountsUsernameKeysKeyIdDelete(ctx context.Context, params APIAdminServiceAccountsUsernameKeysKeyIdDeleteParams) (APIAdminServiceAccountsUsernameKeysKeyIdDeleteRes, error)
+	// APIAdminServiceAccountsUsernameKeysPost implements POST /api/admin/service-accounts/{username}/keys operation.
+	//
+	// Выпуск API-ключа; ключ показывается только в этом
+	// ответе (только для администраторов).
+	//
+	// POST /api/admin/service-accounts/{username}/keys
+	APIAdminServiceAccountsUsernameKeysPost(ctx context.Context, req *ApiKeyRequest, params APIAdminServiceAccountsUsernameKeysPostParams) (APIAdminServiceAccountsUsernameKeysPostRes, error)
+	// APIAdminUsersUsernameOffboardPost implements POST /api/admin/users/{username}/offboard operation.
+	//
+	// Увольнение: деактивация, отзыв токенов и передача
+	// остатка баланса (только для администраторов).
+	//
+	// POST /api/admin/users/{username}/offboard
+	APIAdminUsersUsernameOffboardPost(ctx context.Context, req OptOffboardRequest, params APIAdminUsersUsernameOffboardPostParams) (APIAdminUsersUsernameOffboardPostRes, error)
+	// APIAdminUsersUsernamePasswordResetPost implements POST /api/admin/users/{username}/password-reset operation.
+	//
+	// Выдача одноразового токена сброса пароля (только для
+	// администраторов).
+	//
+	// POST /api/admin/users/{username}/password-reset
+	APIAdminUsersUsernamePasswordResetPost(ctx context.Context, params APIAdminUsersUsernamePasswordResetPostParams) (APIAdminUsersUsernamePasswordResetPostRes, error)
+	// APIAdminUsersUsernameStatusPut implements PUT /api/admin/users/{username}/status operation.
+	//
+	// Изменение статуса учётной записи (только для
+	// администраторов).
+	//
+	// PUT /api/admin/users/{username}/status
+	APIAdminUsersUsernameStatusPut(ctx context.Context, req *UserStatusRequest, params APIAdminUsersUsernameStatusPutParams) (APIAdminUsersUsernameStatusPutRes, error)
+	// APIAuth2FAPost implements POST /api/auth/2fa operation.
+	//
+	// Второй шаг входа: проверка TOTP-кода или кода
+	// восстановления.
+	//
+	// POST /api/auth/2fa
+	APIAuth2FAPost(ctx context.Context, req *MfaLoginRequest) (APIAuth2FAPostRes, error)
+	// APIAuthOidcCallbackPost implements POST /api/auth/oidc/callback operation.
+	//
+	// Завершение входа через OIDC: обмен кода авторизации на
+	// JWT-токен сервиса.
+	//
+	// POST /api/auth/oidc/callback
+	APIAuthOidcCallbackPost(ctx context.Context, req *OidcCallbackRequest) (APIAuthOidcCallbackPostRes, error)
+	// APIAuthOidcGet implements GET /api/auth/oidc operation.
+	//
+	// Начало входа через корпоративного OpenID Connect
+	// провайдера: возвращает адрес авторизации.
+	//
+	// GET /api/auth/oidc
+	APIAuthOidcGet(ctx context.Context) (APIAuthOidcGetRes, error)
+	// APIAuthPost implements POST /api/auth operation.
+	//
+	// Аутентификация и получение JWT-токена.
+	//
+	// POST /api/auth
+	APIAuthPost(ctx context.Context, req *AuthRequest) (APIAuthPostRes, error)
+	// APIBuyItemGet implements GET /api/buy/{item} operation.
+	//
+	// Купить предмет за монеты.
+	//
+	// GET /api/buy/{item}
+	APIBuyItemGet(ctx context.Context, params APIBuyItemGetParams) (APIBuyItemGetRes, error)
+	// APIDisputesDisputeIdReturnPost implements POST /api/disputes/{disputeId}/return operation.
+	//
+	// Вернуть монеты по спору (получатель перевода).
+	//
+	// POST /api/disputes/{disputeId}/return
+	APIDisputesDisputeIdReturnPost(ctx context.Context, params APIDisputesDisputeIdReturnPostParams) (APIDisputesDisputeIdReturnPostRes, error)
+	// APIDisputesGet implements GET /api/disputes operation.
+	//
+	// Споры по переводам пользователя и переводам ему.
+	//
+	// GET /api/disputes
+	APIDisputesGet(ctx context.Context) (APIDisputesGetRes, error)
+	// APIInfoGet implements GET /api/info operation.
+	//
+	// Получить информацию о монетах, инвентаре и истории
+	// транзакций.
+	//
+	// GET /api/info
+	APIInfoGet(ctx context.Context) (APIInfoGetRes, error)
+	// APIKudosGet implements GET /api/kudos operation.
+	//
+	// Лента публичных благодарностей: переводы,
+	// отправленные с public=true, от новых к старым. Суммы не
+	// показываются.
+	//
+	// GET /api/kudos
+	APIKudosGet(ctx context.Context, params APIKudosGetParams) (APIKudosGetRes, error)
+	// APINotificationsGet implements GET /api/notifications operation.
+	//
+	// Уведомления пользователя.
+	//
+	// GET /api/notifications
+	APINotificationsGet(ctx context.Context, params APINotificationsGetParams) (APINotificationsGetRes, error)
+	// APINotificationsReadPost implements POST /api/notifications/read operation.
+	//
+	// Отметить все уведомления прочитанными.
+	//
+	// POST /api/notifications/read
+	APINotificationsReadPost(ctx context.Context) (APINotificationsReadPostRes, error)
+	// APIPasswordPost implements POST /api/password operation.
+	//
+	// Смена собственного пароля; выданные ранее токены
+	// отзываются.
+	//
+	// POST /api/password
+	APIPasswordPost(ctx context.Context, req *ChangePasswordRequest) (APIPasswordPostRes, error)
+	// APIPasswordResetPost implements POST /api/password/reset operation.
+	//
+	// Установка нового пароля по одноразовому токену
+	// сброса.
+	//
+	// POST /api/password/reset
+	APIPasswordResetPost(ctx context.Context, req *ResetPasswordRequest) (APIPasswordResetPostRes, error)
+	// APIRegisterPost implements POST /api/register operation.
+	//
+	// Регистрация пользователя и получение JWT-токена.
+	//
+	// POST /api/register
+	APIRegisterPost(ctx context.Context, req *AuthRequest) (APIRegisterPostRes, error)
+	// APISchedulesGet implements GET /api/schedules operation.
+	//
+	// Запланированные переводы пользователя.
+	//
+	// GET /api/schedules
+	APISchedulesGet(ctx context.Context) (APISchedulesGetRes, error)
+	// APISchedulesPost implements POST /api/schedules operation.
+	//
+	// Запланировать перевод на будущее время или по
+	// расписанию.
+	//
+	// POST /api/schedules
+	APISchedulesPost(ctx context.Context, req *ScheduleRequest) (APISchedulesPostRes, error)
+	// APISchedulesScheduleIdCancelPost implements POST /api/schedules/{scheduleId}/cancel operation.
+	//
+	// Отменить расписание.
+	//
+	// POST /api/schedules/{scheduleId}/cancel
+	APISchedulesScheduleIdCancelPost(ctx context.Context, params APISchedulesScheduleIdCancelPostParams) (APISchedulesScheduleIdCancelPostRes, error)
+	// APISchedulesScheduleIdPausePost implements POST /api/schedules/{scheduleId}/pause operation.
+	//
+	// Приостановить расписание.
+	//
+	// POST /api/schedules/{scheduleId}/pause
+	APISchedulesScheduleIdPausePost(ctx context.Context, params APISchedulesScheduleIdPausePostParams) (APISchedulesScheduleIdPausePostRes, error)
+	// APISchedulesScheduleIdResumePost implements POST /api/schedules/{scheduleId}/resume operation.
+	//
+	// Возобновить приостановленное расписание.
+	//
+	// POST /api/schedules/{scheduleId}/resume
+	APISchedulesScheduleIdResumePost(ctx context.Context, params APISchedulesScheduleIdResumePostParams) (APISchedulesScheduleIdResumePostRes, error)
+	// APISendCoinBatchPost implements POST /api/sendCoin/batch operation.
+	//
+	// Отправить монеты нескольким пользователям одним
+	// переводом. Все переводы выполняются в одной
+	// транзакции: при ошибке не выполняется ни один.
+	//
+	// POST /api/sendCoin/batch
+	APISendCoinBatchPost(ctx context.Context, req *BatchSendCoinRequest) (APISendCoinBatchPostRes, error)
+	// APISendCoinPost implements POST /api/sendCoin operation.
+	//
+	// Отправить монеты другому пользователю.
+	//
+	// POST /api/sendCoin
+	APISendCoinPost(ctx context.Context, req *SendCoinRequest) (APISendCoinPostRes, error)
+	// APITransactionsTransactionIdDisputePost implements POST /api/transactions/{transactionId}/dispute operation.
+	//
+	// Запросить отмену своего перевода.
+	//
+	// POST /api/transactions/{transactionId}/dispute
+	APITransactionsTransactionIdDisputePost(ctx context.Context, req *DisputeRequest, params APITransactionsTransactionIdDisputePostParams) (APITransactionsTransactionIdDisputePostRes, error)
+	// APITransfersPendingGet implements GET /api/transfers/pending operation.
+	//
+	// Ожидающие переводы пользователя: входящие, исходящие
+	// и ожидающие его одобрения.
+	//
+	// GET /api/transfers/pending
+	APITransfersPendingGet(ctx context.Context) (APITransfersPendingGetRes, error)
+	// APITransfersTransferIdAcceptPost implements POST /api/transfers/{transferId}/accept operation.
+	//
+	// Принять перевод (получатель).
+	//
+	// POST /api/transfers/{transferId}/accept
+	APITransfersTransferIdAcceptPost(ctx context.Context, params APITransfersTransferIdAcceptPostParams) (APITransfersTransferIdAcceptPostRes, error)
+	// APITransfersTransferIdApprovePost implements POST /api/transfers/{transferId}/approve operation.
+	//
+	// Одобрить крупный перевод (руководитель отправителя
+	// или администратор).
+	//
+	// POST /api/transfers/{transferId}/approve
+	APITransfersTransferIdApprovePost(ctx context.Context, params APITransfersTransferIdApprovePostParams) (APITransfersTransferIdApprovePostRes, error)
+	// APITransfersTransferIdCancelPost implements POST /api/transfers/{transferId}/cancel operation.
+	//
+	// Отменить свой перевод до подтверждения; монеты
+	// возвращаются (отправитель).
+	//
+	// POST /api/transfers/{transferId}/cancel
+	APITransfersTransferIdCancelPost(ctx context.Context, params APITransfersTransferIdCancelPostParams) (APITransfersTransferIdCancelPostRes, error)
+	// APITransfersTransferIdDeclinePost implements POST /api/transfers/{transferId}/decline operation.
+	//
+	// Отклонить перевод; монеты возвращаются отправителю
+	// (получатель).
+	//
+	// POST /api/transfers/{transferId}/decline
+	APITransfersTransferIdDeclinePost(ctx context.Context, params APITransfersTransferIdDeclinePostParams) (APITransfersTransferIdDeclinePostRes, error)
+	// APITransfersTransferIdRejectPost implements POST /api/transfers/{transferId}/reject operation.
+	//
+	// Отклонить крупный перевод; монеты возвращаются
+	// отправителю (руководитель отправителя или
+	// администратор).
+	//
+	// POST /api/transfers/{transferId}/reject
+	APITransfersTransferIdRejectPost(ctx context.Context, params APITransfersTransferIdRejectPostParams) (APITransfersTransferIdRejectPostRes, error)
+}
+
+// Server implements http server based on OpenAPI v3 specification and
+// calls Handler to handle requests.
+type Server struct {
+	h   Handler
+	sec SecurityHandler
+	baseServer
+}
+
+// NewServer creates new Server.
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
+	s, err := newServerConfig(opts...).baseServer()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		h:          h,
+		sec:        sec,
+		baseServer: s,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Приводит сообщение к виду, безопасному для показа в истории и ленте:
+// удаляет управляющие и невидимые символы (в том числе переключатели
+// направления текста) и схлопывает пробельные символы. HTML не
+// экранируется – это задача клиента при выводе. Длина ограничена схемой
+// запроса, а очистка её только уменьшает.
+func sanitizeMessage(message string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range message {
+		switch {
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+			continue
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r), r == unicode.ReplacementChar:
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Сообщение и повод перевода для записи в transactions
+type transferNote struct {
+	Message  sql.NullString
+	Category sql.NullString
+	Public   bool
+}
+
+func newTransferNote(message oas.OptString, category oas.OptTransferCategory, public oas.OptBool) transferNote {
+	var note transferNote
+	if message := sanitizeMessage(message.Or("")); message != "" {
+		note.Message = sql.NullString{String: message, Valid: true}
+	}
+	if category, ok := category.Get(); ok {
+		note.Category = sql.NullString{String: string(category), Valid: true}
+	}
+	note.Public = public.Or(false)
+	return note
+}
+
+func (apiHandler) APIKudosGet(ctx context.Context, params oas.APIKudosGetParams) (oas.APIKudosGetRes, error) {
+	limit := params.Limit.Or(20)
+	// Без курсора лента начинается с самых новых переводов
+	cursor := params.Cursor.Or(0)
+
+	// Запрашивается на одну запись больше, чтобы узнать, есть ли следующая страница.
+	// Возвраты по спорам и возвращённые переводы в ленту не попадают.
+	rows, err := db.QueryContext(ctx, `
+        SELECT t.id, s.username, r.username, t.message, t.category, t.created_at
+        FROM transactions t
+        JOIN users s ON s.id = t.sender_id
+        JOIN users r ON r.id = t.receiver_id
+        WHERE t.public AND ($1 = 0 OR t.id < $1) AND t.reverses_id IS NULL
+          AND NOT EXISTS (SELECT 1 FROM transactions r WHERE r.reverses_id = t.id)
+        ORDER BY t.id DESC
+        LIMIT $2
+    `, cursor, limit+1)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	defer rows.Close()
+
+	feed := &oas.KudosFeed{Items: []oas.Kudos{}}
+	for rows.Next() {
+		var kudos oas.Kudos
+		var message, category sql.NullString
+		var createdAt time.Time
+		if err := rows.Scan(&kudos.ID, &kudos.FromUser, &kudos.ToUser, &message, &category, &createdAt); err != nil {
+			return nil, internalError("Database error", err)
+		}
+		kudos.CreatedAt = createdAt
+		if message.Valid {
+			kudos.Message = oas.NewOptString(message.String)
+		}
+		if category.Valid {
+			kudos.Category = oas.NewOptTransferCategory(oas.TransferCategory(category.String))
+		}
+		feed.Items = append(feed.Items, kudos)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, internalError("Database error", err)
+	}
+
+	if len(feed.Items) > limit {
+		feed.Items = feed.Items[:limit]
+		feed.NextCursor = oas.NewOptInt(feed.Items[limit-1].ID)
+	}
+	return feed, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"Обычный текст", "Спасибо за помощь!", "Спасибо за помощь!"},
+		{"Пробелы схлопываются", "  спасибо \n\n за\tревью  ", "спасибо за ревью"},
+		{"Управляющие символы удаляются", "спа\x00си\x1bбо\u0007", "спасибо"},
+		{"Переключатели направления удаляются", "thanks ‮gnp.exe", "thanks gnp.exe"},
+		{"Невидимые символы удаляются", "hi​‍there", "hithere"},
+		{"Только пробелы", " \n\t ", ""},
+		{"HTML не изменяется", "<b>спасибо</b>", "<b>спасибо</b>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sanitizeMessage(tt.message))
+		})
+	}
+}
+
+func TestSendCoin_MessageValidation(t *testing.T) {
+	token := signTestToken(t, 1)
+
+	code, errResponse := performRawRequest(t, "POST", "/api/sendCoin", token,
+		`{"toUser":"user2","amount":1,"message":"`+strings.Repeat("я", 281)+`"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+	assert.Equal(t, "message", errResponse.Details[0].Field)
+	assert.Equal(t, fieldTooLong, errResponse.Details[0].Code)
+
+	code, errResponse = performRawRequest(t, "POST", "/api/sendCoin", token, `{"toUser":"user2","amount":1,"category":"bribe"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+	assert.Equal(t, "category", errResponse.Details[0].Field)
+
+	code, _ = performRawRequest(t, "GET", "/api/kudos?limit=101", token, "")
+	assert.Equal(t, http.StatusBadRequest, code, "Размер страницы ограничен")
+}
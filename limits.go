@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Ограничения переводов; нулевое значение отключает ограничение. Сутки
+// и месяц – календарные, по часам сервера БД.
+type transferPolicy struct {
+	// Максимальная сумма одного перевода
+	MaxAmount int
+	// Сколько пользователь может отправить за сутки и за месяц
+	DailyLimit   int
+	MonthlyLimit int
+	// Сколько пользователь может получить за сутки
+	DailyReceiveLimit int
+	// Минимальный интервал между переводами одному и тому же получателю
+	RecipientCooldown time.Duration
+}
+
+var transferLimits transferPolicy
+
+// Переводы, учитываемые ограничениями: выполненные и ожидающие подтверждения,
+// монеты которых уже зарезервированы. Возвраты по спорам не учитываются.
+const limitedTransfers = `(
+        SELECT sender_id, receiver_id, amount, created_at FROM transactions WHERE reverses_id IS NULL
+        UNION ALL
+        SELECT sender_id, receiver_id, amount, created_at FROM pending_transfers
+        WHERE status IN ('awaiting_approval', 'awaiting_acceptance')
+    ) t`
+
+// Читает ограничения из TRANSFER_MAX_AMOUNT, TRANSFER_DAILY_LIMIT,
+// TRANSFER_MONTHLY_LIMIT, TRANSFER_DAILY_RECEIVE_LIMIT и
+// TRANSFER_RECIPIENT_COOLDOWN
+func initTransferLimits() error {
+	var env envReader
+	policy := transferPolicy{
+		MaxAmount:         env.Int("TRANSFER_MAX_AMOUNT", 0),
+		DailyLimit:        env.Int("TRANSFER_DAILY_LIMIT", 0),
+		MonthlyLimit:      env.Int("TRANSFER_MONTHLY_LIMIT", 0),
+		DailyReceiveLimit: env.Int("TRANSFER_DAILY_RECEIVE_LIMIT", 0),
+		RecipientCooldown: env.Duration("TRANSFER_RECIPIENT_COOLDOWN", 0),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if policy.MaxAmount < 0 || policy.DailyLimit < 0 || policy.MonthlyLimit < 0 || policy.DailyReceiveLimit < 0 || policy.RecipientCooldown < 0 {
+		return fmt.Errorf("transfer limits must not be negative")
+	}
+	transferLimits = policy
+	return nil
+}
+
+// Проверяет переводы по ограничениям. Вызывается в транзакции перевода после
+// блокировки строк отправителя и получателей, поэтому параллельные переводы
+// не могут вместе превысить ограничение.
+func (p transferPolicy) check(ctx context.Context, tx *sql.Tx, senderID int, legs []transferLeg) error {
+	failed := &transferFailure{}
+	total := 0
+	for _, leg := range legs {
+		total += leg.Amount
+		if p.MaxAmount > 0 && leg.Amount > p.MaxAmount {
+			failed.add(leg, badRequest(codeAmountLimitExceeded, "Transfer exceeds the maximum of "+strconv.Itoa(p.MaxAmount)+" coins"))
+		}
+	}
+	if err := failed.err(); err != nil {
+		return err
+	}
+
+	if p.DailyLimit > 0 || p.MonthlyLimit > 0 {
+		var daily, monthly int
+		err := tx.QueryRowContext(ctx, `
+            SELECT COALESCE(SUM(amount) FILTER (WHERE created_at >= date_trunc('day', NOW())), 0),
+                COALESCE(SUM(amount), 0)
+            FROM `+limitedTransfers+`
+            WHERE sender_id = $1 AND created_at >= date_trunc('month', NOW())
+        `, senderID).Scan(&daily, &monthly)
+		if err != nil {
+			return internalError("Database error", err)
+		}
+		if p.DailyLimit > 0 && daily+total > p.DailyLimit {
+			return badRequest(codeDailyLimitExceeded, fmt.Sprintf("Daily transfer limit of %d coins exceeded, %d left today", p.DailyLimit, max(p.DailyLimit-daily, 0)))
+		}
+		if p.MonthlyLimit > 0 && monthly+total > p.MonthlyLimit {
+			return badRequest(codeMonthlyLimitExceeded, fmt.Sprintf("Monthly transfer limit of %d coins exceeded, %d left this month", p.MonthlyLimit, max(p.MonthlyLimit-monthly, 0)))
+		}
+	}
+
+	receiverIDs := make([]int, len(legs))
+	for i, leg := range legs {
+		receiverIDs[i] = leg.ReceiverID
+	}
+
+	if p.DailyReceiveLimit > 0 {
+		received, err := userValues(ctx, tx, `
+            SELECT receiver_id, SUM(amount) FROM `+limitedTransfers+`
+            WHERE receiver_id = ANY($1) AND created_at >= date_trunc('day', NOW())
+            GROUP BY receiver_id
+        `, pq.Array(receiverIDs))
+		if err != nil {
+			return internalError("Database error", err)
+		}
+		for _, leg := range legs {
+			if received[leg.ReceiverID]+leg.Amount > p.DailyReceiveLimit {
+				failed.add(leg, badRequest(codeReceiveLimitExceeded, "Receiver has reached the daily limit of received coins"))
+			}
+		}
+		if err := failed.err(); err != nil {
+			return err
+		}
+	}
+
+	if p.RecipientCooldown > 0 {
+		// Секунды до окончания интервала для каждого получателя, которому
+		// отправитель уже переводил монеты в пределах интервала
+		wait, err := userValues(ctx, tx, `
+            SELECT receiver_id, CEIL(EXTRACT(EPOCH FROM MAX(created_at) + make_interval(secs => $3) - NOW()))::int
+            FROM `+limitedTransfers+`
+            WHERE sender_id = $1 AND receiver_id = ANY($2) AND created_at > NOW() - make_interval(secs => $3)
+            GROUP BY receiver_id
+        `, senderID, pq.Array(receiverIDs), p.RecipientCooldown.Seconds())
+		if err != nil {
+			return internalError("Database error", err)
+		}
+		for _, leg := range legs {
+			if seconds, ok := wait[leg.ReceiverID]; ok {
+				failed.add(leg, tooManyRequests(codeRecipientCooldown, "Transfers to the same recipient are limited to one per "+p.RecipientCooldown.String(), time.Duration(max(seconds, 1))*time.Second))
+			}
+		}
+		if err := failed.err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Выполняет запрос, возвращающий пары (id пользователя, число), и собирает их в map
+func userValues(ctx context.Context, tx *sql.Tx, query string, args ...any) (map[int]int, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[int]int)
+	for rows.Next() {
+		var userID, value int
+		if err := rows.Scan(&userID, &value); err != nil {
+			return nil, err
+		}
+		result[userID] = value
+	}
+	return result, rows.Err()
+}
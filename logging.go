@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// Логгер сервиса; до вызова initLogger ничего не пишет
+var logger = zap.NewNop()
+
+// Создаёт JSON-логгер с уровнем из переменной окружения LOG_LEVEL (по умолчанию info)
+func initLogger() (*zap.Logger, error) {
+	cfg := zap.NewProductionConfig()
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		lvl, err := zap.ParseAtomicLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Level = lvl
+	}
+	cfg.EncoderConfig.TimeKey = "time"
+	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	return cfg.Build()
+}
+
+type requestLogKey struct{}
+
+// Данные запроса, которые обработчики дополняют по ходу работы
+type requestLog struct {
+	logger   *zap.Logger
+	user     string
+	userID   int
+	clientIP string
+}
+
+// Возвращает логгер текущего запроса с его request_id
+func requestLogger(ctx context.Context) *zap.Logger {
+	if state, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		return state.logger
+	}
+	return logger
+}
+
+// IP клиента текущего запроса; пустая строка вне HTTP-запроса
+func requestClientIP(ctx context.Context) string {
+	if state, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		return state.clientIP
+	}
+	return ""
+}
+
+// Запоминает пользователя запроса для access-лога
+func setRequestUser(ctx context.Context, username string) {
+	if state, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		state.user = username
+	}
+}
+
+// Запоминает ID аутентифицированного пользователя для access-лога
+func setRequestUserID(ctx context.Context, userID int) {
+	if state, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		state.userID = userID
+	}
+}
+
+// Middleware, присваивающая запросу идентификатор и пишущая access-лог
+func logRequests(name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+
+		reqLogger := logger.With(zap.String("request_id", requestID))
+		if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+			reqLogger = reqLogger.With(zap.String("trace_id", sc.TraceID().String()))
+		}
+		state := &requestLog{logger: reqLogger, clientIP: clientIP(r)}
+		r = r.WithContext(context.WithValue(r.Context(), requestLogKey{}, state))
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		state.logger.Info("request",
+			zap.String("route", name),
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.String("user", state.user),
+			zap.Int("user_id", state.userID),
+			zap.Int("status", rec.status),
+			zap.Duration("duration", time.Since(start)),
+		)
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Подменяет глобальный логгер на наблюдаемый на время теста
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zap.DebugLevel)
+	previous := logger
+	logger = zap.New(core)
+	t.Cleanup(func() { logger = previous })
+	return logs
+}
+
+// Тест access-лога и проброса X-Request-ID
+func TestLogRequests(t *testing.T) {
+	logs := observeLogs(t)
+
+	handler := logRequests("TestRoute", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setRequestUser(r.Context(), "testuser")
+		handleAPIError(r.Context(), w, r, internalError("Database error", errors.New("connection refused")))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set(requestIDHeader, "req-42")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	// Клиент получает переданный идентификатор, но не текст исходной ошибки
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code, "Ожидался код 500")
+	assert.Equal(t, "req-42", recorder.Header().Get(requestIDHeader), "Идентификатор запроса должен вернуться в ответе")
+	assert.NotContains(t, recorder.Body.String(), "connection refused", "Ошибка БД не должна попадать в ответ")
+
+	errorLogs := logs.FilterMessage("Database error").All()
+	if assert.Len(t, errorLogs, 1, "Ожидалась запись об ошибке") {
+		fields := errorLogs[0].ContextMap()
+		assert.Equal(t, "req-42", fields["request_id"])
+		assert.Equal(t, "connection refused", fields["error"])
+	}
+
+	accessLogs := logs.FilterMessage("request").All()
+	if assert.Len(t, accessLogs, 1, "Ожидалась запись access-лога") {
+		fields := accessLogs[0].ContextMap()
+		assert.Equal(t, "TestRoute", fields["route"])
+		assert.Equal(t, "testuser", fields["user"])
+		assert.EqualValues(t, http.StatusInternalServerError, fields["status"])
+	}
+}
+
+// Тест генерации идентификатора запроса, если клиент его не передал
+func TestLogRequests_GeneratesRequestID(t *testing.T) {
+	observeLogs(t)
+
+	handler := logRequests("TestRoute", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respondWithJSON(w, http.StatusOK, map[string]string{"message": "ok"})
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.NotEmpty(t, recorder.Header().Get(requestIDHeader), "Идентификатор запроса должен быть сгенерирован")
+}
@@ -0,0 +1,512 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Срок жизни монет. Баланс хранится партиями с датой начисления; покупки
+// и переводы тратят сначала самые старые партии, а переведённые монеты
+// сохраняют исходный срок. Истёкшие партии сжигает фоновая задача.
+type lotPolicy struct {
+	// Через сколько начисленные монеты сгорают; 0 – не сгорают
+	TTL time.Duration
+	// Период проверки истёкших партий
+	ExpiryInterval time.Duration
+}
+
+var coinLots = lotPolicy{TTL: 365 * 24 * time.Hour, ExpiryInterval: time.Hour}
+
+// Сколько ближайших сгораний показывается в /api/info
+const upcomingExpirations = 5
+
+// Читает COIN_LOT_TTL и COIN_EXPIRY_INTERVAL
+func initCoinLots() error {
+	var env envReader
+	policy := lotPolicy{
+		TTL:            env.Duration("COIN_LOT_TTL", coinLots.TTL),
+		ExpiryInterval: env.Duration("COIN_EXPIRY_INTERVAL", coinLots.ExpiryInterval),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if policy.TTL < 0 || policy.ExpiryInterval <= 0 {
+		return fmt.Errorf("invalid coin expiry settings")
+	}
+	coinLots = policy
+	return nil
+}
+
+// Монеты одной партии, переходящие к другому владельцу вместе с датами.
+// Нулевой GrantedAt – новое начисление: оно выдаётся в момент зачисления
+// и сгорает через TTL.
+type lotPortion struct {
+	Amount    int
+	GrantedAt time.Time
+	ExpiresAt sql.NullTime
+}
+
+// Новое начисление; нулевая сумма – без партии
+func freshLot(amount int) []lotPortion {
+	if amount <= 0 {
+		return nil
+	}
+	return []lotPortion{{Amount: amount}}
+}
+
+func sumPortions(portions []lotPortion) int {
+	total := 0
+	for _, p := range portions {
+		total += p.Amount
+	}
+	return total
+}
+
+// Делит части по порядку: первые amount монет и остаток. Часть на границе
+// делится между ними.
+func splitPortions(portions []lotPortion, amount int) (head, tail []lotPortion) {
+	for i, p := range portions {
+		if amount <= 0 {
+			return head, append(tail, portions[i:]...)
+		}
+		if p.Amount <= amount {
+			head = append(head, p)
+			amount -= p.Amount
+			continue
+		}
+		part := p
+		part.Amount = amount
+		head = append(head, part)
+		p.Amount -= amount
+		amount = 0
+		tail = append(tail, p)
+	}
+	return head, tail
+}
+
+// Забирает amount монет из партий пользователя, начиная с самых старых.
+// balance – баланс до списания: его часть, не покрытая партиями, тратится
+// первой и у нового владельца становится новым начислением. Строка
+// пользователя должна быть заблокирована.
+func takeLots(ctx context.Context, tx *sql.Tx, userID, balance, amount int) ([]lotPortion, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, remaining, granted_at, expires_at FROM coin_lots
+        WHERE user_id = $1 AND remaining > 0 AND pending_transfer_id IS NULL
+        ORDER BY granted_at, id
+        FOR UPDATE
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	var lots []lotPortion
+	for rows.Next() {
+		var id int64
+		var p lotPortion
+		if err := rows.Scan(&id, &p.Amount, &p.GrantedAt, &p.ExpiresAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+		lots = append(lots, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var taken []lotPortion
+	if untracked := min(balance-sumPortions(lots), amount); untracked > 0 {
+		taken = freshLot(untracked)
+		amount -= untracked
+	}
+	head, _ := splitPortions(lots, amount)
+	if len(head) == 0 {
+		return taken, nil
+	}
+	amounts := make([]int64, len(head))
+	for i, p := range head {
+		amounts[i] = int64(p.Amount)
+	}
+	_, err = tx.ExecContext(ctx, `
+        UPDATE coin_lots l SET remaining = l.remaining - x.amount
+        FROM unnest($1::int[], $2::int[]) AS x(id, amount)
+        WHERE l.id = x.id
+    `, pq.Array(ids[:len(head)]), pq.Array(amounts))
+	if err != nil {
+		return nil, err
+	}
+	return append(taken, head...), nil
+}
+
+// Создаёт партии пользователя; pendingTransferID 0 – партии доступны сразу,
+// иначе удерживаются до завершения ожидающего перевода
+func insertLots(ctx context.Context, tx *sql.Tx, userID, pendingTransferID int, portions []lotPortion) error {
+	grants := make([]lotGrant, len(portions))
+	for i, p := range portions {
+		grants[i] = lotGrant{UserID: userID, PendingTransferID: pendingTransferID, lotPortion: p}
+	}
+	return insertLotGrants(ctx, tx, grants)
+}
+
+// Партия, выдаваемая конкретному пользователю
+type lotGrant struct {
+	UserID            int
+	PendingTransferID int
+	lotPortion
+}
+
+// Создаёт партии одним запросом. Срок новых начислений считается только
+// здесь, чтобы правила партий не расходились между местами начисления.
+func insertLotGrants(ctx context.Context, tx *sql.Tx, grants []lotGrant) error {
+	if len(grants) == 0 {
+		return nil
+	}
+	var userIDs, amounts, pendingIDs []int64
+	var grantedAt, expiresAt []string
+	for _, g := range grants {
+		if g.Amount <= 0 {
+			continue
+		}
+		userIDs = append(userIDs, int64(g.UserID))
+		amounts = append(amounts, int64(g.Amount))
+		pendingIDs = append(pendingIDs, int64(g.PendingTransferID))
+		grantedAt = append(grantedAt, formatLotTime(g.GrantedAt))
+		var expires time.Time
+		if g.ExpiresAt.Valid {
+			expires = g.ExpiresAt.Time
+		}
+		expiresAt = append(expiresAt, formatLotTime(expires))
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO coin_lots (user_id, amount, remaining, granted_at, expires_at, pending_transfer_id)
+        SELECT x.user_id, x.amount, x.amount, COALESCE(NULLIF(x.granted_at, '')::timestamptz, NOW()),
+            CASE WHEN x.granted_at <> '' THEN NULLIF(x.expires_at, '')::timestamptz
+                 WHEN $6::float8 > 0 THEN NOW() + make_interval(secs => $6) END,
+            NULLIF(x.pending_transfer_id, 0)
+        FROM unnest($1::int[], $2::int[], $3::text[], $4::text[], $5::int[])
+            AS x(user_id, amount, granted_at, expires_at, pending_transfer_id)
+    `, pq.Array(userIDs), pq.Array(amounts), pq.Array(grantedAt), pq.Array(expiresAt), pq.Array(pendingIDs),
+		coinLots.TTL.Seconds())
+	return err
+}
+
+// Нулевое время передаётся пустой строкой и становится NULL
+func formatLotTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// Учётная запись, создаваемая со стартовым балансом
+type newAccount struct {
+	Username    string
+	Password    sql.NullString
+	Coins       int
+	Service     bool
+	DisplayName string
+	Department  string
+	HireDate    string
+	Imported    bool
+}
+
+// Создаёт учётные записи и выдаёт стартовый баланс первой партией монет.
+// Возвращает ID в порядке accounts; 0 – имя уже занято.
+func insertAccounts(ctx context.Context, tx *sql.Tx, accounts []newAccount) ([]int, error) {
+	var usernames, names, departments, hireDates, kinds []string
+	var passwords []sql.NullString
+	var coins []int64
+	var imported []bool
+	for _, a := range accounts {
+		kind := "person"
+		if a.Service {
+			kind = "service"
+		}
+		usernames = append(usernames, a.Username)
+		passwords = append(passwords, a.Password)
+		coins = append(coins, int64(a.Coins))
+		kinds = append(kinds, kind)
+		names = append(names, a.DisplayName)
+		departments = append(departments, a.Department)
+		hireDates = append(hireDates, a.HireDate)
+		imported = append(imported, a.Imported)
+	}
+	rows, err := tx.QueryContext(ctx, `
+        INSERT INTO users (username, password, coins, kind, display_name, department, hire_date, imported_at)
+        SELECT x.username, x.password, x.coins, x.kind, NULLIF(x.display_name, ''), NULLIF(x.department, ''),
+            NULLIF(x.hire_date, '')::date, CASE WHEN x.imported THEN NOW() END
+        FROM unnest($1::text[], $2::text[], $3::int[], $4::text[], $5::text[], $6::text[], $7::text[], $8::bool[])
+            AS x(username, password, coins, kind, display_name, department, hire_date, imported)
+        ON CONFLICT ((lower(username))) DO NOTHING
+        RETURNING id, lower(username)
+    `, pq.Array(usernames), pq.Array(passwords), pq.Array(coins), pq.Array(kinds),
+		pq.Array(names), pq.Array(departments), pq.Array(hireDates), pq.Array(imported))
+	if err != nil {
+		return nil, err
+	}
+	created := make(map[string]int, len(accounts))
+	for rows.Next() {
+		var id int
+		var username string
+		if err := rows.Scan(&id, &username); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		created[username] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(accounts))
+	var grants []lotGrant
+	for i, a := range accounts {
+		ids[i] = created[strings.ToLower(a.Username)]
+		if ids[i] == 0 {
+			continue
+		}
+		for _, p := range freshLot(a.Coins) {
+			grants = append(grants, lotGrant{UserID: ids[i], lotPortion: p})
+		}
+	}
+	if err := insertLotGrants(ctx, tx, grants); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Забирает монеты, удерживаемые для ожидающего перевода, в порядке начисления
+func releaseLots(ctx context.Context, tx *sql.Tx, pendingTransferID int) ([]lotPortion, error) {
+	rows, err := tx.QueryContext(ctx, `
+        DELETE FROM coin_lots WHERE pending_transfer_id = $1
+        RETURNING remaining, granted_at, expires_at
+    `, pendingTransferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var portions []lotPortion
+	for rows.Next() {
+		var p lotPortion
+		if err := rows.Scan(&p.Amount, &p.GrantedAt, &p.ExpiresAt); err != nil {
+			return nil, err
+		}
+		portions = append(portions, p)
+	}
+	sort.SliceStable(portions, func(i, j int) bool {
+		return portions[i].GrantedAt.Before(portions[j].GrantedAt)
+	})
+	return portions, rows.Err()
+}
+
+// Сжигает истёкшие партии; возвращает число сгоревших монет
+func expireCoinLots(ctx context.Context) (int, error) {
+	expired := 0
+	for {
+		rows, err := db.QueryContext(ctx, `
+            SELECT DISTINCT user_id FROM coin_lots
+            WHERE remaining > 0 AND pending_transfer_id IS NULL AND expires_at <= NOW()
+            LIMIT $1
+        `, expireBatchSize)
+		if err != nil {
+			return expired, err
+		}
+		var users []int
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return expired, err
+			}
+			users = append(users, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return expired, err
+		}
+
+		for _, id := range users {
+			n, err := expireUserLots(ctx, id)
+			if err != nil {
+				return expired, err
+			}
+			expired += n
+		}
+		if len(users) < expireBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// Сжигает истёкшие партии пользователя с записью в журнал и уведомляет его
+func expireUserLots(ctx context.Context, userID int) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Строка пользователя блокируется раньше партий, как при списании
+	if _, err := lockAccounts(ctx, tx, userID); err != nil {
+		return 0, err
+	}
+	var amount int
+	err = tx.QueryRowContext(ctx, `
+        WITH expired AS (
+            UPDATE coin_lots l SET remaining = 0
+            FROM (
+                SELECT id, remaining FROM coin_lots
+                WHERE user_id = $1 AND remaining > 0 AND pending_transfer_id IS NULL AND expires_at <= NOW()
+                FOR UPDATE
+            ) e
+            WHERE l.id = e.id
+            RETURNING l.id, e.remaining AS amount
+        ), ledger AS (
+            INSERT INTO coin_ledger (user_id, lot_id, amount, reason)
+            SELECT $1, id, -amount, 'expired' FROM expired
+        )
+        SELECT COALESCE(SUM(amount), 0) FROM expired
+    `, userID).Scan(&amount)
+	if err != nil {
+		return 0, err
+	}
+	if amount == 0 {
+		return 0, nil
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE users SET coins = coins - $1 WHERE id = $2", amount, userID); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	coinsExpiredTotal.Add(float64(amount))
+	userInfoCache.Invalidate(ctx, userID)
+	if err := notify(ctx, userID, oas.NotificationKindCoinsExpired, fmt.Sprintf("%d coins expired", amount), 0); err != nil {
+		logger.Error("Failed to notify user", zap.Int("user_id", userID), zap.Error(err))
+	}
+	return amount, nil
+}
+
+// Раскладывает по партиям баланс, не покрытый ими: монеты, начисленные до
+// учёта партий. Остаток пользователя становится одной партией, начисленной в
+// момент переноса, и сгорает через TTL. Возвращает число перенесённых монет.
+func backfillCoinLots(ctx context.Context) (int, error) {
+	backfilled, lastID := 0, 0
+	for {
+		rows, err := db.QueryContext(ctx, `
+            SELECT u.id FROM users u
+            WHERE u.id > $1 AND u.coins > COALESCE((
+                SELECT SUM(l.remaining) FROM coin_lots l
+                WHERE l.user_id = u.id AND l.pending_transfer_id IS NULL
+            ), 0)
+            ORDER BY u.id
+            LIMIT $2
+        `, lastID, expireBatchSize)
+		if err != nil {
+			return backfilled, err
+		}
+		var users []int
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return backfilled, err
+			}
+			users = append(users, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return backfilled, err
+		}
+
+		for _, id := range users {
+			n, err := backfillUserLots(ctx, id)
+			if err != nil {
+				return backfilled, err
+			}
+			backfilled += n
+			lastID = id
+		}
+		if len(users) < expireBatchSize {
+			return backfilled, nil
+		}
+	}
+}
+
+// Создаёт партию из непокрытого остатка пользователя. Остаток считается под
+// блокировкой строки пользователя, чтобы параллельный перевод не потратил его
+// между подсчётом и созданием партии.
+func backfillUserLots(ctx context.Context, userID int) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	accounts, err := lockAccounts(ctx, tx, userID)
+	if err != nil {
+		return 0, err
+	}
+	a, ok := accounts[userID]
+	if !ok {
+		return 0, nil
+	}
+	var tracked int
+	err = tx.QueryRowContext(ctx, `
+        SELECT COALESCE(SUM(remaining), 0) FROM coin_lots
+        WHERE user_id = $1 AND pending_transfer_id IS NULL
+    `, userID).Scan(&tracked)
+	if err != nil {
+		return 0, err
+	}
+	untracked := a.Coins - tracked
+	if untracked <= 0 {
+		return 0, nil
+	}
+	if err := insertLots(ctx, tx, userID, 0, freshLot(untracked)); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	userInfoCache.Invalidate(ctx, userID)
+	return untracked, nil
+}
+
+// Запускает сжигание истёкших партий с периодом ExpiryInterval до отмены ctx
+func startCoinExpiry(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(coinLots.ExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := expireCoinLots(ctx)
+				if err != nil {
+					logger.Error("Failed to expire coins", zap.Error(err))
+				}
+				if n > 0 {
+					logger.Info("Coins expired", zap.Int("amount", n))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitCoinLots(t *testing.T) {
+	defer func(saved lotPolicy) { coinLots = saved }(coinLots)
+
+	assert.NoError(t, initCoinLots())
+	assert.Equal(t, 365*24*time.Hour, coinLots.TTL, "По умолчанию монеты сгорают через год")
+
+	t.Setenv("COIN_LOT_TTL", "0s")
+	assert.NoError(t, initCoinLots(), "Нулевой срок отключает сгорание")
+	assert.Zero(t, coinLots.TTL)
+
+	t.Setenv("COIN_EXPIRY_INTERVAL", "0s")
+	assert.Error(t, initCoinLots())
+}
+
+func TestSplitPortions(t *testing.T) {
+	old := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	portions := []lotPortion{{Amount: 30, GrantedAt: old}, {Amount: 50, GrantedAt: recent}}
+
+	head, tail := splitPortions(portions, 40)
+	assert.Equal(t, []lotPortion{{Amount: 30, GrantedAt: old}, {Amount: 10, GrantedAt: recent}}, head, "Сначала берутся старые монеты")
+	assert.Equal(t, []lotPortion{{Amount: 40, GrantedAt: recent}}, tail, "Партия на границе делится")
+
+	head, tail = splitPortions(portions, 30)
+	assert.Equal(t, portions[:1], head)
+	assert.Equal(t, portions[1:], tail)
+
+	head, tail = splitPortions(portions, 0)
+	assert.Empty(t, head)
+	assert.Equal(t, portions, tail)
+
+	head, tail = splitPortions(portions, 100)
+	assert.Equal(t, portions, head, "Если партий не хватает, берутся все")
+	assert.Empty(t, tail)
+	assert.Equal(t, 80, sumPortions(head))
+}
+
+func TestFreshLot(t *testing.T) {
+	assert.Nil(t, freshLot(0))
+	assert.Equal(t, []lotPortion{{Amount: 5}}, freshLot(5))
+}
@@ -1,449 +1,528 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
 )
 
 var jwtKey = []byte("my_secret_key")
 
+// Издатель и получатель токенов, выдаваемых /api/auth
+const (
+	tokenIssuer   = "avito-shop"
+	tokenAudience = "avito-shop-api"
+	tokenTTL      = 5 * time.Minute
+)
+
+// Принимать ли токены старого формата, содержащие только имя пользователя.
+// Выключается через ACCEPT_LEGACY_TOKENS=false, когда legacy_tokens_total
+// перестаёт расти.
+var acceptLegacyTokens = true
+
+// Данные JWT. Пользователь идентифицируется неизменяемым ID в sub;
+// Username заполнен только в токенах старого формата.
 type Claims struct {
-	Username string `json:"username"`
+	Username string `json:"username,omitempty"`
+	// Версия токенов пользователя на момент выдачи; см. userState
+	TokenVersion int `json:"ver,omitempty"`
+	// Вход выполнен со вторым фактором; см. mfaPolicy
+	MFA bool `json:"mfa,omitempty"`
 	jwt.RegisteredClaims
+
+	// ID пользователя из sub или, для старых токенов, найденный по имени
+	UserID int `json:"-"`
+	// Для запросов с API-ключом: ключ и его разрешения
+	APIKeyID int      `json:"-"`
+	Scopes   []string `json:"-"`
+}
+
+// Старый токен без ID пользователя
+func (c *Claims) legacy() bool {
+	return c.Subject == ""
+}
+
+// Выдаёт токен пользователю с указанным ID
+func issueToken(ctx context.Context, userID int) (string, error) {
+	return signToken(ctx, userID, tokenAudience, tokenTTL, false)
+}
+
+// Подписывает токен для указанного получателя: API или второго шага входа
+func signToken(ctx context.Context, userID int, audience string, ttl time.Duration, secondFactor bool) (string, error) {
+	state, ok, err := currentUserState(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !ok || state.Status != statusActive {
+		return "", fmt.Errorf("user %d is not active", userID)
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		TokenVersion: state.TokenVersion,
+		MFA:          secondFactor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(userID),
+			Issuer:    tokenIssuer,
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			ID:        uuid.NewString(),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
 }
 
-var tokenCache sync.Map // Кэш для хранения проверенных токенов
+var tokenCache = newTokenClaimsCache(tokenCacheSize) // Кэш для хранения проверенных токенов
+
+// Проверяет подпись и формат токена; результат кэшируется
+func parseToken(ctx context.Context, tokenString string) (*Claims, error) {
+	// Токен, уже разобранный в этом запросе, не проверяется и не учитывается
+	// в метриках кэша повторно
+	if parsed, ok := ctx.Value(parsedTokenKey{}).(*parsedToken); ok && parsed.token == tokenString {
+		return parsed.claims, parsed.err
+	}
+
+	_, span := tracer.Start(ctx, "tokenCache.Load")
+	defer span.End()
 
-// Функция для проверки токена с использованием кэша
-func validateToken(tokenString string) (*Claims, error) {
 	// Проверяем, есть ли токен в кэше
 	if claims, ok := tokenCache.Load(tokenString); ok {
-		return claims.(*Claims), nil
+		tokenCacheRequests.WithLabelValues("hit").Inc()
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		return claims, nil
 	}
+	tokenCacheRequests.WithLabelValues("miss").Inc()
+	span.SetAttributes(attribute.Bool("cache.hit", false))
 
 	// Если токена нет в кэше, проверяем его стандартным способом
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		return jwtKey, nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
 
 	if err != nil || !token.Valid {
 		return nil, fmt.Errorf("invalid token")
 	}
 
-	// Сохраняем токен в кэше
-	tokenCache.Store(tokenString, claims)
+	if claims.legacy() {
+		// ID старого токена определяется по имени при первом использовании
+		if claims.Username == "" || !acceptLegacyTokens {
+			return nil, fmt.Errorf("invalid token")
+		}
+	} else {
+		// iss и aud есть только у токенов нового формата, поэтому проверяются здесь,
+		// а не опциями парсера
+		if claims.Issuer != tokenIssuer || !slices.Contains(claims.Audience, tokenAudience) {
+			return nil, fmt.Errorf("invalid token")
+		}
+		if claims.UserID, err = strconv.Atoi(claims.Subject); err != nil || claims.UserID <= 0 {
+			return nil, fmt.Errorf("invalid token")
+		}
+		// Сохраняем токен в кэше
+		tokenCache.Store(tokenString, claims)
+	}
 
 	return claims, nil
 }
 
-func ApiAuthPost(w http.ResponseWriter, r *http.Request) {
-	var req AuthRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
-		return
-	}
+type parsedTokenKey struct{}
 
-	if req.Username == "" || req.Password == "" {
-		respondWithError(w, http.StatusBadRequest, "Username and password are required")
-		return
-	}
+// Результат parseToken, сохранённый в контексте запроса
+type parsedToken struct {
+	token  string
+	claims *Claims
+	err    error
+}
 
-	var userID int
-	var storedPassword string
-	err := db.QueryRow("SELECT id, password FROM users WHERE username = $1", req.Username).Scan(&userID, &storedPassword)
+// Разбирает токен и сохраняет результат в контексте, чтобы последующие
+// проверки того же запроса его переиспользовали
+func withParsedToken(ctx context.Context, tokenString string) (context.Context, *Claims, error) {
+	claims, err := parseToken(ctx, tokenString)
+	return context.WithValue(ctx, parsedTokenKey{}, &parsedToken{token: tokenString, claims: claims, err: err}), claims, err
+}
+
+// Проверяет токен и то, что его владелец активен, а токен не отозван.
+// Ошибки возвращаются в виде, готовом для ответа клиенту.
+func validateToken(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := parseToken(ctx, tokenString)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			// Пользователь не существует, создаем нового
-			_, err = db.Exec("INSERT INTO users (username, password, coins) VALUES ($1, $2, $3)", req.Username, req.Password, 1000)
-			if err != nil {
-				respondWithError(w, http.StatusInternalServerError, "Failed to create user")
-				return
-			}
-		} else {
-			respondWithError(w, http.StatusInternalServerError, "Database error")
-			return
-		}
-	} else {
-		// Пользователь существует, проверяем пароль
-		if req.Password != storedPassword {
-			respondWithError(w, http.StatusUnauthorized, "Invalid password")
-			return
+		return nil, unauthorized(codeInvalidToken, "Invalid token")
+	}
+	if claims.legacy() {
+		if claims, err = resolveLegacyToken(ctx, tokenString, claims); err != nil {
+			return nil, err
 		}
 	}
+	if err := checkUserState(ctx, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
 
-	// Генерация JWT токена
-	expirationTime := time.Now().Add(5 * time.Minute)
-	claims := &Claims{
-		Username: req.Username,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-		},
+// Находит ID пользователя по имени из токена старого формата
+func resolveLegacyToken(ctx context.Context, tokenString string, claims *Claims) (*Claims, error) {
+	legacyTokensTotal.Inc()
+	if claims.UserID != 0 {
+		return claims, nil
+	}
+
+	resolved := *claims
+	err := db.QueryRowContext(ctx, "SELECT id FROM users WHERE username = $1", claims.Username).Scan(&resolved.UserID)
+	if err == sql.ErrNoRows {
+		return nil, unauthorized(codeInvalidToken, "Invalid token")
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to create token")
-		return
+		return nil, internalError("Database error", err)
 	}
-
-	respondWithJSON(w, http.StatusOK, AuthResponse{Token: tokenString})
+	tokenCache.Store(tokenString, &resolved)
+	return &resolved, nil
 }
 
-var itemPrices = map[string]int{
-	"t-shirt":    80,
-	"cup":        20,
-	"book":       50,
-	"pen":        10,
-	"powerbank":  200,
-	"hoody":      300,
-	"umbrella":   200,
-	"socks":      10,
-	"wallet":     50,
-	"pink-hoody": 500,
+type claimsKey struct{}
+
+// Возвращает данные токена, проверенного securityHandler
+func claimsFromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(claimsKey{}).(*Claims)
+	return claims
 }
 
-func ApiBuyItemGet(w http.ResponseWriter, r *http.Request) {
-	// Проверка токена
-	tokenString := r.Header.Get("Authorization")
-	if tokenString == "" {
-		respondWithError(w, http.StatusUnauthorized, "Missing Authorization header")
-		return
-	}
+// Проверка JWT или API-ключа для операций, защищённых схемой BearerAuth
+type securityHandler struct{}
 
-	claims, err := validateToken(tokenString)
+func (securityHandler) HandleBearerAuth(ctx context.Context, operationName oas.OperationName, t oas.BearerAuth) (context.Context, error) {
+	var claims *Claims
+	var err error
+	if isAPIKey(t.Token) {
+		claims, err = validateAPIKey(ctx, t.Token, operationName)
+	} else {
+		claims, err = validateToken(ctx, t.Token)
+	}
 	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Invalid token")
-		return
+		return nil, err
 	}
+	setRequestUserID(ctx, claims.UserID)
+	return context.WithValue(ctx, claimsKey{}, claims), nil
+}
 
-	// Получаем название товара из URL
-	vars := mux.Vars(r)
-	itemName := vars["item"]
+// Реализация операций из api/schema.json
+type apiHandler struct{}
 
-	// Проверяем, что товар существует
-	itemPrice, ok := itemPrices[itemName]
-	if !ok {
-		respondWithError(w, http.StatusBadRequest, "Item not found")
-		return
-	}
+var _ oas.Handler = apiHandler{}
 
-	// Получаем ID и баланс пользователя
-	var userID int
-	var coins int
-	err = db.QueryRow("SELECT id, coins FROM users WHERE username = $1", claims.Username).Scan(&userID, &coins)
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Database error")
-		return
+func (apiHandler) APIAuthPost(ctx context.Context, req *oas.AuthRequest) (oas.APIAuthPostRes, error) {
+	username := normalizeUsername(req.Username)
+	if username == "" || req.Password == "" {
+		return nil, badRequest(codeValidationFailed, "Username and password are required")
 	}
+	setRequestUser(ctx, username)
 
-	// Проверяем, хватает ли монет
-	if coins < itemPrice {
-		respondWithError(w, http.StatusBadRequest, "Not enough coins")
-		return
+	if err := checkLoginLockout(ctx, username); err != nil {
+		return nil, err
 	}
 
-	// Начинаем транзакцию
-	tx, err := db.Begin()
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
-		return
+	loginFailed := func(message string) error {
+		if err := recordLoginFailure(ctx, username); err != nil {
+			return err
+		}
+		return unauthorized(codeInvalidCredentials, message)
 	}
 
-	// Выполняем операции в отдельной горутине
-	done := make(chan bool)
-	go func() {
-		// Списываем монеты с баланса пользователя
-		_, err = tx.Exec("UPDATE users SET coins = coins - $1 WHERE id = $2", itemPrice, userID)
-		if err != nil {
-			tx.Rollback()
-			respondWithError(w, http.StatusInternalServerError, "Failed to update balance")
-			close(done)
-			return
+	// Имена сравниваются без учёта регистра, чтобы находить и учётные записи,
+	// созданные до нормализации имён
+	var userID int
+	var storedPassword sql.NullString
+	var status string
+	err := db.QueryRowContext(ctx, "SELECT id, password, status FROM users WHERE lower(username) = $1", username).Scan(&userID, &storedPassword, &status)
+	switch {
+	case err == sql.ErrNoRows:
+		// Пользователь не существует: создаём его, только если это разрешено
+		if !registration.AutoRegister || !registration.allowed(username) {
+			return nil, loginFailed("Invalid username or password")
 		}
-
-		// Добавляем запись о покупке
-		_, err = tx.Exec("INSERT INTO purchases (user_id, item_name) VALUES ($1, $2)", userID, itemName)
-		if err != nil {
-			tx.Rollback()
-			respondWithError(w, http.StatusInternalServerError, "Failed to record purchase")
-			close(done)
-			return
+		userID, err = createUser(ctx, username, req.Password)
+		if errors.Is(err, errRegistrationClosed) {
+			return nil, loginFailed("Invalid username or password")
 		}
-
-		// Завершаем транзакцию
-		err = tx.Commit()
 		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
-			close(done)
-			return
+			return nil, createUserError(err)
+		}
+	case err != nil:
+		return nil, internalError("Database error", err)
+	default:
+		// Пользователь существует, проверяем пароль. У сотрудника,
+		// импортированного из справочника, пароля нет, пока он не задаст его
+		// по токену сброса или не войдёт через OIDC.
+		if !storedPassword.Valid || req.Password != storedPassword.String {
+			return nil, loginFailed("Invalid username or password")
+		}
+		// Статус сообщается только после проверки пароля
+		if status != statusActive {
+			return nil, forbidden(codeAccountInactive, "Account is "+status)
 		}
+	}
 
-		close(done)
-	}()
+	setRequestUserID(ctx, userID)
 
-	<-done // Ждём завершения операций
-	// Возвращаем успешный ответ
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("You bought a %s!", itemName)})
+	// Генерация JWT токена; состояние перечитывается из БД, так как оно могло
+	// измениться на другом экземпляре
+	forgetUserState(userID)
+	return completeLogin(ctx, username, userID)
 }
 
-func ApiInfoGet(w http.ResponseWriter, r *http.Request) {
-	// Проверка токена
-	tokenString := r.Header.Get("Authorization")
-	if tokenString == "" {
-		respondWithError(w, http.StatusUnauthorized, "Missing Authorization header")
-		return
+func (apiHandler) APIRegisterPost(ctx context.Context, req *oas.AuthRequest) (oas.APIRegisterPostRes, error) {
+	username := normalizeUsername(req.Username)
+	if username == "" || req.Password == "" {
+		return nil, badRequest(codeValidationFailed, "Username and password are required")
 	}
+	setRequestUser(ctx, username)
 
-	claims, err := validateToken(tokenString)
-	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Invalid token")
-		return
+	if !registration.allowed(username) {
+		return nil, forbidden(codeRegistrationDenied, "Registration is not allowed for this username")
 	}
 
-	// Получаем ID пользователя
-	var userID int
-	var coins int32
-	err = db.QueryRow("SELECT id, coins FROM users WHERE username = $1", claims.Username).Scan(&userID, &coins)
+	userID, err := createUser(ctx, username, req.Password)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Database error")
-		return
+		return nil, createUserError(err)
 	}
+	setRequestUserID(ctx, userID)
 
-	// Получаем инвентарь пользователя
-	inventoryChan := make(chan []InfoResponseInventory, 1)
-	go func() {
-		var inventory []InfoResponseInventory
-		rows, err := db.Query("SELECT item_name, COUNT(*) as quantity FROM purchases WHERE user_id = $1 GROUP BY item_name", userID)
-		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, "Failed to fetch inventory")
-			close(inventoryChan)
-			return
-		}
-		defer rows.Close()
-
-		for rows.Next() {
-			var itemName string
-			var quantity int32
-			err := rows.Scan(&itemName, &quantity)
-			if err != nil {
-				respondWithError(w, http.StatusInternalServerError, "Failed to scan inventory")
-				close(inventoryChan)
-				return
-			}
-			inventory = append(inventory, InfoResponseInventory{
-				Type_:    itemName,
-				Quantity: quantity,
-			})
-		}
-		inventoryChan <- inventory
-	}()
-
-	// Получаем историю полученных монет
-	receivedChan := make(chan []InfoResponseCoinHistoryReceived, 1)
-	go func() {
-		var received []InfoResponseCoinHistoryReceived
-		rows, err := db.Query(`
-            SELECT u.username, t.amount 
-            FROM transactions t 
-            JOIN users u ON t.sender_id = u.id 
-            WHERE t.receiver_id = $1
-        `, userID)
-		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, "Failed to fetch received transactions")
-			close(receivedChan)
-			return
-		}
-		defer rows.Close()
-
-		for rows.Next() {
-			var fromUser string
-			var amount int32
-			err := rows.Scan(&fromUser, &amount)
-			if err != nil {
-				respondWithError(w, http.StatusInternalServerError, "Failed to scan received transaction")
-				close(receivedChan)
-				return
-			}
-			received = append(received, InfoResponseCoinHistoryReceived{
-				FromUser: fromUser,
-				Amount:   amount,
-			})
-		}
-		receivedChan <- received
-	}()
-
-	// Получаем историю отправленных монет
-	sentChan := make(chan []InfoResponseCoinHistorySent, 1)
-	go func() {
-		var sent []InfoResponseCoinHistorySent
-		rows, err := db.Query(`
-            SELECT u.username, t.amount 
-            FROM transactions t 
-            JOIN users u ON t.receiver_id = u.id 
-            WHERE t.sender_id = $1
-        `, userID)
-		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, "Failed to fetch sent transactions")
-			close(sentChan)
-			return
-		}
-		defer rows.Close()
-
-		for rows.Next() {
-			var toUser string
-			var amount int32
-			err := rows.Scan(&toUser, &amount)
-			if err != nil {
-				respondWithError(w, http.StatusInternalServerError, "Failed to scan sent transaction")
-				close(sentChan)
-				return
-			}
-			sent = append(sent, InfoResponseCoinHistorySent{
-				ToUser: toUser,
-				Amount: amount,
-			})
-		}
-		sentChan <- sent
-	}()
-
-	inventory := <-inventoryChan
-	received := <-receivedChan
-	sent := <-sentChan
+	tokenString, err := issueToken(ctx, userID)
+	if err != nil {
+		return nil, internalError("Failed to create token", err)
+	}
+	return &oas.AuthResponse{Token: oas.NewOptString(tokenString)}, nil
+}
 
-	response := InfoResponse{
-		Coins:     coins,
-		Inventory: inventory,
-		CoinHistory: &InfoResponseCoinHistory{
-			Received: received,
-			Sent:     sent,
-		},
+func createUserError(err error) *apiError {
+	if errors.Is(err, errUsernameTaken) {
+		return conflict(codeUsernameTaken, "Username already taken")
+	}
+	if errors.Is(err, errRegistrationClosed) {
+		return forbidden(codeRegistrationDenied, "Registration is not allowed for this username")
 	}
+	return internalError("Failed to create user", err)
+}
 
-	respondWithJSON(w, http.StatusOK, response)
+var itemPrices = map[string]int{
+	"t-shirt":    80,
+	"cup":        20,
+	"book":       50,
+	"pen":        10,
+	"powerbank":  200,
+	"hoody":      300,
+	"umbrella":   200,
+	"socks":      10,
+	"wallet":     50,
+	"pink-hoody": 500,
 }
 
-func ApiSendCoinPost(w http.ResponseWriter, r *http.Request) {
-	// Проверка токена
-	tokenString := r.Header.Get("Authorization")
-	if tokenString == "" {
-		respondWithError(w, http.StatusUnauthorized, "Missing Authorization header")
-		return
+func (apiHandler) APIBuyItemGet(ctx context.Context, params oas.APIBuyItemGetParams) (oas.APIBuyItemGetRes, error) {
+	claims := claimsFromContext(ctx)
+	itemName := params.Item
+
+	// Проверяем, что товар существует
+	itemPrice, ok := itemPrices[itemName]
+	if !ok {
+		return nil, badRequest(codeItemNotFound, "Item not found")
 	}
 
-	claims, err := validateToken(tokenString)
+	// Начинаем транзакцию
+	userID := claims.UserID
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Invalid token")
-		return
+		return nil, internalError("Failed to start transaction", err)
 	}
+	defer tx.Rollback()
 
-	// Получаем данные из запроса
-	var req SendCoinRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
-		return
+	// Списываем монеты с баланса пользователя, если их хватает
+	if _, err := debit(ctx, tx, userID, itemPrice); err != nil {
+		return nil, err
 	}
 
-	// Проверяем, что сумма положительная
-	if req.Amount <= 0 {
-		respondWithError(w, http.StatusBadRequest, "Amount must be positive")
-		return
+	// Добавляем запись о покупке
+	_, err = tx.ExecContext(ctx, "INSERT INTO purchases (user_id, item_name) VALUES ($1, $2)", userID, itemName)
+	if err != nil {
+		return nil, internalError("Failed to record purchase", err)
 	}
 
-	// Получаем ID и баланс отправителя
-	var senderID int
-	var senderCoins int32
-	err = db.QueryRow("SELECT id, coins FROM users WHERE username = $1", claims.Username).Scan(&senderID, &senderCoins)
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Database error")
-		return
+	// Завершаем транзакцию
+	if err = tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
 	}
+	purchasesTotal.WithLabelValues(itemName).Inc()
+	userInfoCache.Invalidate(ctx, userID)
 
-	// Проверяем, что отправитель не пытается отправить монеты сам себе
-	if claims.Username == req.ToUser {
-		respondWithError(w, http.StatusBadRequest, "Cannot send coins to yourself")
-		return
+	return &oas.MessageResponse{Message: oas.NewOptString(fmt.Sprintf("You bought a %s!", itemName))}, nil
+}
+
+func (apiHandler) APIInfoGet(ctx context.Context) (oas.APIInfoGetRes, error) {
+	claims := claimsFromContext(ctx)
+
+	info, err := userInfoCache.GetOrLoad(ctx, claims.UserID, func(ctx context.Context) (*oas.InfoResponse, error) {
+		return loadInfo(ctx, claims.UserID)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return info, nil
+}
 
-	// Проверяем, хватает ли монет у отправителя
-	if senderCoins < req.Amount {
-		respondWithError(w, http.StatusBadRequest, "Not enough coins")
-		return
+// Баланс, инвентарь и обе стороны истории переводов читаются одним запросом:
+// в Postgres один оператор видит один снимок данных, поэтому ответ согласован
+// даже при параллельных переводах, а запрос занимает одно соединение пула
+const infoQuery = `
+    SELECT u.coins,
+        COALESCE((
+            SELECT json_agg(json_build_object('type', p.item_name, 'quantity', p.quantity) ORDER BY p.item_name)
+            FROM (
+                SELECT item_name, COUNT(*) AS quantity
+                FROM purchases
+                WHERE user_id = u.id
+                GROUP BY item_name
+            ) p
+        ), '[]'),
+        COALESCE((
+            SELECT json_agg(json_strip_nulls(json_build_object('id', t.id, 'fromUser', s.username, 'amount', t.amount,
+                'message', t.message, 'category', t.category, 'reversalOf', t.reverses_id)) ORDER BY t.id)
+            FROM transactions t
+            JOIN users s ON s.id = t.sender_id
+            WHERE t.receiver_id = u.id
+        ), '[]'),
+        COALESCE((
+            SELECT json_agg(json_strip_nulls(json_build_object('id', t.id, 'toUser', r.username, 'amount', t.amount,
+                'fromBudget', NULLIF(t.budget_amount, 0), 'message', t.message, 'category', t.category,
+                'reversalOf', t.reverses_id)) ORDER BY t.id)
+            FROM transactions t
+            JOIN users r ON r.id = t.receiver_id
+            WHERE t.sender_id = u.id
+        ), '[]'),
+        (
+            SELECT json_build_object('granted', b.granted, 'remaining', b.remaining, 'periodStart', b.period_start)
+            FROM giving_budgets b
+            WHERE b.user_id = u.id AND b.period_start = date_trunc($2, LOCALTIMESTAMP)
+        ),
+        COALESCE((
+            SELECT json_agg(json_build_object('amount', e.amount, 'expiresAt', e.expires_at) ORDER BY e.expires_at)
+            FROM (
+                SELECT SUM(remaining) AS amount, MIN(expires_at) AS expires_at
+                FROM coin_lots
+                WHERE user_id = u.id AND remaining > 0 AND pending_transfer_id IS NULL AND expires_at IS NOT NULL
+                GROUP BY date_trunc('day', expires_at)
+                ORDER BY 2
+                LIMIT $3
+            ) e
+        ), '[]')
+    FROM users u
+    WHERE u.id = $1
+`
+
+// Загружает баланс, инвентарь и историю переводов пользователя из БД
+func loadInfo(ctx context.Context, userID int) (*oas.InfoResponse, error) {
+	if err := givingBudgets.refresh(ctx, db, userID); err != nil {
+		return nil, internalError("Failed to grant giving budget", err)
 	}
 
-	// Получаем ID получателя
-	var receiverID int
-	err = db.QueryRow("SELECT id FROM users WHERE username = $1", req.ToUser).Scan(&receiverID)
+	var coins int
+	var inventoryJSON, receivedJSON, sentJSON, budgetJSON, expiringJSON []byte
+	err := db.QueryRowContext(ctx, infoQuery, userID, givingBudgets.Period, upcomingExpirations).
+		Scan(&coins, &inventoryJSON, &receivedJSON, &sentJSON, &budgetJSON, &expiringJSON)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			respondWithError(w, http.StatusBadRequest, "Receiver not found")
-			return
-		}
-		respondWithError(w, http.StatusInternalServerError, "Database error")
-		return
+		return nil, internalError("Database error", err)
 	}
 
-	// Начинаем транзакцию
-	tx, err := db.Begin()
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
-		return
+	var inventory []oas.InfoResponseInventoryItem
+	if err := json.Unmarshal(inventoryJSON, &inventory); err != nil {
+		return nil, internalError("Failed to decode inventory", err)
+	}
+	var received []oas.InfoResponseCoinHistoryReceivedItem
+	if err := json.Unmarshal(receivedJSON, &received); err != nil {
+		return nil, internalError("Failed to decode received transactions", err)
+	}
+	var sent []oas.InfoResponseCoinHistorySentItem
+	if err := json.Unmarshal(sentJSON, &sent); err != nil {
+		return nil, internalError("Failed to decode sent transactions", err)
+	}
+	var expiring []oas.InfoResponseExpiringCoinsItem
+	if err := json.Unmarshal(expiringJSON, &expiring); err != nil {
+		return nil, internalError("Failed to decode coin expirations", err)
 	}
 
-	done := make(chan bool)
-	go func() {
-		// Списываем монеты с отправителя
-		_, err = tx.Exec("UPDATE users SET coins = coins - $1 WHERE id = $2", req.Amount, senderID)
-		if err != nil {
-			tx.Rollback()
-			respondWithError(w, http.StatusInternalServerError, "Failed to update sender balance")
-			close(done)
-			return
+	info := &oas.InfoResponse{
+		Coins:     oas.NewOptInt(coins),
+		Inventory: inventory,
+		CoinHistory: oas.NewOptInfoResponseCoinHistory(oas.InfoResponseCoinHistory{
+			Received: received,
+			Sent:     sent,
+		}),
+		ExpiringCoins: expiring,
+	}
+	if budgetJSON != nil {
+		var budget struct {
+			Granted     int    `json:"granted"`
+			Remaining   int    `json:"remaining"`
+			PeriodStart string `json:"periodStart"`
 		}
-
-		// Добавляем монеты получателю
-		_, err = tx.Exec("UPDATE users SET coins = coins + $1 WHERE id = $2", req.Amount, receiverID)
-		if err != nil {
-			tx.Rollback()
-			respondWithError(w, http.StatusInternalServerError, "Failed to update receiver balance")
-			close(done)
-			return
+		if err := json.Unmarshal(budgetJSON, &budget); err != nil {
+			return nil, internalError("Failed to decode giving budget", err)
 		}
-
-		// Записываем транзакцию
-		_, err = tx.Exec("INSERT INTO transactions (sender_id, receiver_id, amount) VALUES ($1, $2, $3)", senderID, receiverID, req.Amount)
+		start, err := time.Parse("2006-01-02T15:04:05", budget.PeriodStart)
 		if err != nil {
-			tx.Rollback()
-			respondWithError(w, http.StatusInternalServerError, "Failed to record transaction")
-			close(done)
-			return
+			return nil, internalError("Failed to decode giving budget", err)
 		}
+		info.GivingBudget = oas.NewOptInfoResponseGivingBudget(oas.InfoResponseGivingBudget{
+			Granted:   budget.Granted,
+			Remaining: budget.Remaining,
+			ExpiresAt: givingBudgets.periodEnd(start),
+		})
+	}
+	return info, nil
+}
 
-		// Завершаем транзакцию
-		err = tx.Commit()
-		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
-			close(done)
-			return
-		}
+func (apiHandler) APISendCoinPost(ctx context.Context, req *oas.SendCoinRequest) (oas.APISendCoinPostRes, error) {
+	claims := claimsFromContext(ctx)
+	if err := mfa.requireForTransfer(claims, req.Amount); err != nil {
+		return nil, err
+	}
 
-		close(done)
-	}()
+	note := newTransferNote(req.Message, req.Category, req.Public)
+	legs := []transferLeg{{ToUser: req.ToUser, Amount: req.Amount}}
+	if err := executeTransfers(ctx, claims.UserID, legs, note, req.UseBudget.Or(false)); err != nil {
+		return nil, err
+	}
 
-	<-done
+	// Отложенный перевод возвращается с кодом 202, чтобы клиент показал его статус
+	if id := legs[0].PendingTransferID; id != 0 {
+		pending, err := scanPendingTransfer(db.QueryRowContext(ctx, pendingTransferQuery+"WHERE p.id = $1", id))
+		if err != nil {
+			return nil, internalError("Database error", err)
+		}
+		return pending, nil
+	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Coins sent successfully"})
+	return &oas.MessageResponse{Message: oas.NewOptString("Coins sent successfully")}, nil
 }
 
 func respondWithJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
@@ -452,8 +531,8 @@ func respondWithJSON(w http.ResponseWriter, statusCode int, payload interface{})
 	json.NewEncoder(w).Encode(payload)
 }
 
-func respondWithError(w http.ResponseWriter, statusCode int, message string) {
-	respondWithJSON(w, statusCode, ErrorResponse{Errors: message})
+func respondWithError(w http.ResponseWriter, statusCode int, code, message string) {
+	respondWithJSON(w, statusCode, &oas.ErrorResponse{Errors: oas.NewOptString(message), Code: oas.NewOptString(code)})
 }
 
 var db *sql.DB
@@ -469,7 +548,13 @@ func initdb() (*sql.DB, error) {
 		os.Getenv("DB_NAME"),
 	)
 
-	db, err := sql.Open("postgres", connStr)
+	db, err := otelsql.Open("postgres", connStr,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+		}),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -482,24 +567,109 @@ func initdb() (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	if err := migrate(context.Background(), db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	return db, nil
 }
 
 func main() {
 	var err error
-	db, err = initdb()
+	logger, err = initLogger()
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer logger.Sync()
+
+	shutdownTracing, err := initTracing(context.Background())
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
+
+	db, err = initdb()
+	if err != nil {
+		logger.Fatal("Failed to initialize database", zap.Error(err))
+	}
 	defer db.Close()
 
-	log.Println("Successfully connected to the database")
+	registerDBMetrics(db)
+
+	redisClient, err := initRedis(context.Background())
+	if err != nil {
+		logger.Fatal("Failed to initialize redis", zap.Error(err))
+	}
+	if err := initRateLimits(redisClient); err != nil {
+		logger.Fatal("Failed to configure rate limits", zap.Error(err))
+	}
+	if err := initInfoCache(redisClient); err != nil {
+		logger.Fatal("Failed to configure info cache", zap.Error(err))
+	}
+	if err := initRequestLimits(); err != nil {
+		logger.Fatal("Failed to configure request limits", zap.Error(err))
+	}
+	if err := initRegistration(); err != nil {
+		logger.Fatal("Failed to configure registration", zap.Error(err))
+	}
+	if err := initAccounts(); err != nil {
+		logger.Fatal("Failed to configure accounts", zap.Error(err))
+	}
+	if err := initTransferLimits(); err != nil {
+		logger.Fatal("Failed to configure transfer limits", zap.Error(err))
+	}
+	if err := initPendingTransfers(); err != nil {
+		logger.Fatal("Failed to configure pending transfers", zap.Error(err))
+	}
+	if err := initGivingBudgets(); err != nil {
+		logger.Fatal("Failed to configure giving budgets", zap.Error(err))
+	}
+	if err := initSchedules(); err != nil {
+		logger.Fatal("Failed to configure scheduled transfers", zap.Error(err))
+	}
+	if err := initCoinLots(); err != nil {
+		logger.Fatal("Failed to configure coin expiry", zap.Error(err))
+	}
+	if err := initDisputes(); err != nil {
+		logger.Fatal("Failed to configure disputes", zap.Error(err))
+	}
+	if err := initFraud(); err != nil {
+		logger.Fatal("Failed to configure fraud analyzer", zap.Error(err))
+	}
+	if err := initMFA(); err != nil {
+		logger.Fatal("Failed to configure two-factor authentication", zap.Error(err))
+	}
+	if err := initOIDC(context.Background()); err != nil {
+		logger.Fatal("Failed to configure OIDC login", zap.Error(err))
+	}
+
+	// Подкоманды выполняются вместо запуска сервера
+	if len(os.Args) > 1 && os.Args[1] == "import-users" {
+		if err := importUsersCommand(context.Background(), os.Args[2:], os.Stdout); err != nil {
+			logger.Fatal("Failed to import users", zap.Error(err))
+		}
+		return
+	}
+	acceptLegacyTokens = envString("ACCEPT_LEGACY_TOKENS", "true") == "true"
+
+	logger.Info("Successfully connected to the database")
 
-	log.Printf("Server started")
+	logger.Info("Server started", zap.String("addr", ":8080"))
+
+	startFraudAnalyzer(context.Background())
+	startPendingTransferSweeper(context.Background())
+	startScheduler(context.Background())
+	// Баланс, начисленный до учёта партий, тоже должен сгорать
+	if n, err := backfillCoinLots(context.Background()); err != nil {
+		logger.Error("Failed to backfill coin lots", zap.Error(err))
+	} else if n > 0 {
+		logger.Info("Coin balances moved into lots", zap.Int("amount", n))
+	}
+	startCoinExpiry(context.Background())
 
 	router := NewRouter()
 
-	log.Fatal(http.ListenAndServe(":8080", router))
+	logger.Fatal("Server stopped", zap.Error(http.ListenAndServe(":8080", router)))
 }
 
 type Route struct {
@@ -511,22 +681,97 @@ type Route struct {
 
 type Routes []Route
 
+// Операция сгенерированного сервера
+type apiRoute struct {
+	Name    string
+	Method  string
+	Pattern string
+}
+
 func NewRouter() *mux.Router {
+	server, err := oas.NewServer(apiHandler{}, securityHandler{}, oas.WithErrorHandler(handleAPIError))
+	if err != nil {
+		logger.Fatal("Failed to create API server", zap.Error(err))
+	}
+
 	router := mux.NewRouter().StrictSlash(true)
-	for _, route := range routes {
-		var handler http.Handler
-		handler = route.HandlerFunc
+	register := func(name, method, pattern string, handler http.Handler) {
+		handler = instrumentRoute(name, handler)
+		handler = logRequests(name, handler)
+		handler = otelhttp.NewHandler(handler, name)
 
 		router.
-			Methods(route.Method).
-			Path(route.Pattern).
-			Name(route.Name).
+			Methods(method).
+			Path(pattern).
+			Name(name).
 			Handler(handler)
 	}
 
+	for _, route := range routes {
+		register(route.Name, route.Method, route.Pattern, route.HandlerFunc)
+	}
+	for _, route := range apiRoutes {
+		limit := maxBodyBytes
+		if strings.HasPrefix(route.Pattern, "/api/admin/") {
+			limit = adminMaxBodyBytes
+		}
+		register(route.Name, route.Method, route.Pattern, legacyRequestCompat(rateLimited(route.Name, limitRequestBody(limit, server))))
+	}
+
 	return router
 }
 
+// Исторически клиенты передают токен в Authorization без схемы Bearer
+// и не всегда указывают Content-Type; такие запросы приводятся к виду,
+// который ожидает сгенерированный сервер
+func legacyRequestCompat(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("Authorization"); token != "" && !strings.Contains(token, " ") {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		if r.Header.Get("Content-Type") == "" && r.Body != nil && r.Body != http.NoBody {
+			r.Header.Set("Content-Type", "application/json")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Предельный размер тела запроса; административные операции принимают
+// справочники на 100k сотрудников
+var (
+	maxBodyBytes      int64 = 64 << 10
+	adminMaxBodyBytes int64 = 32 << 20
+)
+
+// Читает MAX_REQUEST_BODY_BYTES и ADMIN_MAX_REQUEST_BODY_BYTES
+func initRequestLimits() error {
+	var env envReader
+	limit := env.Int("MAX_REQUEST_BODY_BYTES", int(maxBodyBytes))
+	adminLimit := env.Int("ADMIN_MAX_REQUEST_BODY_BYTES", int(adminMaxBodyBytes))
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if limit <= 0 || adminLimit <= 0 {
+		return fmt.Errorf("request body limits must be positive")
+	}
+	maxBodyBytes, adminMaxBodyBytes = int64(limit), int64(adminLimit)
+	return nil
+}
+
+// Ограничивает размер тела запроса; превышение возвращается клиенту как 413
+func limitRequestBody(limit int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			respondWithError(w, http.StatusRequestEntityTooLarge, codePayloadTooLarge, fmt.Sprintf("Request body exceeds %d bytes", limit))
+			return
+		}
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func Index(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "Hello World!")
 }
@@ -540,103 +785,58 @@ var routes = Routes{
 	},
 
 	Route{
-		"ApiAuthPost",
-		strings.ToUpper("Post"),
-		"/api/auth",
-		ApiAuthPost,
-	},
-
-	Route{
-		"ApiBuyItemGet",
-		strings.ToUpper("Get"),
-		"/api/buy/{item}",
-		ApiBuyItemGet,
-	},
-
-	Route{
-		"ApiInfoGet",
-		strings.ToUpper("Get"),
-		"/api/info",
-		ApiInfoGet,
-	},
-
-	Route{
-		"ApiSendCoinPost",
-		strings.ToUpper("Post"),
-		"/api/sendCoin",
-		ApiSendCoinPost,
+		"Metrics",
+		"GET",
+		"/metrics",
+		promhttp.Handler().ServeHTTP,
 	},
 }
 
-type AuthRequest struct {
-
-	// Имя пользователя для аутентификации.
-	Username string `json:"username"`
-
-	// Пароль для аутентификации.
-	Password string `json:"password"`
-}
-
-type AuthResponse struct {
-
-	// JWT-токен для доступа к защищенным ресурсам.
-	Token string `json:"token,omitempty"`
-}
-
-type ErrorResponse struct {
-
-	// Сообщение об ошибке, описывающее проблему.
-	Errors string `json:"errors,omitempty"`
-}
-
-type InfoResponseCoinHistoryReceived struct {
-
-	// Имя пользователя, который отправил монеты.
-	FromUser string `json:"fromUser,omitempty"`
-
-	// Количество полученных монет.
-	Amount int32 `json:"amount,omitempty"`
-}
-
-type InfoResponseCoinHistorySent struct {
-
-	// Имя пользователя, которому отправлены монеты.
-	ToUser string `json:"toUser,omitempty"`
-
-	// Количество отправленных монет.
-	Amount int32 `json:"amount,omitempty"`
-}
-
-type InfoResponseCoinHistory struct {
-	Received []InfoResponseCoinHistoryReceived `json:"received,omitempty"`
-
-	Sent []InfoResponseCoinHistorySent `json:"sent,omitempty"`
-}
-
-type InfoResponseInventory struct {
-
-	// Тип предмета.
-	Type_ string `json:"type,omitempty"`
-
-	// Количество предметов.
-	Quantity int32 `json:"quantity,omitempty"`
-}
-
-type InfoResponse struct {
-
-	// Количество доступных монет.
-	Coins int32 `json:"coins,omitempty"`
-
-	Inventory []InfoResponseInventory `json:"inventory,omitempty"`
-
-	CoinHistory *InfoResponseCoinHistory `json:"coinHistory,omitempty"`
-}
-
-type SendCoinRequest struct {
-
-	// Имя пользователя, которому нужно отправить монеты.
-	ToUser string `json:"toUser"`
-
-	// Количество монет, которые необходимо отправить.
-	Amount int32 `json:"amount"`
+// Операции из api/schema.json; обрабатываются сгенерированным сервером,
+// а имя используется в метриках, логах и ограничениях частоты запросов
+var apiRoutes = []apiRoute{
+	{"ApiAuthPost", http.MethodPost, "/api/auth"},
+	{"ApiAuth2FAPost", http.MethodPost, "/api/auth/2fa"},
+	{"ApiAuthOidcGet", http.MethodGet, "/api/auth/oidc"},
+	{"ApiAuthOidcCallbackPost", http.MethodPost, "/api/auth/oidc/callback"},
+	{"ApiRegisterPost", http.MethodPost, "/api/register"},
+	{"ApiBuyItemGet", http.MethodGet, "/api/buy/{item}"},
+	{"ApiInfoGet", http.MethodGet, "/api/info"},
+	{"ApiSendCoinPost", http.MethodPost, "/api/sendCoin"},
+	{"ApiSendCoinBatchPost", http.MethodPost, "/api/sendCoin/batch"},
+	{"ApiTransfersPendingGet", http.MethodGet, "/api/transfers/pending"},
+	{"ApiTransferAcceptPost", http.MethodPost, "/api/transfers/{transferId}/accept"},
+	{"ApiTransferDeclinePost", http.MethodPost, "/api/transfers/{transferId}/decline"},
+	{"ApiTransferCancelPost", http.MethodPost, "/api/transfers/{transferId}/cancel"},
+	{"ApiTransferApprovePost", http.MethodPost, "/api/transfers/{transferId}/approve"},
+	{"ApiTransferRejectPost", http.MethodPost, "/api/transfers/{transferId}/reject"},
+	{"ApiTransactionDisputePost", http.MethodPost, "/api/transactions/{transactionId}/dispute"},
+	{"ApiDisputesGet", http.MethodGet, "/api/disputes"},
+	{"ApiDisputeReturnPost", http.MethodPost, "/api/disputes/{disputeId}/return"},
+	{"ApiSchedulesGet", http.MethodGet, "/api/schedules"},
+	{"ApiSchedulesPost", http.MethodPost, "/api/schedules"},
+	{"ApiSchedulePausePost", http.MethodPost, "/api/schedules/{scheduleId}/pause"},
+	{"ApiScheduleResumePost", http.MethodPost, "/api/schedules/{scheduleId}/resume"},
+	{"ApiScheduleCancelPost", http.MethodPost, "/api/schedules/{scheduleId}/cancel"},
+	{"ApiNotificationsGet", http.MethodGet, "/api/notifications"},
+	{"ApiNotificationsReadPost", http.MethodPost, "/api/notifications/read"},
+	{"ApiKudosGet", http.MethodGet, "/api/kudos"},
+	{"ApiPasswordPost", http.MethodPost, "/api/password"},
+	{"ApiPasswordResetPost", http.MethodPost, "/api/password/reset"},
+	{"Api2FATotpPost", http.MethodPost, "/api/2fa/totp"},
+	{"Api2FATotpConfirmPost", http.MethodPost, "/api/2fa/totp/confirm"},
+	{"Api2FATotpDisablePost", http.MethodPost, "/api/2fa/totp/disable"},
+	{"ApiAdminImportUsersPost", http.MethodPost, "/api/admin/import-users"},
+	{"ApiAdminServiceAccountsPost", http.MethodPost, "/api/admin/service-accounts"},
+	{"ApiAdminServiceAccountKeysGet", http.MethodGet, "/api/admin/service-accounts/{username}/keys"},
+	{"ApiAdminServiceAccountKeysPost", http.MethodPost, "/api/admin/service-accounts/{username}/keys"},
+	{"ApiAdminServiceAccountKeyDelete", http.MethodDelete, "/api/admin/service-accounts/{username}/keys/{keyId}"},
+	{"ApiAdminFraudFlagsGet", http.MethodGet, "/api/admin/fraud-flags"},
+	{"ApiAdminFraudScanPost", http.MethodPost, "/api/admin/fraud-flags/scan"},
+	{"ApiAdminFraudFlagResolvePost", http.MethodPost, "/api/admin/fraud-flags/{flagId}/resolve"},
+	{"ApiAdminDisputesGet", http.MethodGet, "/api/admin/disputes"},
+	{"ApiAdminDisputeResolvePost", http.MethodPost, "/api/admin/disputes/{disputeId}/resolve"},
+	{"ApiAdminUserStatusPut", http.MethodPut, "/api/admin/users/{username}/status"},
+	{"ApiAdminUserOffboardPost", http.MethodPost, "/api/admin/users/{username}/offboard"},
+	{"ApiAdminUserPasswordResetPost", http.MethodPost, "/api/admin/users/{username}/password-reset"},
 }
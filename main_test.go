@@ -2,14 +2,21 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pquerna/otp/totp"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
 )
 
 // Функция для настройки переменных окружения для тестов
@@ -34,16 +41,86 @@ func cleanupTestEnvironment(t *testing.T) {
 	os.Unsetenv("DB_PASSWORD")
 	os.Unsetenv("DB_NAME")
 
-	_, err := db.Exec("DELETE FROM transactions")
+	_, err := db.Exec("DELETE FROM coin_ledger")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы coin_ledger: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM coin_lots")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы coin_lots: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM notifications")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы notifications: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM giving_budgets")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы giving_budgets: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM scheduled_transfers")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы scheduled_transfers: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM transfer_disputes")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы transfer_disputes: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM pending_transfers")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы pending_transfers: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM transactions")
 	if err != nil {
 		t.Fatalf("Ошибка при очистке данных из таблицы transactions: %v", err)
 	}
 
+	_, err = db.Exec("DELETE FROM fraud_flags")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы fraud_flags: %v", err)
+	}
+
 	_, err = db.Exec("DELETE FROM purchases")
 	if err != nil {
 		t.Fatalf("Ошибка при очистке данных из таблицы purchases: %v", err)
 	}
 
+	_, err = db.Exec("DELETE FROM password_resets")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы password_resets: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM recovery_codes")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы recovery_codes: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM totp_credentials")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы totp_credentials: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM api_keys")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы api_keys: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM user_identities")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы user_identities: %v", err)
+	}
+
+	_, err = db.Exec("DELETE FROM offboardings")
+	if err != nil {
+		t.Fatalf("Ошибка при очистке данных из таблицы offboardings: %v", err)
+	}
+
 	_, err = db.Exec("DELETE FROM users")
 	if err != nil {
 		t.Fatalf("Ошибка при очистке данных из таблицы users: %v", err)
@@ -72,7 +149,7 @@ func TestApiAuthPost(t *testing.T) {
 	router := NewRouter()
 
 	// Создаем нового пользователя
-	authRequest := AuthRequest{
+	authRequest := oas.AuthRequest{
 		Username: "testuser",
 		Password: "testpassword",
 	}
@@ -84,12 +161,12 @@ func TestApiAuthPost(t *testing.T) {
 	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
 
 	// Проверяем наличие токена в ответе
-	var authResponse AuthResponse
+	var authResponse oas.AuthResponse
 	err := json.Unmarshal(recorder.Body.Bytes(), &authResponse)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
-	assert.NotEmpty(t, authResponse.Token, "Токен должен быть не пустым")
+	assert.NotEmpty(t, authResponse.Token.Value, "Токен должен быть не пустым")
 }
 
 // Тест покупки товара (GET /api/buy/{item})
@@ -101,20 +178,20 @@ func TestApiBuyItemGet(t *testing.T) {
 	router := NewRouter()
 
 	// Аутентифицируем пользователя для получения токена
-	authRequest := AuthRequest{
+	authRequest := oas.AuthRequest{
 		Username: "testuser",
 		Password: "testpassword",
 	}
 
 	authRecorder := performRequest(router, "POST", "/api/auth", authRequest)
-	var authResponse AuthResponse
+	var authResponse oas.AuthResponse
 	err := json.Unmarshal(authRecorder.Body.Bytes(), &authResponse)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
 
 	// Покупаем товар
-	token := authResponse.Token
+	token := authResponse.Token.Value
 	itemName := "t-shirt" // Предмет из списка itemPrices
 
 	req, _ := http.NewRequest("GET", "/api/buy/"+itemName, nil)
@@ -144,20 +221,20 @@ func TestApiInfoGet(t *testing.T) {
 	router := NewRouter()
 
 	// Аутентифицируем пользователя для получения токена
-	authRequest := AuthRequest{
+	authRequest := oas.AuthRequest{
 		Username: "testuser",
 		Password: "testpassword",
 	}
 
 	authRecorder := performRequest(router, "POST", "/api/auth", authRequest)
-	var authResponse AuthResponse
+	var authResponse oas.AuthResponse
 	err := json.Unmarshal(authRecorder.Body.Bytes(), &authResponse)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
 
 	// Получаем информацию о пользователе
-	token := authResponse.Token
+	token := authResponse.Token.Value
 	req, _ := http.NewRequest("GET", "/api/info", nil)
 	req.Header.Set("Authorization", token)
 	recorder := httptest.NewRecorder()
@@ -167,12 +244,12 @@ func TestApiInfoGet(t *testing.T) {
 	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
 
 	// Проверяем содержимое ответа
-	var infoResponse InfoResponse
+	var infoResponse oas.InfoResponse
 	err = json.Unmarshal(recorder.Body.Bytes(), &infoResponse)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
-	assert.GreaterOrEqual(t, infoResponse.Coins, int32(0), "Количество монет должно быть неотрицательным")
+	assert.GreaterOrEqual(t, infoResponse.Coins.Value, 0, "Количество монет должно быть неотрицательным")
 }
 
 // Тест отправки монет другому пользователю (POST /api/sendCoin)
@@ -183,35 +260,35 @@ func TestApiSendCoinPost(t *testing.T) {
 	router := NewRouter()
 
 	// Аутентифицируем первого пользователя
-	authRequest1 := AuthRequest{
+	authRequest1 := oas.AuthRequest{
 		Username: "testuser1",
 		Password: "testpassword1",
 	}
 	authRecorder1 := performRequest(router, "POST", "/api/auth", authRequest1)
-	var authResponse1 AuthResponse
+	var authResponse1 oas.AuthResponse
 	err := json.Unmarshal(authRecorder1.Body.Bytes(), &authResponse1)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
 
 	// Аутентифицируем второго пользователя
-	authRequest2 := AuthRequest{
+	authRequest2 := oas.AuthRequest{
 		Username: "testuser2",
 		Password: "testpassword2",
 	}
 	authRecorder2 := performRequest(router, "POST", "/api/auth", authRequest2)
-	var authResponse2 AuthResponse
+	var authResponse2 oas.AuthResponse
 	err = json.Unmarshal(authRecorder2.Body.Bytes(), &authResponse2)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
 
 	// Отправляем монеты от первого пользователя ко второму
-	sendCoinRequest := SendCoinRequest{
+	sendCoinRequest := oas.SendCoinRequest{
 		ToUser: "testuser2",
 		Amount: 100,
 	}
-	token := authResponse1.Token
+	token := authResponse1.Token.Value
 	body, _ := json.Marshal(sendCoinRequest)
 	req := httptest.NewRequest(http.MethodPost, "/api/sendCoin", bytes.NewBuffer(body))
 	req.Header.Set("Authorization", token)
@@ -248,17 +325,17 @@ func TestSendCoins_HappyPath(t *testing.T) {
 	}
 
 	// Авторизуемся как user1
-	authRequest := AuthRequest{
+	authRequest := oas.AuthRequest{
 		Username: "user1",
 		Password: "password1",
 	}
 	authRecorder := performRequest(router, "POST", "/api/auth", authRequest)
-	var authResponse AuthResponse
+	var authResponse oas.AuthResponse
 	err = json.Unmarshal(authRecorder.Body.Bytes(), &authResponse)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
-	token := authResponse.Token
+	token := authResponse.Token.Value
 
 	// Проверяем баланс user1 до отправки монет
 	var initialCoins int
@@ -268,7 +345,7 @@ func TestSendCoins_HappyPath(t *testing.T) {
 	}
 
 	// Отправляем 100 монет от user1 к user2
-	sendCoinRequest := SendCoinRequest{
+	sendCoinRequest := oas.SendCoinRequest{
 		ToUser: "user2",
 		Amount: 100,
 	}
@@ -309,17 +386,17 @@ func TestSendCoins_NegativePath(t *testing.T) {
 	}
 
 	// Авторизуемся как user1
-	authRequest := AuthRequest{
+	authRequest := oas.AuthRequest{
 		Username: "user1",
 		Password: "password1",
 	}
 	authRecorder := performRequest(router, "POST", "/api/auth", authRequest)
-	var authResponse AuthResponse
+	var authResponse oas.AuthResponse
 	err = json.Unmarshal(authRecorder.Body.Bytes(), &authResponse)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
-	token := authResponse.Token
+	token := authResponse.Token.Value
 
 	// Проверяем баланс user1 до отправки монет
 	var initialCoins int
@@ -329,7 +406,7 @@ func TestSendCoins_NegativePath(t *testing.T) {
 	}
 
 	// Попытка отправить 1001 монету
-	sendCoinRequest := SendCoinRequest{
+	sendCoinRequest := oas.SendCoinRequest{
 		ToUser: "user2",
 		Amount: 1001,
 	}
@@ -357,17 +434,17 @@ func TestBuyMerch_HappyPath(t *testing.T) {
 	router := NewRouter()
 
 	// Авторизуемся как user1
-	authRequest := AuthRequest{
+	authRequest := oas.AuthRequest{
 		Username: "user1",
 		Password: "password1",
 	}
 	authRecorder := performRequest(router, "POST", "/api/auth", authRequest)
-	var authResponse AuthResponse
+	var authResponse oas.AuthResponse
 	err := json.Unmarshal(authRecorder.Body.Bytes(), &authResponse)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
-	token := authResponse.Token
+	token := authResponse.Token.Value
 
 	// Проверяем баланс user1 до покупки
 	var initialCoins int
@@ -406,17 +483,17 @@ func TestBuyMerch_NotEnoughCoins(t *testing.T) {
 	}
 
 	// Авторизуемся как user1
-	authRequest := AuthRequest{
+	authRequest := oas.AuthRequest{
 		Username: "user1",
 		Password: "password1",
 	}
 	authRecorder := performRequest(router, "POST", "/api/auth", authRequest)
-	var authResponse AuthResponse
+	var authResponse oas.AuthResponse
 	err = json.Unmarshal(authRecorder.Body.Bytes(), &authResponse)
 	if err != nil {
 		t.Fatalf("Ошибка при парсинге JSON: %v", err)
 	}
-	token := authResponse.Token
+	token := authResponse.Token.Value
 
 	itemName := "pink-hoody"
 	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/buy/%s", itemName), nil)
@@ -450,3 +527,1331 @@ func TestBuyMerch_Unauthorized(t *testing.T) {
 	// Проверяем код ответа
 	assert.Equal(t, http.StatusUnauthorized, recorder.Code, "Ожидался код 401")
 }
+
+// Тест явной регистрации (POST /api/register)
+func TestApiRegisterPost(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	router := NewRouter()
+
+	recorder := performRequest(router, "POST", "/api/register", oas.AuthRequest{Username: "testuser", Password: "testpassword"})
+	assert.Equal(t, http.StatusCreated, recorder.Code, "Ожидался код 201")
+	var authResponse oas.AuthResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &authResponse); err != nil {
+		t.Fatalf("Ошибка при парсинге JSON: %v", err)
+	}
+	assert.NotEmpty(t, authResponse.Token.Value, "Токен должен быть не пустым")
+
+	// Имя, отличающееся регистром и пробелами, считается тем же пользователем
+	recorder = performRequest(router, "POST", "/api/register", oas.AuthRequest{Username: " TestUser ", Password: "other"})
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Ожидался код 409")
+	assert.Contains(t, recorder.Body.String(), codeUsernameTaken)
+
+	recorder = performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: "TESTUSER", Password: "testpassword"})
+	assert.Equal(t, http.StatusOK, recorder.Code, "Вход должен работать без учёта регистра имени")
+}
+
+// Тест входа неизвестного пользователя при выключенной авторегистрации
+func TestApiAuthPost_AutoRegisterDisabled(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	previous := registration
+	registration = registrationPolicy{AutoRegister: false}
+	defer func() { registration = previous }()
+
+	router := NewRouter()
+
+	recorder := performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: "testuser", Password: "testpassword"})
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, "Ожидался код 401")
+	assert.Contains(t, recorder.Body.String(), codeInvalidCredentials)
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM users WHERE username = 'testuser'").Scan(&count); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	assert.Equal(t, 0, count, "Пользователь не должен создаваться")
+
+	// Неверный пароль существующего пользователя неотличим от неизвестного имени
+	if _, err := db.Exec("INSERT INTO users (username, password, coins) VALUES ('knownuser', 'testpassword', 1000)"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	wrongPassword := performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: "knownuser", Password: "wrong"})
+	assert.Equal(t, recorder.Code, wrongPassword.Code)
+	assert.JSONEq(t, recorder.Body.String(), wrongPassword.Body.String(), "Ответ не должен раскрывать существование пользователя")
+}
+
+// Тест импорта справочника сотрудников (POST /api/admin/import-users)
+func TestApiAdminImportUsersPost(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	router := NewRouter()
+
+	authRecorder := performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: "admin", Password: "adminpassword"})
+	var authResponse oas.AuthResponse
+	if err := json.Unmarshal(authRecorder.Body.Bytes(), &authResponse); err != nil {
+		t.Fatalf("Ошибка при парсинге JSON: %v", err)
+	}
+	importUsers := func(query string) *httptest.ResponseRecorder {
+		roster := "username,display_name,department,manager,hire_date\n" +
+			"admin,Администратор,IT,,\n" +
+			"ivan.petrov,Иван Петров,Платформа,admin,2021-03-01\n"
+		req := httptest.NewRequest("POST", "/api/admin/import-users"+query, strings.NewReader(roster))
+		req.Header.Set("Authorization", authResponse.Token.Value)
+		req.Header.Set("Content-Type", "text/csv")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := importUsers("")
+	assert.Equal(t, http.StatusForbidden, recorder.Code, "Импорт доступен только администраторам")
+
+	if _, err := db.Exec("UPDATE users SET role = 'admin' WHERE username = 'admin'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	// Без apply возвращается только дифф
+	recorder = importUsers("")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var diff oas.ImportUsersResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &diff); err != nil {
+		t.Fatalf("Ошибка при парсинге JSON: %v", err)
+	}
+	assert.False(t, diff.Applied)
+	assert.Len(t, diff.Created, 1)
+	assert.Len(t, diff.Updated, 1)
+	var count int
+	db.QueryRow("SELECT COUNT(*) FROM users WHERE username = 'ivan.petrov'").Scan(&count)
+	assert.Equal(t, 0, count, "Пробный запуск не должен изменять БД")
+
+	recorder = importUsers("?apply=true")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+
+	var coins int
+	var manager string
+	err := db.QueryRow(`SELECT u.coins, m.username FROM users u JOIN users m ON m.id = u.manager_id
+		WHERE u.username = 'ivan.petrov'`).Scan(&coins, &manager)
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, coins, "Новый сотрудник должен получить стартовый баланс")
+	assert.Equal(t, "admin", manager)
+
+	// Учётную запись без пароля нельзя занять входом или регистрацией
+	recorder = performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: "ivan.petrov", Password: "secret"})
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, "Ожидался код 401")
+	assert.Contains(t, recorder.Body.String(), codeInvalidCredentials)
+	recorder = performRequest(router, "POST", "/api/register", oas.AuthRequest{Username: "ivan.petrov", Password: "secret"})
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Ожидался код 409")
+
+	// Пароль задаётся токеном сброса, выданным администратором
+	req := httptest.NewRequest("POST", "/api/admin/users/ivan.petrov/password-reset", nil)
+	req.Header.Set("Authorization", authResponse.Token.Value)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var reset oas.PasswordResetTokenResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &reset); err != nil {
+		t.Fatalf("Ошибка при парсинге JSON: %v", err)
+	}
+	recorder = performRequest(router, "POST", "/api/password/reset", oas.ResetPasswordRequest{ResetToken: reset.ResetToken, NewPassword: "secret"})
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	recorder = performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: "ivan.petrov", Password: "secret"})
+	assert.Equal(t, http.StatusOK, recorder.Code, "Вход с паролем, заданным по токену")
+}
+
+// Тест увольнения с передачей остатка на благотворительность
+func TestApiAdminUsersOffboardPost(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	previous := offboarding
+	offboarding = offboardingConfig{CharityAccount: "charity", DefaultDisposition: "treasury"}
+	defer func() { offboarding = previous }()
+
+	router := NewRouter()
+	login := func(username string) *httptest.ResponseRecorder {
+		return performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: username, Password: "password"})
+	}
+	token := func(username string) string {
+		var authResponse oas.AuthResponse
+		if err := json.Unmarshal(login(username).Body.Bytes(), &authResponse); err != nil {
+			t.Fatalf("Ошибка при парсинге JSON: %v", err)
+		}
+		return authResponse.Token.Value
+	}
+	adminToken := token("admin")
+	userToken := token("user1")
+	senderToken := token("user2")
+	token("charity")
+	if _, err := db.Exec("UPDATE users SET role = 'admin' WHERE username = 'admin'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	// Ожидающие переводы в обе стороны и запланированный перевод уволенному
+	defer func(saved pendingPolicy) { pendingTransfers = saved }(pendingTransfers)
+	pendingTransfers.RequireAcceptance = true
+	for _, transfer := range []struct{ token, body string }{
+		{userToken, `{"toUser":"user2","amount":100}`},
+		{senderToken, `{"toUser":"user1","amount":50}`},
+	} {
+		req := httptest.NewRequest("POST", "/api/sendCoin", strings.NewReader(transfer.body))
+		req.Header.Set("Authorization", transfer.token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusAccepted, recorder.Code, "Ожидался код 202")
+	}
+	_, err := db.Exec(`INSERT INTO scheduled_transfers (sender_id, receiver_id, amount, recurrence, start_at, next_run_at)
+		SELECT s.id, r.id, 10, 'weekly', NOW(), NOW() FROM users s, users r WHERE s.username = 'user2' AND r.username = 'user1'`)
+	if err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/api/admin/users/user1/offboard", strings.NewReader(`{"disposition":"charity"}`))
+	req.Header.Set("Authorization", adminToken)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var offboardResponse oas.OffboardResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &offboardResponse); err != nil {
+		t.Fatalf("Ошибка при парсинге JSON: %v", err)
+	}
+	assert.Equal(t, 1000, offboardResponse.Amount)
+	assert.Equal(t, "charity", offboardResponse.Recipient.Value)
+
+	var charityCoins int
+	db.QueryRow("SELECT coins FROM users WHERE username = 'charity'").Scan(&charityCoins)
+	assert.Equal(t, 2000, charityCoins, "Остаток должен перейти на благотворительный счёт")
+
+	var senderCoins, open int
+	var scheduleStatus string
+	db.QueryRow("SELECT coins FROM users WHERE username = 'user2'").Scan(&senderCoins)
+	assert.Equal(t, 1000, senderCoins, "Входящий ожидающий перевод возвращается отправителю")
+	db.QueryRow("SELECT COUNT(*) FROM pending_transfers WHERE status IN " + openTransferStatuses).Scan(&open)
+	assert.Zero(t, open, "Ожидающие переводы уволенного отменяются")
+	db.QueryRow("SELECT status FROM scheduled_transfers").Scan(&scheduleStatus)
+	assert.Equal(t, "paused", scheduleStatus, "Запланированные переводы приостанавливаются")
+
+	// Токены уволенного отозваны, вход запрещён
+	req = httptest.NewRequest("GET", "/api/info", nil)
+	req.Header.Set("Authorization", userToken)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, "Ожидался код 401")
+	assert.Equal(t, http.StatusForbidden, login("user1").Code, "Ожидался код 403")
+
+	// Переводы деактивированному пользователю запрещены
+	req = httptest.NewRequest("POST", "/api/sendCoin", strings.NewReader(`{"toUser":"user1","amount":10}`))
+	req.Header.Set("Authorization", senderToken)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Ожидался код 400")
+	assert.Contains(t, recorder.Body.String(), codeReceiverInactive)
+}
+
+// Тест смены пароля и сброса пароля администратором
+func TestApiPasswordChangeAndReset(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	router := NewRouter()
+	login := func(username, password string) (int, string) {
+		recorder := performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: username, Password: password})
+		var authResponse oas.AuthResponse
+		json.Unmarshal(recorder.Body.Bytes(), &authResponse)
+		return recorder.Code, authResponse.Token.Value
+	}
+	post := func(path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	info := func(token string) int {
+		req := httptest.NewRequest("GET", "/api/info", nil)
+		req.Header.Set("Authorization", token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	_, adminToken := login("admin", "adminpassword")
+	if _, err := db.Exec("UPDATE users SET role = 'admin' WHERE username = 'admin'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	_, oldToken := login("user1", "password1")
+
+	recorder := post("/api/password", oldToken, `{"oldPassword":"wrong","newPassword":"password2"}`)
+	assert.Equal(t, http.StatusForbidden, recorder.Code, "Смена пароля требует текущий пароль")
+
+	recorder = post("/api/password", oldToken, `{"oldPassword":"password1","newPassword":"password2"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var authResponse oas.AuthResponse
+	json.Unmarshal(recorder.Body.Bytes(), &authResponse)
+	assert.Equal(t, http.StatusUnauthorized, info(oldToken), "Старые токены должны быть отозваны")
+	assert.Equal(t, http.StatusOK, info(authResponse.Token.Value), "Новый токен должен работать")
+
+	code, _ := login("user1", "password1")
+	assert.Equal(t, http.StatusUnauthorized, code, "Старый пароль не должен подходить")
+
+	// Сброс пароля администратором
+	recorder = post("/api/admin/users/user1/password-reset", adminToken, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var resetResponse oas.PasswordResetTokenResponse
+	json.Unmarshal(recorder.Body.Bytes(), &resetResponse)
+	assert.NotEmpty(t, resetResponse.ResetToken)
+
+	body := `{"resetToken":"` + resetResponse.ResetToken + `","newPassword":"password3"}`
+	assert.Equal(t, http.StatusOK, post("/api/password/reset", "", body).Code, "Ожидался код 200")
+	assert.Equal(t, http.StatusBadRequest, post("/api/password/reset", "", body).Code, "Токен сброса одноразовый")
+	assert.Equal(t, http.StatusUnauthorized, info(authResponse.Token.Value), "Сброс должен отзывать токены")
+
+	code, _ = login("user1", "password3")
+	assert.Equal(t, http.StatusOK, code, "Вход с новым паролем")
+}
+
+func TestApiTwoFactorAuth(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	router := NewRouter()
+	post := func(path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func() oas.AuthResponse {
+		recorder := post("/api/auth", "", `{"username":"user1","password":"password1"}`)
+		assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+		var authResponse oas.AuthResponse
+		json.Unmarshal(recorder.Body.Bytes(), &authResponse)
+		return authResponse
+	}
+
+	token := login().Token.Value
+	recorder := post("/api/2fa/totp", token, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var enrollResponse oas.TotpEnrollResponse
+	json.Unmarshal(recorder.Body.Bytes(), &enrollResponse)
+	assert.True(t, strings.HasPrefix(enrollResponse.OtpauthUri, "otpauth://totp/"), "Ожидался otpauth URI")
+
+	// Код предыдущего шага, чтобы следующий код не совпал с уже использованным
+	code, err := totp.GenerateCodeCustom(enrollResponse.Secret, time.Now().Add(-totpPeriod*time.Second), totpOpts)
+	if err != nil {
+		t.Fatalf("Не удалось сгенерировать код: %v", err)
+	}
+	assert.Equal(t, http.StatusBadRequest, post("/api/2fa/totp/confirm", token, `{"code":"000000"}`).Code, "Неверный код не должен подтверждать подключение")
+	recorder = post("/api/2fa/totp/confirm", token, `{"code":"`+code+`"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var codesResponse oas.RecoveryCodesResponse
+	json.Unmarshal(recorder.Body.Bytes(), &codesResponse)
+	assert.Len(t, codesResponse.RecoveryCodes, recoveryCodeCount)
+
+	// Теперь вход требует второго шага
+	authResponse := login()
+	assert.False(t, authResponse.Token.Set, "Токен не должен выдаваться до второго шага")
+	assert.True(t, authResponse.MfaRequired.Value)
+	assert.Equal(t, http.StatusUnauthorized, post("/api/sendCoin", authResponse.MfaToken.Value, `{"toUser":"user2","amount":1}`).Code,
+		"Токен второго шага не должен давать доступ к API")
+
+	step := `{"mfaToken":"` + authResponse.MfaToken.Value + `","code":"`
+	assert.Equal(t, http.StatusUnauthorized, post("/api/auth/2fa", "", step+code+`"}`).Code, "Использованный код не должен приниматься повторно")
+	recorder = post("/api/auth/2fa", "", step+codesResponse.RecoveryCodes[0]+`"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Код восстановления должен подходить")
+	json.Unmarshal(recorder.Body.Bytes(), &authResponse)
+	claims, err := parseToken(context.Background(), authResponse.Token.Value)
+	assert.NoError(t, err)
+	assert.True(t, claims.MFA, "Токен должен отмечать вход со вторым фактором")
+	assert.Equal(t, http.StatusUnauthorized, post("/api/auth/2fa", "", step+codesResponse.RecoveryCodes[0]+`"}`).Code, "Код восстановления одноразовый")
+
+	recorder = post("/api/2fa/totp/disable", authResponse.Token.Value, `{"code":"`+codesResponse.RecoveryCodes[1]+`"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	assert.True(t, login().Token.Set, "После отключения вход снова в один шаг")
+}
+
+func TestApiAuthOidcCallbackPost(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	provider := newMockOIDCProvider(t)
+	client, err := newOIDCClient(context.Background(), provider.config())
+	if err != nil {
+		t.Fatalf("Не удалось подключиться к провайдеру: %v", err)
+	}
+	defer func(saved *oidcClient) { sso = saved }(sso)
+	sso = client
+
+	router := NewRouter()
+	callback := func(code, subject, username string) *httptest.ResponseRecorder {
+		state, nonce := startOIDCLogin(t, client)
+		provider.issueCode(code, jwt.MapClaims{"sub": subject, "nonce": nonce, "preferred_username": username})
+		return performRequest(router, "POST", "/api/auth/oidc/callback", oas.OidcCallbackRequest{Code: code, State: state})
+	}
+
+	// Первый вход создаёт пользователя
+	recorder := callback("code1", "u-1", "Alice")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var authResponse oas.AuthResponse
+	json.Unmarshal(recorder.Body.Bytes(), &authResponse)
+	claims, err := parseToken(context.Background(), authResponse.Token.Value)
+	assert.NoError(t, err)
+
+	// Повторный вход находит пользователя по subject, даже если имя у провайдера сменилось
+	recorder = callback("code2", "u-1", "alice.renamed")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	json.Unmarshal(recorder.Body.Bytes(), &authResponse)
+	renamed, err := parseToken(context.Background(), authResponse.Token.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, claims.UserID, renamed.UserID, "Ожидался тот же пользователь")
+
+	// Пароль пользователю провайдера через /api/auth не задаётся
+	recorder = performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: "alice", Password: "password"})
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, "Ожидался код 401")
+
+	// Существующий локальный пользователь связывается по имени, а другой subject
+	// для уже связанного пользователя отклоняется
+	performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: "bob", Password: "password"})
+	assert.Equal(t, http.StatusOK, callback("code3", "u-2", "bob").Code, "Ожидался код 200")
+	assert.Equal(t, http.StatusConflict, callback("code4", "u-3", "bob").Code, "Ожидался код 409")
+}
+
+func TestApiServiceAccountKeys(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := request("POST", "/api/auth", "", `{"username":"admin","password":"adminpassword"}`)
+	var authResponse oas.AuthResponse
+	json.Unmarshal(recorder.Body.Bytes(), &authResponse)
+	adminToken := authResponse.Token.Value
+	if _, err := db.Exec("UPDATE users SET role = 'admin' WHERE username = 'admin'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	request("POST", "/api/auth", "", `{"username":"newhire","password":"password"}`)
+
+	recorder = request("POST", "/api/admin/service-accounts", adminToken, `{"username":"onboarding-bot","coins":100}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code, "Ожидался код 201")
+	assert.Equal(t, http.StatusConflict, request("POST", "/api/admin/service-accounts", adminToken, `{"username":"newhire"}`).Code, "Ожидался код 409")
+
+	// Сервисный аккаунт не может задать себе пароль через /api/auth
+	assert.Equal(t, http.StatusUnauthorized, request("POST", "/api/auth", "", `{"username":"onboarding-bot","password":"password"}`).Code)
+
+	recorder = request("POST", "/api/admin/service-accounts/onboarding-bot/keys", adminToken, `{"name":"welcome","scopes":["grant:coins"]}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code, "Ожидался код 201")
+	var created oas.ApiKeyCreatedResponse
+	json.Unmarshal(recorder.Body.Bytes(), &created)
+	assert.True(t, isAPIKey(created.Key))
+	assert.Equal(t, created.Key[:apiKeyDisplayLen], created.ApiKey.Prefix)
+
+	var stored string
+	db.QueryRow("SELECT key_hash FROM api_keys WHERE id = $1", created.ApiKey.ID).Scan(&stored)
+	assert.Equal(t, hashSecret(created.Key), stored, "В БД должен храниться только хэш ключа")
+
+	// Порог второго фактора на ключ с grant:coins не действует
+	defer func(saved mfaPolicy) { mfa = saved }(mfa)
+	mfa.TransferThreshold = 10
+	recorder = request("POST", "/api/sendCoin", "Bearer "+created.Key, `{"toUser":"newhire","amount":50}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ключ с grant:coins должен переводить монеты")
+	assert.Equal(t, http.StatusForbidden, request("GET", "/api/info", "Bearer "+created.Key, "").Code, "Ключ без read:info")
+
+	recorder = request("GET", "/api/admin/service-accounts/onboarding-bot/keys", adminToken, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var list oas.ApiKeyList
+	json.Unmarshal(recorder.Body.Bytes(), &list)
+	assert.Len(t, list.Keys, 1)
+	assert.True(t, list.Keys[0].LastUsedAt.Set, "Должно отмечаться время использования")
+	assert.NotContains(t, recorder.Body.String(), created.Key, "Ключ не должен показываться повторно")
+
+	path := fmt.Sprintf("/api/admin/service-accounts/onboarding-bot/keys/%d", created.ApiKey.ID)
+	assert.Equal(t, http.StatusOK, request("DELETE", path, adminToken, "").Code, "Ожидался код 200")
+	assert.Equal(t, http.StatusUnauthorized, request("POST", "/api/sendCoin", "Bearer "+created.Key, `{"toUser":"newhire","amount":1}`).Code,
+		"Отозванный ключ не должен работать")
+}
+
+func TestApiKudos(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(request("POST", "/api/auth", "", `{"username":"`+username+`","password":"password"}`).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+
+	aliceToken := login("alice")
+	bobToken := login("bob")
+
+	recorder := request("POST", "/api/sendCoin", aliceToken, `{"toUser":"bob","amount":10,"message":"Спасибо\u0000 за   ревью!","category":"help","public":true}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	request("POST", "/api/sendCoin", aliceToken, `{"toUser":"bob","amount":5,"message":"Личное"}`)
+	request("POST", "/api/sendCoin", bobToken, `{"toUser":"alice","amount":1,"category":"thanks","public":true}`)
+
+	var info oas.InfoResponse
+	json.Unmarshal(request("GET", "/api/info", bobToken, "").Body.Bytes(), &info)
+	received := info.CoinHistory.Value.Received
+	assert.Len(t, received, 2)
+	messages := []string{received[0].Message.Value, received[1].Message.Value}
+	assert.ElementsMatch(t, []string{"Спасибо за ревью!", "Личное"}, messages, "Сообщения должны видеть участники перевода")
+
+	recorder = request("GET", "/api/kudos?limit=1", aliceToken, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	assert.NotContains(t, recorder.Body.String(), "amount", "Лента не должна показывать суммы")
+	var feed oas.KudosFeed
+	json.Unmarshal(recorder.Body.Bytes(), &feed)
+	assert.Len(t, feed.Items, 1)
+	assert.Equal(t, "bob", feed.Items[0].FromUser, "Лента начинается с новых переводов")
+	assert.Equal(t, oas.TransferCategoryThanks, feed.Items[0].Category.Value)
+	assert.True(t, feed.NextCursor.Set)
+
+	path := fmt.Sprintf("/api/kudos?limit=1&cursor=%d", feed.NextCursor.Value)
+	feed = oas.KudosFeed{}
+	json.Unmarshal(request("GET", path, aliceToken, "").Body.Bytes(), &feed)
+	assert.Len(t, feed.Items, 1)
+	assert.Equal(t, "Спасибо за ревью!", feed.Items[0].Message.Value, "Непубличный перевод не должен попадать в ленту")
+	assert.False(t, feed.NextCursor.Set, "Страница последняя")
+
+	// Возвращённый по спору перевод и сам возврат из ленты исчезают
+	_, err := db.Exec(`INSERT INTO transactions (sender_id, receiver_id, amount, reverses_id, public)
+		SELECT receiver_id, sender_id, amount, id, TRUE FROM transactions WHERE message = 'Спасибо за ревью!'`)
+	if err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	feed = oas.KudosFeed{}
+	json.Unmarshal(request("GET", "/api/kudos", aliceToken, "").Body.Bytes(), &feed)
+	if assert.Len(t, feed.Items, 1) {
+		assert.Equal(t, "bob", feed.Items[0].FromUser)
+	}
+}
+
+func TestApiSendCoinBatchPost(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(request("POST", "/api/auth", "", `{"username":"`+username+`","password":"password"}`).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+	coins := func(username string) int {
+		var coins int
+		if err := db.QueryRow("SELECT coins FROM users WHERE username = $1", username).Scan(&coins); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		return coins
+	}
+
+	leadToken := login("lead")
+	login("alice")
+	login("bob")
+	login("carol")
+
+	// Один неизвестный получатель отменяет весь пакет
+	recorder := request("POST", "/api/sendCoin/batch", leadToken, `{"transfers":[{"toUser":"alice","amount":10},{"toUser":"nobody","amount":10}]}`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Ожидался код 400")
+	var errResponse oas.ErrorResponse
+	json.Unmarshal(recorder.Body.Bytes(), &errResponse)
+	assert.Equal(t, codeReceiverNotFound, errResponse.Code.Value)
+	assert.Equal(t, "transfers[1].toUser", errResponse.Details[0].Field)
+	assert.Equal(t, 1000, coins("alice"), "Частичный перевод не должен выполняться")
+	assert.Equal(t, 1000, coins("lead"))
+
+	recorder = request("POST", "/api/sendCoin/batch", leadToken, `{"transfers":[{"toUser":"alice"},{"toUser":"bob"},{"toUser":"carol"}],"totalAmount":100,"message":"Спасибо за релиз"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var response oas.BatchSendCoinResponse
+	json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, 100, response.TotalAmount)
+	assert.Len(t, response.Transfers, 3)
+	assert.Equal(t, 900, coins("lead"))
+	assert.Equal(t, 1034, coins("alice"))
+	assert.Equal(t, 1033, coins("carol"))
+
+	recorder = request("POST", "/api/sendCoin/batch", leadToken, `{"transfers":[{"toUser":"alice","amount":500},{"toUser":"bob","amount":500}]}`)
+	json.Unmarshal(recorder.Body.Bytes(), &errResponse)
+	assert.Equal(t, codeNotEnoughCoins, errResponse.Code.Value, "Проверяется сумма всего пакета")
+	assert.Equal(t, 900, coins("lead"))
+}
+
+func TestApiSendCoinPost_TransferLimits(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+	defer func(saved transferPolicy) { transferLimits = saved }(transferLimits)
+	transferLimits = transferPolicy{DailyLimit: 150, DailyReceiveLimit: 120, RecipientCooldown: time.Hour}
+
+	router := NewRouter()
+	send := func(token, toUser string, amount int) (int, oas.ErrorResponse, http.Header) {
+		body, _ := json.Marshal(oas.SendCoinRequest{ToUser: toUser, Amount: amount})
+		req := httptest.NewRequest(http.MethodPost, "/api/sendCoin", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		var errResponse oas.ErrorResponse
+		json.Unmarshal(recorder.Body.Bytes(), &errResponse)
+		return recorder.Code, errResponse, recorder.Header()
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(performRequest(router, "POST", "/api/auth", oas.AuthRequest{Username: username, Password: "password"}).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+
+	aliceToken := login("alice")
+	carolToken := login("carol")
+	login("bob")
+	login("dave")
+
+	code, _, _ := send(aliceToken, "bob", 100)
+	assert.Equal(t, http.StatusOK, code, "Ожидался код 200")
+
+	code, errResponse, header := send(aliceToken, "bob", 10)
+	assert.Equal(t, http.StatusTooManyRequests, code, "Повторный перевод тому же получателю")
+	assert.Equal(t, codeRecipientCooldown, errResponse.Code.Value)
+	assert.NotEmpty(t, header.Get("Retry-After"))
+
+	_, errResponse, _ = send(aliceToken, "dave", 60)
+	assert.Equal(t, codeDailyLimitExceeded, errResponse.Code.Value, "За сутки можно отправить не больше 150")
+	code, _, _ = send(aliceToken, "dave", 50)
+	assert.Equal(t, http.StatusOK, code, "Ожидался код 200")
+
+	_, errResponse, _ = send(carolToken, "bob", 30)
+	assert.Equal(t, codeReceiveLimitExceeded, errResponse.Code.Value, "За сутки можно получить не больше 120")
+
+	var coins int
+	db.QueryRow("SELECT coins FROM users WHERE username = 'bob'").Scan(&coins)
+	assert.Equal(t, 1100, coins, "Отклонённые переводы не должны менять баланс")
+}
+
+func TestApiAdminFraudFlags(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+	defer func(saved fraudConfig) { fraud = saved }(fraud)
+	fraud.AutoFreeze = true
+	fraud.VolumeThreshold = 0
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(request("POST", "/api/auth", "", `{"username":"`+username+`","password":"password"}`).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+	send := func(token, toUser string) {
+		recorder := request("POST", "/api/sendCoin", token, `{"toUser":"`+toUser+`","amount":100}`)
+		assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	}
+
+	adminToken := login("admin")
+	if _, err := db.Exec("UPDATE users SET role = 'admin' WHERE username = 'admin'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	// Давние сотрудники: их переводы не считаются переводами новых учётных записей
+	login("alice")
+	login("bob")
+	login("carol")
+	if _, err := db.Exec("UPDATE users SET created_at = NOW() - INTERVAL '30 days'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	// Бонусы трёх новых учётных записей уходят одному получателю
+	login("farmer")
+	for _, username := range []string{"sock1", "sock2", "sock3"} {
+		send(login(username), "farmer")
+	}
+	// Только что импортированные из справочника сотрудники новыми не считаются
+	login("dave")
+	for _, username := range []string{"hr1", "hr2", "hr3"} {
+		if _, err := db.Exec("INSERT INTO users (username, password, coins, imported_at) VALUES ($1, 'password', 1000, NOW())", username); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		send(login(username), "dave")
+	}
+	// Круговые переводы между давними сотрудниками
+	send(login("alice"), "bob")
+	send(login("bob"), "carol")
+	send(login("carol"), "alice")
+
+	recorder := request("POST", "/api/admin/fraud-flags/scan", adminToken, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	var scan oas.FraudScanResult
+	json.Unmarshal(recorder.Body.Bytes(), &scan)
+	assert.Equal(t, 4, scan.Flagged, "Получатель бонусов и три участника цикла")
+	assert.Equal(t, 4, scan.Frozen)
+
+	recorder = request("POST", "/api/admin/fraud-flags/scan", adminToken, "")
+	json.Unmarshal(recorder.Body.Bytes(), &scan)
+	assert.Equal(t, 0, scan.Flagged, "Повторный анализ не должен дублировать отметки")
+
+	var list oas.FraudFlagList
+	json.Unmarshal(request("GET", "/api/admin/fraud-flags", adminToken, "").Body.Bytes(), &list)
+	assert.Len(t, list.Flags, 4)
+	var fanIn oas.FraudFlag
+	for _, flag := range list.Flags {
+		if flag.Kind == oas.FraudFlagKindFanIn {
+			fanIn = flag
+		}
+	}
+	assert.Equal(t, "farmer", fanIn.Username)
+	assert.Equal(t, []string{"sock1", "sock2", "sock3"}, fanIn.RelatedUsers)
+	assert.Equal(t, 300, fanIn.Amount.Value)
+	assert.True(t, fanIn.Frozen)
+
+	assert.Empty(t, login("farmer"), "Заблокированная учётная запись не может войти")
+
+	path := fmt.Sprintf("/api/admin/fraud-flags/%d/resolve", fanIn.ID)
+	recorder = request("POST", path, adminToken, `{"resolution":"dismissed"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	assert.Equal(t, http.StatusConflict, request("POST", path, adminToken, `{"resolution":"confirmed"}`).Code, "Отметка уже закрыта")
+	assert.NotEmpty(t, login("farmer"), "Отклонение отметки снимает блокировку")
+
+	request("POST", "/api/admin/fraud-flags/scan", adminToken, "")
+	json.Unmarshal(request("GET", "/api/admin/fraud-flags", adminToken, "").Body.Bytes(), &list)
+	assert.Len(t, list.Flags, 3, "Закрытая отметка не должна появляться снова по тем же переводам")
+}
+
+func TestApiPendingTransfers(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+	defer func(saved pendingPolicy) { pendingTransfers = saved }(pendingTransfers)
+	pendingTransfers.RequireAcceptance = true
+	pendingTransfers.ApprovalThreshold = 500
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(request("POST", "/api/auth", "", `{"username":"`+username+`","password":"password"}`).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+	send := func(token string, amount int) oas.PendingTransfer {
+		recorder := request("POST", "/api/sendCoin", token, fmt.Sprintf(`{"toUser":"bob","amount":%d,"message":"Спасибо"}`, amount))
+		assert.Equal(t, http.StatusAccepted, recorder.Code, "Ожидался код 202")
+		var transfer oas.PendingTransfer
+		json.Unmarshal(recorder.Body.Bytes(), &transfer)
+		return transfer
+	}
+	action := func(token string, id int, name string) (int, oas.PendingTransfer) {
+		recorder := request("POST", fmt.Sprintf("/api/transfers/%d/%s", id, name), token, "")
+		var transfer oas.PendingTransfer
+		json.Unmarshal(recorder.Body.Bytes(), &transfer)
+		return recorder.Code, transfer
+	}
+	coins := func(username string) int {
+		var coins int
+		if err := db.QueryRow("SELECT coins FROM users WHERE username = $1", username).Scan(&coins); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		return coins
+	}
+
+	managerToken := login("manager")
+	aliceToken := login("alice")
+	bobToken := login("bob")
+	if _, err := db.Exec("UPDATE users SET manager_id = (SELECT id FROM users WHERE username = 'manager') WHERE username = 'alice'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	// Монеты резервируются при отправке и зачисляются после подтверждения
+	transfer := send(aliceToken, 100)
+	assert.Equal(t, oas.TransferStatusAwaitingAcceptance, transfer.Status)
+	assert.Equal(t, 900, coins("alice"))
+	assert.Equal(t, 1000, coins("bob"))
+
+	var list oas.PendingTransferList
+	json.Unmarshal(request("GET", "/api/transfers/pending", bobToken, "").Body.Bytes(), &list)
+	assert.Len(t, list.Incoming, 1)
+	assert.Equal(t, "Спасибо", list.Incoming[0].Message.Value)
+
+	code, _ := action(aliceToken, transfer.ID, "accept")
+	assert.Equal(t, http.StatusNotFound, code, "Отправитель не может подтвердить свой перевод")
+	code, transfer = action(bobToken, transfer.ID, "accept")
+	assert.Equal(t, http.StatusOK, code, "Ожидался код 200")
+	assert.Equal(t, oas.TransferStatusAccepted, transfer.Status)
+	assert.True(t, transfer.TransactionId.Set)
+	assert.Equal(t, 1100, coins("bob"))
+	code, _ = action(bobToken, transfer.ID, "decline")
+	assert.Equal(t, http.StatusConflict, code, "Перевод уже принят")
+
+	transfer = send(aliceToken, 50)
+	action(bobToken, transfer.ID, "decline")
+	transfer = send(aliceToken, 20)
+	_, transfer = action(aliceToken, transfer.ID, "cancel")
+	assert.Equal(t, oas.TransferStatusCancelled, transfer.Status)
+	assert.Equal(t, 900, coins("alice"), "Отклонённые и отменённые переводы возвращаются отправителю")
+
+	// Крупный перевод одобряет руководитель отправителя
+	transfer = send(aliceToken, 600)
+	assert.Equal(t, oas.TransferStatusAwaitingApproval, transfer.Status)
+	assert.Equal(t, "manager", transfer.Approver.Value)
+	code, _ = action(bobToken, transfer.ID, "accept")
+	assert.Equal(t, http.StatusConflict, code, "Перевод ещё не одобрен")
+	json.Unmarshal(request("GET", "/api/transfers/pending", managerToken, "").Body.Bytes(), &list)
+	assert.Len(t, list.Approvals, 1)
+	code, transfer = action(managerToken, transfer.ID, "approve")
+	assert.Equal(t, http.StatusOK, code, "Ожидался код 200")
+	assert.Equal(t, oas.TransferStatusAwaitingAcceptance, transfer.Status)
+	action(bobToken, transfer.ID, "accept")
+	assert.Equal(t, 1700, coins("bob"))
+	assert.Equal(t, 300, coins("alice"))
+
+	// Неподтверждённый вовремя перевод возвращается отправителю
+	transfer = send(aliceToken, 10)
+	if _, err := db.Exec("UPDATE pending_transfers SET expires_at = NOW() - INTERVAL '1 second' WHERE id = $1", transfer.ID); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	expired, err := expirePendingTransfers(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, 300, coins("alice"))
+	code, _ = action(bobToken, transfer.ID, "accept")
+	assert.Equal(t, http.StatusConflict, code, "Истёкший перевод нельзя принять")
+
+	// Получателя уволили, пока перевод ждал одобрения: монеты возвращаются
+	pendingTransfers.RequireAcceptance = false
+	pendingTransfers.ApprovalThreshold = 200
+	transfer = send(aliceToken, 200)
+	if _, err := db.Exec("UPDATE users SET status = 'deactivated' WHERE username = 'bob'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	code, transfer = action(managerToken, transfer.ID, "approve")
+	assert.Equal(t, http.StatusOK, code, "Ожидался код 200")
+	assert.Equal(t, oas.TransferStatusCancelled, transfer.Status)
+	assert.Equal(t, 300, coins("alice"))
+	assert.Equal(t, 1700, coins("bob"), "Уволенному сотруднику монеты не зачисляются")
+}
+
+func TestApiDisputes(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(request("POST", "/api/auth", "", `{"username":"`+username+`","password":"password"}`).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+	send := func(token, toUser string, amount int) int {
+		assert.Equal(t, http.StatusOK, request("POST", "/api/sendCoin", token, fmt.Sprintf(`{"toUser":"%s","amount":%d}`, toUser, amount)).Code)
+		var id int
+		if err := db.QueryRow("SELECT MAX(id) FROM transactions").Scan(&id); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		return id
+	}
+	openDispute := func(token string, transactionID int) (int, oas.Dispute) {
+		recorder := request("POST", fmt.Sprintf("/api/transactions/%d/dispute", transactionID), token, `{"reason":"Ошибся получателем"}`)
+		var d oas.Dispute
+		json.Unmarshal(recorder.Body.Bytes(), &d)
+		return recorder.Code, d
+	}
+	resolve := func(token string, id int, body string) (int, oas.Dispute) {
+		recorder := request("POST", fmt.Sprintf("/api/admin/disputes/%d/resolve", id), token, body)
+		var d oas.Dispute
+		json.Unmarshal(recorder.Body.Bytes(), &d)
+		return recorder.Code, d
+	}
+	coins := func(username string) int {
+		var coins int
+		if err := db.QueryRow("SELECT coins FROM users WHERE username = $1", username).Scan(&coins); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		return coins
+	}
+
+	adminToken := login("admin")
+	aliceToken := login("alice")
+	bobToken := login("bob")
+	if _, err := db.Exec("UPDATE users SET role = 'admin' WHERE username = 'admin'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	// Спор открывает только отправитель
+	transactionID := send(aliceToken, "bob", 100)
+	code, _ := openDispute(bobToken, transactionID)
+	assert.Equal(t, http.StatusNotFound, code, "Получатель не может оспорить чужой перевод")
+	code, d := openDispute(aliceToken, transactionID)
+	assert.Equal(t, http.StatusCreated, code, "Ожидался код 201")
+	assert.Equal(t, oas.DisputeStatusOpen, d.Status)
+	assert.Equal(t, "bob", d.ToUser)
+	code, _ = openDispute(aliceToken, transactionID)
+	assert.Equal(t, http.StatusConflict, code, "Спор по переводу может быть только один")
+
+	var list oas.DisputeList
+	json.Unmarshal(request("GET", "/api/disputes", bobToken, "").Body.Bytes(), &list)
+	assert.Len(t, list.Received, 1)
+	assert.Empty(t, list.Opened)
+
+	// Получатель возвращает монеты добровольно
+	code, d = resolve(bobToken, d.ID, `{"decision":"reverse"}`)
+	assert.Equal(t, http.StatusForbidden, code, "Решать споры может только администратор")
+	recorder := request("POST", fmt.Sprintf("/api/disputes/%d/return", d.ID), bobToken, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	json.Unmarshal(recorder.Body.Bytes(), &d)
+	assert.Equal(t, oas.DisputeStatusReturned, d.Status)
+	assert.Equal(t, 100, d.ReversedAmount.Value)
+	assert.Equal(t, 1000, coins("alice"))
+	assert.Equal(t, 1000, coins("bob"))
+
+	var info oas.InfoResponse
+	json.Unmarshal(request("GET", "/api/info", aliceToken, "").Body.Bytes(), &info)
+	if assert.Len(t, info.CoinHistory.Value.Received, 1) {
+		assert.Equal(t, transactionID, info.CoinHistory.Value.Received[0].ReversalOf.Value, "Возврат связан с исходным переводом")
+	}
+	code, _ = openDispute(aliceToken, d.ReversalTransactionId.Value)
+	assert.Equal(t, http.StatusNotFound, code, "Возврат отправлен не пользователем")
+
+	// Администратор отменяет перевод, монеты которого получатель уже потратил
+	transactionID = send(aliceToken, "bob", 300)
+	_, d = openDispute(aliceToken, transactionID)
+	if _, err := db.Exec("UPDATE users SET coins = 200 WHERE username = 'bob'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	recorder = request("POST", fmt.Sprintf("/api/disputes/%d/return", d.ID), bobToken, "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Вернуть можно только всю сумму")
+
+	var admin oas.AdminDisputeList
+	json.Unmarshal(request("GET", "/api/admin/disputes", adminToken, "").Body.Bytes(), &admin)
+	assert.Len(t, admin.Disputes, 1)
+
+	code, d = resolve(adminToken, d.ID, `{"decision":"reverse","note":"Ошибочный перевод"}`)
+	assert.Equal(t, http.StatusOK, code, "Ожидался код 200")
+	assert.Equal(t, oas.DisputeStatusReversed, d.Status)
+	assert.Equal(t, 200, d.ReversedAmount.Value)
+	assert.Equal(t, 100, d.Shortfall.Value, "Потраченная часть записывается как недостача")
+	assert.Equal(t, "admin", d.ResolvedBy.Value)
+	assert.Equal(t, 0, coins("bob"))
+	assert.Equal(t, 900, coins("alice"))
+	code, _ = resolve(adminToken, d.ID, `{"decision":"reject"}`)
+	assert.Equal(t, http.StatusConflict, code, "Спор уже закрыт")
+
+	// С allowNegativeBalance списывается вся сумма
+	transactionID = send(aliceToken, "bob", 50)
+	_, d = openDispute(aliceToken, transactionID)
+	if _, err := db.Exec("UPDATE users SET coins = 0 WHERE username = 'bob'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	_, d = resolve(adminToken, d.ID, `{"decision":"reverse","allowNegativeBalance":true}`)
+	assert.Equal(t, 50, d.ReversedAmount.Value)
+	assert.Equal(t, -50, coins("bob"))
+}
+
+func TestApiSchedules(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+	defer func(saved schedulePolicy) { schedules = saved }(schedules)
+	schedules.MaxFailures = 2
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(request("POST", "/api/auth", "", `{"username":"`+username+`","password":"password"}`).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+	create := func(token, body string) (int, oas.ScheduledTransfer) {
+		recorder := request("POST", "/api/schedules", token, body)
+		var s oas.ScheduledTransfer
+		json.Unmarshal(recorder.Body.Bytes(), &s)
+		return recorder.Code, s
+	}
+	action := func(token string, id int, name string) (int, oas.ScheduledTransfer) {
+		recorder := request("POST", fmt.Sprintf("/api/schedules/%d/%s", id, name), token, "")
+		var s oas.ScheduledTransfer
+		json.Unmarshal(recorder.Body.Bytes(), &s)
+		return recorder.Code, s
+	}
+	// Переносит наступление перевода в прошлое и запускает планировщик
+	runDue := func(id int) {
+		if _, err := db.Exec("UPDATE scheduled_transfers SET next_run_at = NOW() - INTERVAL '1 second' WHERE id = $1", id); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		if _, err := runDueSchedules(context.Background()); err != nil {
+			t.Fatalf("Ошибка выполнения расписаний: %v", err)
+		}
+	}
+	get := func(token string, id int) oas.ScheduledTransfer {
+		var list oas.ScheduledTransferList
+		json.Unmarshal(request("GET", "/api/schedules", token, "").Body.Bytes(), &list)
+		for _, s := range list.Schedules {
+			if s.ID == id {
+				return s
+			}
+		}
+		t.Fatalf("Расписание %d не найдено", id)
+		return oas.ScheduledTransfer{}
+	}
+	coins := func(username string) int {
+		var coins int
+		if err := db.QueryRow("SELECT coins FROM users WHERE username = $1", username).Scan(&coins); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		return coins
+	}
+
+	aliceToken := login("alice")
+	bobToken := login("bob")
+	startAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	code, _ := create(aliceToken, `{"toUser":"alice","amount":10,"startAt":"`+startAt+`"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Перевод самому себе нельзя запланировать")
+
+	// Ежемесячный перевод выполняется и переносится на следующий месяц
+	code, monthly := create(aliceToken, `{"toUser":"bob","amount":100,"message":"Спасибо за менторство","startAt":"`+startAt+`","recurrence":"monthly"}`)
+	assert.Equal(t, http.StatusCreated, code, "Ожидался код 201")
+	assert.Equal(t, oas.ScheduleStatusActive, monthly.Status)
+	runDue(monthly.ID)
+	assert.Equal(t, 900, coins("alice"))
+	assert.Equal(t, 1100, coins("bob"))
+	monthly = get(aliceToken, monthly.ID)
+	assert.True(t, monthly.NextRunAt.Value.After(time.Now().Add(27*24*time.Hour)), "Следующий перевод через месяц")
+	assert.True(t, monthly.LastRunAt.Set)
+
+	code, _ = action(bobToken, monthly.ID, "pause")
+	assert.Equal(t, http.StatusNotFound, code, "Чужое расписание недоступно")
+
+	// Нехватка монет: уведомление, после MaxFailures неудач подряд – пауза
+	if _, err := db.Exec("UPDATE users SET coins = 50 WHERE username = 'alice'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	runDue(monthly.ID)
+	monthly = get(aliceToken, monthly.ID)
+	assert.Equal(t, oas.ScheduleStatusActive, monthly.Status)
+	assert.Equal(t, codeNotEnoughCoins, monthly.LastError.Value)
+	assert.Equal(t, 1, monthly.Failures)
+	runDue(monthly.ID)
+	monthly = get(aliceToken, monthly.ID)
+	assert.Equal(t, oas.ScheduleStatusPaused, monthly.Status, "Расписание приостанавливается после неудач подряд")
+	assert.Equal(t, 50, coins("alice"))
+
+	var notifications oas.NotificationList
+	json.Unmarshal(request("GET", "/api/notifications", aliceToken, "").Body.Bytes(), &notifications)
+	assert.Equal(t, 2, notifications.Unread)
+	if assert.Len(t, notifications.Notifications, 2) {
+		assert.Equal(t, oas.NotificationKindSchedulePaused, notifications.Notifications[0].Kind)
+		assert.Equal(t, oas.NotificationKindScheduleFailed, notifications.Notifications[1].Kind)
+		assert.Equal(t, monthly.ID, notifications.Notifications[1].ScheduleId.Value)
+	}
+	assert.Equal(t, http.StatusOK, request("POST", "/api/notifications/read", aliceToken, "").Code)
+	json.Unmarshal(request("GET", "/api/notifications", aliceToken, "").Body.Bytes(), &notifications)
+	assert.Equal(t, 0, notifications.Unread)
+
+	code, monthly = action(aliceToken, monthly.ID, "resume")
+	assert.Equal(t, http.StatusOK, code, "Ожидался код 200")
+	assert.Equal(t, oas.ScheduleStatusActive, monthly.Status)
+	assert.Equal(t, 0, monthly.Failures)
+	code, monthly = action(aliceToken, monthly.ID, "cancel")
+	assert.Equal(t, http.StatusOK, code, "Ожидался код 200")
+	assert.Equal(t, oas.ScheduleStatusCancelled, monthly.Status)
+	assert.False(t, monthly.NextRunAt.Set)
+	code, _ = action(aliceToken, monthly.ID, "resume")
+	assert.Equal(t, http.StatusConflict, code, "Отменённое расписание нельзя возобновить")
+
+	// Разовый перевод выполняется один раз
+	_, once := create(aliceToken, `{"toUser":"bob","amount":20,"startAt":"`+startAt+`"}`)
+	runDue(once.ID)
+	once = get(aliceToken, once.ID)
+	assert.Equal(t, oas.ScheduleStatusCompleted, once.Status)
+	assert.Equal(t, 30, coins("alice"))
+	runDue(once.ID)
+	assert.Equal(t, 30, coins("alice"), "Выполненный разовый перевод не повторяется")
+}
+
+func TestApiGivingBudget(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+	defer func(saved budgetPolicy) { givingBudgets = saved }(givingBudgets)
+	defer func(saved pendingPolicy) { pendingTransfers = saved }(pendingTransfers)
+	givingBudgets = budgetPolicy{Period: "month", Base: 50, PerReport: 100}
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(request("POST", "/api/auth", "", `{"username":"`+username+`","password":"password"}`).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+	info := func(token string) oas.InfoResponse {
+		var info oas.InfoResponse
+		json.Unmarshal(request("GET", "/api/info", token, "").Body.Bytes(), &info)
+		return info
+	}
+	coins := func(username string) int {
+		var coins int
+		if err := db.QueryRow("SELECT coins FROM users WHERE username = $1", username).Scan(&coins); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		return coins
+	}
+
+	managerToken := login("manager")
+	aliceToken := login("alice")
+	bobToken := login("bob")
+	if _, err := db.Exec("UPDATE users SET manager_id = (SELECT id FROM users WHERE username = 'manager') WHERE username IN ('alice', 'bob')"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	// Бюджет зависит от размера команды; у сотрудника без подчинённых его нет
+	budget := info(managerToken).GivingBudget
+	if assert.True(t, budget.Set, "Руководитель получает бюджет") {
+		assert.Equal(t, 250, budget.Value.Granted)
+		assert.Equal(t, 250, budget.Value.Remaining)
+		assert.True(t, budget.Value.ExpiresAt.After(time.Now()))
+	}
+	assert.False(t, info(aliceToken).GivingBudget.Set)
+
+	// Без useBudget перевод идёт с личного баланса
+	assert.Equal(t, http.StatusOK, request("POST", "/api/sendCoin", managerToken, `{"toUser":"alice","amount":10}`).Code)
+	assert.Equal(t, 990, coins("manager"))
+
+	// С useBudget сначала расходуется бюджет, недостающее – с баланса
+	assert.Equal(t, http.StatusOK, request("POST", "/api/sendCoin", managerToken, `{"toUser":"alice","amount":200,"useBudget":true}`).Code)
+	assert.Equal(t, 990, coins("manager"))
+	recorder := request("POST", "/api/sendCoin/batch", managerToken, `{"transfers":[{"toUser":"alice","amount":30},{"toUser":"bob","amount":40}],"useBudget":true}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var batch oas.BatchSendCoinResponse
+	json.Unmarshal(recorder.Body.Bytes(), &batch)
+	assert.Equal(t, 30, batch.Transfers[0].FromBudget.Value)
+	assert.Equal(t, 20, batch.Transfers[1].FromBudget.Value)
+	assert.Equal(t, 970, coins("manager"))
+
+	managerInfo := info(managerToken)
+	assert.Equal(t, 0, managerInfo.GivingBudget.Value.Remaining)
+	assert.Equal(t, 200, managerInfo.CoinHistory.Value.Sent[1].FromBudget.Value)
+
+	// Бюджет нельзя потратить на мерч
+	if _, err := db.Exec("UPDATE users SET coins = 0 WHERE username = 'manager'"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	if _, err := db.Exec("UPDATE giving_budgets SET remaining = 100"); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	assert.Equal(t, http.StatusBadRequest, request("GET", "/api/buy/pen", managerToken, "").Code, "Ожидался код 400")
+
+	// Отклонённый перевод возвращает монеты в бюджет, а не на баланс
+	pendingTransfers.RequireAcceptance = true
+	recorder = request("POST", "/api/sendCoin", managerToken, `{"toUser":"bob","amount":60,"useBudget":true}`)
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	var pending oas.PendingTransfer
+	json.Unmarshal(recorder.Body.Bytes(), &pending)
+	assert.Equal(t, 60, pending.FromBudget.Value)
+	assert.Equal(t, http.StatusOK, request("POST", fmt.Sprintf("/api/transfers/%d/decline", pending.ID), bobToken, "").Code)
+	assert.Equal(t, 0, coins("manager"))
+	assert.Equal(t, 100, info(managerToken).GivingBudget.Value.Remaining)
+}
+
+func TestApiCoinExpiry(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+	defer func(saved pendingPolicy) { pendingTransfers = saved }(pendingTransfers)
+
+	router := NewRouter()
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	login := func(username string) string {
+		var authResponse oas.AuthResponse
+		json.Unmarshal(request("POST", "/api/auth", "", `{"username":"`+username+`","password":"password"}`).Body.Bytes(), &authResponse)
+		return authResponse.Token.Value
+	}
+	info := func(token string) oas.InfoResponse {
+		var info oas.InfoResponse
+		json.Unmarshal(request("GET", "/api/info", token, "").Body.Bytes(), &info)
+		return info
+	}
+	coins := func(username string) int {
+		var coins int
+		if err := db.QueryRow("SELECT coins FROM users WHERE username = $1", username).Scan(&coins); err != nil {
+			t.Fatalf("Ошибка запроса к БД: %v", err)
+		}
+		return coins
+	}
+
+	aliceToken := login("alice")
+	bobToken := login("bob")
+
+	// Стартовый баланс сгорает через год
+	expiring := info(aliceToken).ExpiringCoins
+	if assert.Len(t, expiring, 1, "Стартовый баланс – одна партия") {
+		assert.Equal(t, 1000, expiring[0].Amount)
+		assert.WithinDuration(t, time.Now().Add(coinLots.TTL), expiring[0].ExpiresAt, time.Hour)
+	}
+
+	// Партия alice начислена раньше и сгорает завтра
+	_, err := db.Exec(`UPDATE coin_lots SET granted_at = NOW() - INTERVAL '2 days', expires_at = NOW() + INTERVAL '1 day'
+		WHERE user_id = (SELECT id FROM users WHERE username = 'alice')`)
+	if err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	// Переведённые монеты сохраняют срок и тратятся первыми
+	assert.Equal(t, http.StatusOK, request("POST", "/api/sendCoin", aliceToken, `{"toUser":"bob","amount":300}`).Code)
+	assert.Equal(t, http.StatusOK, request("GET", "/api/buy/cup", bobToken, "").Code)
+	expiring = info(bobToken).ExpiringCoins
+	if assert.Len(t, expiring, 2) {
+		assert.Equal(t, 280, expiring[0].Amount, "Покупка должна тратить самые старые монеты")
+		assert.Equal(t, 1000, expiring[1].Amount)
+	}
+
+	// Монеты отменённого перевода возвращаются с исходным сроком
+	pendingTransfers.RequireAcceptance = true
+	recorder := request("POST", "/api/sendCoin", aliceToken, `{"toUser":"bob","amount":100}`)
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	var pending oas.PendingTransfer
+	json.Unmarshal(recorder.Body.Bytes(), &pending)
+	assert.Equal(t, 600, info(aliceToken).ExpiringCoins[0].Amount, "Зарезервированные монеты не показываются")
+	assert.Equal(t, http.StatusOK, request("POST", fmt.Sprintf("/api/transfers/%d/cancel", pending.ID), aliceToken, "").Code)
+	assert.Equal(t, 700, info(aliceToken).ExpiringCoins[0].Amount)
+
+	// Истёкшие партии сгорают с записью в журнал и уведомлением
+	_, err = db.Exec("UPDATE coin_lots SET expires_at = NOW() - INTERVAL '1 minute' WHERE expires_at < NOW() + INTERVAL '2 days'")
+	if err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	n, err := expireCoinLots(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 980, n)
+	assert.Equal(t, 0, coins("alice"))
+	assert.Equal(t, 1000, coins("bob"))
+	assert.Empty(t, info(aliceToken).ExpiringCoins)
+
+	var ledger int
+	db.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM coin_ledger WHERE reason = 'expired'").Scan(&ledger)
+	assert.Equal(t, -980, ledger)
+
+	var notifications oas.NotificationList
+	json.Unmarshal(request("GET", "/api/notifications", aliceToken, "").Body.Bytes(), &notifications)
+	if assert.Len(t, notifications.Notifications, 1) {
+		assert.Equal(t, oas.NotificationKindCoinsExpired, notifications.Notifications[0].Kind)
+	}
+
+	// Повторный запуск ничего не сжигает
+	n, err = expireCoinLots(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+// Тест переноса баланса, начисленного до учёта партий
+func TestApiCoinLotBackfill(t *testing.T) {
+	setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t)
+
+	var userID int
+	err := db.QueryRow("INSERT INTO users (username, password, coins) VALUES ('legacy', 'password', 500) RETURNING id").Scan(&userID)
+	if err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+
+	n, err := backfillCoinLots(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 500, n, "Баланс без партий переносится в одну партию")
+
+	var amount int
+	var grantedAt, expiresAt time.Time
+	err = db.QueryRow("SELECT amount, granted_at, expires_at FROM coin_lots WHERE user_id = $1", userID).Scan(&amount, &grantedAt, &expiresAt)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 500, amount)
+		assert.WithinDuration(t, time.Now(), grantedAt, time.Minute, "Дата начисления – момент переноса")
+		assert.WithinDuration(t, grantedAt.Add(coinLots.TTL), expiresAt, time.Second)
+	}
+
+	n, err = backfillCoinLots(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, n, "Повторный перенос ничего не создаёт")
+
+	// Перенесённые монеты сгорают, как и начисленные после учёта партий
+	if _, err := db.Exec("UPDATE coin_lots SET expires_at = NOW() - INTERVAL '1 minute' WHERE user_id = $1", userID); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	n, err = expireCoinLots(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 500, n)
+
+	var coins int
+	if err := db.QueryRow("SELECT coins FROM users WHERE id = $1", userID).Scan(&coins); err != nil {
+		t.Fatalf("Ошибка запроса к БД: %v", err)
+	}
+	assert.Zero(t, coins)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Бакеты гистограммы подобраны вокруг SLI времени ответа в 50 мс
+var latencyBuckets = []float64{.005, .01, .02, .03, .04, .05, .075, .1, .25, .5, 1, 2.5}
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Время обработки HTTP-запроса по маршрутам.",
+		Buckets: latencyBuckets,
+	}, []string{"route", "method"})
+
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Количество HTTP-запросов по маршрутам и кодам ответа.",
+	}, []string{"route", "method", "code"})
+
+	tokenCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "token_cache_requests_total",
+		Help: "Обращения к кэшу проверенных токенов (result=hit|miss).",
+	}, []string{"result"})
+
+	legacyTokensTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "legacy_tokens_total",
+		Help: "Запросы с токенами старого формата без ID пользователя.",
+	})
+
+	infoCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "info_cache_requests_total",
+		Help: "Обращения к кэшу /api/info (result=hit|miss|error).",
+	}, []string{"result"})
+
+	rateLimitedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limited_requests_total",
+		Help: "Запросы, отклонённые ограничением частоты.",
+	}, []string{"route"})
+
+	coinsTransferredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "coins_transferred_total",
+		Help: "Сумма монет, переданных между пользователями.",
+	})
+
+	transfersTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "transfers_total",
+		Help: "Количество успешных переводов монет.",
+	})
+
+	purchasesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "purchases_total",
+		Help: "Количество покупок по товарам.",
+	}, []string{"item"})
+
+	signupsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "signups_total",
+		Help: "Количество зарегистрированных пользователей.",
+	})
+
+	fraudFlagsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fraud_flags_total",
+		Help: "Отметки анализатора подозрительных переводов по видам.",
+	}, []string{"kind"})
+
+	scheduledTransfersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduled_transfers_total",
+		Help: "Выполнение запланированных переводов по результату.",
+	}, []string{"result"})
+
+	coinsExpiredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "coins_expired_total",
+		Help: "Сумма сгоревших монет.",
+	})
+)
+
+// Регистрирует метрики пула соединений с базой данных
+func registerDBMetrics(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "avito_shop"))
+}
+
+// Обёртка над ResponseWriter, запоминающая код ответа
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.status = http.StatusOK
+		r.wroteHeader = true
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Middleware, снимающая метрики латентности и кодов ответа для маршрута
+func instrumentRoute(name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		httpRequestDuration.WithLabelValues(name, r.Method).Observe(time.Since(start).Seconds())
+		httpRequestsTotal.WithLabelValues(name, r.Method, strconv.Itoa(rec.status)).Inc()
+	})
+}
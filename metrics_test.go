@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// Тест учёта кодов ответа и латентности в middleware маршрута
+func TestInstrumentRoute(t *testing.T) {
+	handler := instrumentRoute("TestRoute", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respondWithError(w, http.StatusBadRequest, codeInvalidRequest, "bad request")
+	}))
+
+	before := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("TestRoute", "GET", "400"))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Ожидался код 400")
+	assert.Equal(t, before+1, testutil.ToFloat64(httpRequestsTotal.WithLabelValues("TestRoute", "GET", "400")), "Счётчик запросов должен увеличиться")
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(httpRequestDuration), 1, "Латентность запроса должна быть учтена")
+}
+
+// Тест эндпоинта /metrics
+func TestMetricsEndpoint(t *testing.T) {
+	router := NewRouter()
+
+	// Первый запрос попадает в метрики, которые отдаст второй
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+	assert.Contains(t, recorder.Body.String(), `http_requests_total{code="200",method="GET",route="Metrics"}`, "Ответ должен содержать метрики маршрутов")
+}
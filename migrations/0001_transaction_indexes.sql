@@ -0,0 +1,3 @@
+-- /api/info читает историю переводов пользователя в обе стороны
+CREATE INDEX idx_transactions_sender_id ON transactions(sender_id);
+CREATE INDEX idx_transactions_receiver_id ON transactions(receiver_id);
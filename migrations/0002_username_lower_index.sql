@@ -0,0 +1,4 @@
+-- Имена сравниваются без учёта регистра. Если в БД уже есть имена,
+-- отличающиеся только регистром, миграция не применится: такие учётные
+-- записи нужно объединить или переименовать вручную.
+CREATE UNIQUE INDEX idx_users_username_lower ON users(lower(username));
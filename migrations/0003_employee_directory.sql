@@ -0,0 +1,10 @@
+-- Справочник сотрудников: импортированные пользователи создаются без пароля
+-- и задают его по токену сброса от администратора или входят через OIDC
+ALTER TABLE users ALTER COLUMN password DROP NOT NULL;
+ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'user';
+ALTER TABLE users ADD COLUMN display_name VARCHAR(255);
+ALTER TABLE users ADD COLUMN department VARCHAR(255);
+ALTER TABLE users ADD COLUMN manager_id INT REFERENCES users(id);
+ALTER TABLE users ADD COLUMN hire_date DATE;
+ALTER TABLE users ADD COLUMN departed_at TIMESTAMP;
+CREATE INDEX idx_users_manager_id ON users(manager_id);
@@ -0,0 +1,13 @@
+-- Статус учётной записи; token_version увеличивается при отзыве токенов
+ALTER TABLE users ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('active', 'suspended', 'deactivated'));
+ALTER TABLE users ADD COLUMN token_version INT NOT NULL DEFAULT 0;
+
+CREATE TABLE offboardings (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id),
+    disposition VARCHAR(16) NOT NULL,
+    amount INT NOT NULL,
+    recipient_id INT REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,18 @@
+-- Двухфакторная аутентификация: TOTP включается после подтверждения кодом,
+-- last_step защищает от повторного использования кода
+CREATE TABLE totp_credentials (
+    user_id INT PRIMARY KEY REFERENCES users(id),
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    last_step BIGINT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Одноразовые коды восстановления; хранится только SHA-256 кода
+CREATE TABLE recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id),
+    code_hash CHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,10 @@
+-- Учётные записи OIDC-провайдеров, связанные с пользователями; такие
+-- пользователи без пароля входят только через провайдера
+CREATE TABLE user_identities (
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (issuer, subject),
+    UNIQUE (issuer, user_id)
+);
@@ -0,0 +1,18 @@
+-- Сервисные аккаунты интеграций входят только по API-ключам
+ALTER TABLE users ADD COLUMN kind VARCHAR(16) NOT NULL DEFAULT 'person'
+    CHECK (kind IN ('person', 'service'));
+
+-- API-ключи сервисных аккаунтов; хранится только SHA-256 ключа
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id),
+    name VARCHAR(64) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
@@ -0,0 +1,5 @@
+-- Сообщение и повод перевода; public – показывать в ленте благодарностей
+ALTER TABLE transactions ADD COLUMN message VARCHAR(280);
+ALTER TABLE transactions ADD COLUMN category VARCHAR(32);
+ALTER TABLE transactions ADD COLUMN public BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX idx_transactions_public ON transactions(id) WHERE public;
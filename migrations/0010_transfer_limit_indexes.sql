@@ -0,0 +1,3 @@
+-- Суммы переводов за сутки и месяц для ограничений переводов
+CREATE INDEX idx_transactions_sender_created ON transactions(sender_id, created_at);
+CREATE INDEX idx_transactions_receiver_created ON transactions(receiver_id, created_at);
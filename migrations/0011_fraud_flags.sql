@@ -0,0 +1,28 @@
+-- Время создания учётной записи. Когда созданы существующие пользователи,
+-- неизвестно: они получают '-infinity', чтобы анализатор не считал их новыми,
+-- и только после этого для новых строк задаётся NOW().
+ALTER TABLE users ADD COLUMN created_at TIMESTAMP;
+UPDATE users SET created_at = '-infinity';
+ALTER TABLE users ALTER COLUMN created_at SET DEFAULT NOW(), ALTER COLUMN created_at SET NOT NULL;
+
+-- Время импорта из справочника сотрудников; такие учётные записи заводит
+-- HR-система, и новыми для анализатора они не считаются
+ALTER TABLE users ADD COLUMN imported_at TIMESTAMP;
+
+-- Отметки анализатора подозрительных переводов. Открытая отметка одного вида
+-- на пользователя одна; повторное обнаружение обновляет last_seen_at.
+CREATE TABLE fraud_flags (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id),
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('fan_in', 'cycle', 'volume')),
+    related_user_ids INT[] NOT NULL DEFAULT '{}',
+    amount INT,
+    -- Учётная запись заблокирована анализатором до проверки
+    frozen BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    resolved_by INT REFERENCES users(id),
+    resolution VARCHAR(16) CHECK (resolution IN ('dismissed', 'confirmed'))
+);
+CREATE UNIQUE INDEX idx_fraud_flags_open ON fraud_flags(user_id, kind) WHERE resolved_at IS NULL;
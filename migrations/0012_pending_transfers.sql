@@ -0,0 +1,27 @@
+-- Переводы, ожидающие подтверждения получателем или одобрения руководителем.
+-- Монеты списаны с отправителя при создании и зачисляются получателю при
+-- подтверждении либо возвращаются отправителю.
+CREATE TABLE pending_transfers (
+    id SERIAL PRIMARY KEY,
+    sender_id INT NOT NULL REFERENCES users(id),
+    receiver_id INT NOT NULL REFERENCES users(id),
+    amount INT NOT NULL CHECK (amount > 0),
+    message VARCHAR(280),
+    category VARCHAR(32),
+    public BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(24) NOT NULL CHECK (status IN (
+        'awaiting_approval', 'awaiting_acceptance', 'accepted', 'declined', 'rejected', 'cancelled', 'expired'
+    )),
+    -- Руководитель отправителя; NULL – перевод одобряет администратор
+    approver_id INT REFERENCES users(id),
+    approved_by INT REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP,
+    transaction_id INT REFERENCES transactions(id)
+);
+CREATE INDEX idx_pending_transfers_sender_id ON pending_transfers(sender_id);
+CREATE INDEX idx_pending_transfers_receiver_id ON pending_transfers(receiver_id);
+CREATE INDEX idx_pending_transfers_approver_id ON pending_transfers(approver_id) WHERE status = 'awaiting_approval';
+CREATE INDEX idx_pending_transfers_expires_at ON pending_transfers(expires_at)
+    WHERE status IN ('awaiting_approval', 'awaiting_acceptance');
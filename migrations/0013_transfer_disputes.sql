@@ -0,0 +1,19 @@
+-- Споры по переводам. Компенсирующий перевод ссылается на исходный через
+-- reverses_id; недостача – часть суммы, которую получатель уже потратил.
+ALTER TABLE transactions ADD COLUMN reverses_id INT REFERENCES transactions(id);
+CREATE UNIQUE INDEX idx_transactions_reverses_id ON transactions(reverses_id);
+
+CREATE TABLE transfer_disputes (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL UNIQUE REFERENCES transactions(id),
+    reason VARCHAR(500) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'returned', 'reversed', 'rejected')),
+    reversal_transaction_id INT REFERENCES transactions(id),
+    reversed_amount INT,
+    shortfall INT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    resolved_by INT REFERENCES users(id),
+    note VARCHAR(500)
+);
+CREATE INDEX idx_transfer_disputes_status ON transfer_disputes(status);
@@ -0,0 +1,37 @@
+-- Запланированные переводы. occurrence – номер следующего повтора, время
+-- которого считается от start_at, чтобы ежемесячный перевод 31-го числа
+-- не сдвигался после короткого месяца.
+CREATE TABLE scheduled_transfers (
+    id SERIAL PRIMARY KEY,
+    sender_id INT NOT NULL REFERENCES users(id),
+    receiver_id INT NOT NULL REFERENCES users(id),
+    amount INT NOT NULL CHECK (amount > 0),
+    message VARCHAR(280),
+    category VARCHAR(32),
+    public BOOLEAN NOT NULL DEFAULT FALSE,
+    recurrence VARCHAR(8) NOT NULL CHECK (recurrence IN ('once', 'weekly', 'monthly')),
+    start_at TIMESTAMPTZ NOT NULL,
+    occurrence INT NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMPTZ,
+    status VARCHAR(16) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'paused', 'cancelled', 'completed', 'failed')),
+    -- Неудачных переводов подряд и код последней ошибки
+    failures INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(64),
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_scheduled_transfers_sender_id ON scheduled_transfers(sender_id);
+CREATE INDEX idx_scheduled_transfers_due ON scheduled_transfers(next_run_at) WHERE status = 'active';
+
+-- Уведомления пользователей
+CREATE TABLE notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id),
+    kind VARCHAR(32) NOT NULL,
+    message VARCHAR(500) NOT NULL,
+    schedule_id INT REFERENCES scheduled_transfers(id),
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, id);
@@ -0,0 +1,12 @@
+-- Бюджет на благодарности по периодам. Остаток прошлых периодов сгорел;
+-- budget_amount – часть перевода, взятая из бюджета.
+CREATE TABLE giving_budgets (
+    user_id INT NOT NULL REFERENCES users(id),
+    period_start TIMESTAMP NOT NULL,
+    granted INT NOT NULL,
+    remaining INT NOT NULL CHECK (remaining >= 0),
+    PRIMARY KEY (user_id, period_start)
+);
+ALTER TABLE transactions ADD COLUMN budget_amount INT NOT NULL DEFAULT 0;
+ALTER TABLE pending_transfers ADD COLUMN budget_amount INT NOT NULL DEFAULT 0;
+ALTER TABLE scheduled_transfers ADD COLUMN use_budget BOOLEAN NOT NULL DEFAULT FALSE;
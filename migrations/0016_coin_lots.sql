@@ -0,0 +1,29 @@
+-- Партии монет: баланс users.coins разложен по датам начисления, тратятся
+-- сначала самые старые партии. Перевод переносит получателю части партий
+-- с исходными датами; монеты ожидающего перевода удерживаются в партиях
+-- с pending_transfer_id. Баланс, начисленный до учёта партий, сервис при
+-- старте переносит в партии (backfillCoinLots).
+CREATE TABLE coin_lots (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id),
+    amount INT NOT NULL CHECK (amount > 0),
+    remaining INT NOT NULL CHECK (remaining >= 0),
+    granted_at TIMESTAMPTZ NOT NULL,
+    -- NULL – монеты не сгорают
+    expires_at TIMESTAMPTZ,
+    pending_transfer_id INT REFERENCES pending_transfers(id)
+);
+CREATE INDEX idx_coin_lots_user_id ON coin_lots(user_id, granted_at, id) WHERE remaining > 0;
+CREATE INDEX idx_coin_lots_expires_at ON coin_lots(expires_at) WHERE remaining > 0 AND pending_transfer_id IS NULL;
+CREATE INDEX idx_coin_lots_pending_transfer_id ON coin_lots(pending_transfer_id) WHERE pending_transfer_id IS NOT NULL;
+
+-- Журнал списаний с партий помимо переводов и покупок: сгорание монет
+CREATE TABLE coin_ledger (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id),
+    lot_id INT NOT NULL REFERENCES coin_lots(id),
+    amount INT NOT NULL,
+    reason VARCHAR(16) NOT NULL CHECK (reason IN ('expired')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_coin_ledger_user_id ON coin_ledger(user_id, id);
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations_Ordered(t *testing.T) {
+	migrations, err := loadMigrations()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		// Номера идут подряд, чтобы порядок применения не зависел от имён
+		assert.Regexp(t, fmt.Sprintf(`^%04d_[a-z0-9_]+$`, i+1), m.Version)
+		assert.NotEmpty(t, m.SQL, "Пустая миграция %s", m.Version)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Сохраняет уведомление пользователя; scheduleID 0 – без расписания
+func notify(ctx context.Context, userID int, kind oas.NotificationKind, message string, scheduleID int) error {
+	_, err := db.ExecContext(ctx, `
+        INSERT INTO notifications (user_id, kind, message, schedule_id)
+        VALUES ($1, $2, $3, NULLIF($4, 0))
+    `, userID, string(kind), message, scheduleID)
+	return err
+}
+
+func (apiHandler) APINotificationsGet(ctx context.Context, params oas.APINotificationsGetParams) (oas.APINotificationsGetRes, error) {
+	userID := claimsFromContext(ctx).UserID
+
+	rows, err := db.QueryContext(ctx, `
+        SELECT id, kind, message, schedule_id, read_at IS NOT NULL, created_at
+        FROM notifications
+        WHERE user_id = $1
+        ORDER BY id DESC
+        LIMIT $2
+    `, userID, params.Limit.Or(20))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	defer rows.Close()
+
+	resp := &oas.NotificationList{Notifications: []oas.Notification{}}
+	for rows.Next() {
+		var n oas.Notification
+		var kind string
+		var scheduleID sql.NullInt64
+		if err := rows.Scan(&n.ID, &kind, &n.Message, &scheduleID, &n.Read, &n.CreatedAt); err != nil {
+			return nil, internalError("Database error", err)
+		}
+		n.Kind = oas.NotificationKind(kind)
+		if scheduleID.Valid {
+			n.ScheduleId = oas.NewOptInt(int(scheduleID.Int64))
+		}
+		resp.Notifications = append(resp.Notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, internalError("Database error", err)
+	}
+
+	err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL", userID).Scan(&resp.Unread)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	return resp, nil
+}
+
+func (apiHandler) APINotificationsReadPost(ctx context.Context) (oas.APINotificationsReadPostRes, error) {
+	userID := claimsFromContext(ctx).UserID
+	if _, err := db.ExecContext(ctx, "UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL", userID); err != nil {
+		return nil, internalError("Failed to update notifications", err)
+	}
+	return &oas.MessageResponse{Message: oas.NewOptString("Notifications marked as read")}, nil
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Параметры входа через корпоративного OpenID Connect провайдера
+type oidcConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// Адрес клиента, который получает code и state и передаёт их в /api/auth/oidc/callback
+	RedirectURL string
+	Scopes      []string
+	// Claim ID-токена, из которого берётся имя пользователя
+	UsernameClaim string
+	// Шаблон с одной группой, выделяющей имя из значения claim;
+	// nil – значение используется целиком
+	UsernamePattern *regexp.Regexp
+	// Создавать пользователя при первом входе
+	AutoProvision bool
+	// Связывать первый вход с существующей учётной записью с тем же именем
+	LinkExisting bool
+	// Время на прохождение входа у провайдера
+	StateTTL time.Duration
+}
+
+// Получатель state, чтобы подписанный тем же ключом state нельзя было
+// использовать как токен API и наоборот
+const oidcStateAudience = "avito-shop-oidc-state"
+
+// Пользователь провайдера, подтверждённый ID-токеном
+type oidcIdentity struct {
+	Issuer   string
+	Subject  string
+	Username string
+}
+
+type oidcClient struct {
+	config   oidcConfig
+	oauth2   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// Клиент провайдера; nil – вход через OIDC не настроен
+var sso *oidcClient
+
+// Читает OIDC_ISSUER, OIDC_CLIENT_ID, OIDC_CLIENT_SECRET, OIDC_REDIRECT_URL,
+// OIDC_SCOPES, OIDC_USERNAME_CLAIM, OIDC_USERNAME_PATTERN, OIDC_AUTO_PROVISION,
+// OIDC_LINK_EXISTING и OIDC_STATE_TTL. Без OIDC_ISSUER вход через OIDC выключен.
+func initOIDC(ctx context.Context) error {
+	var env envReader
+	config := oidcConfig{
+		Issuer:        envString("OIDC_ISSUER", ""),
+		ClientID:      envString("OIDC_CLIENT_ID", ""),
+		ClientSecret:  envString("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:   envString("OIDC_REDIRECT_URL", ""),
+		UsernameClaim: envString("OIDC_USERNAME_CLAIM", "preferred_username"),
+		AutoProvision: envString("OIDC_AUTO_PROVISION", "true") == "true",
+		LinkExisting:  envString("OIDC_LINK_EXISTING", "true") == "true",
+		StateTTL:      env.Duration("OIDC_STATE_TTL", 10*time.Minute),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if config.Issuer == "" {
+		return nil
+	}
+	if config.ClientID == "" || config.RedirectURL == "" {
+		return fmt.Errorf("OIDC_ISSUER requires OIDC_CLIENT_ID and OIDC_REDIRECT_URL")
+	}
+	for _, scope := range strings.Split(envString("OIDC_SCOPES", "openid,profile,email"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			config.Scopes = append(config.Scopes, scope)
+		}
+	}
+	if expr := envString("OIDC_USERNAME_PATTERN", ""); expr != "" {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid OIDC username pattern %q: %w", expr, err)
+		}
+		if re.NumSubexp() != 1 {
+			return fmt.Errorf("OIDC username pattern %q must have exactly one group", expr)
+		}
+		config.UsernamePattern = re
+	}
+
+	client, err := newOIDCClient(ctx, config)
+	if err != nil {
+		return err
+	}
+	sso = client
+	return nil
+}
+
+// Загружает метаданные провайдера и ключи подписи
+func newOIDCClient(ctx context.Context, config oidcConfig) (*oidcClient, error) {
+	provider, err := oidc.NewProvider(ctx, config.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider: %w", err)
+	}
+	// Без openid провайдер не выдаёт ID-токен
+	scopes := config.Scopes
+	if !slices.Contains(scopes, oidc.ScopeOpenID) {
+		scopes = append([]string{oidc.ScopeOpenID}, scopes...)
+	}
+	return &oidcClient{
+		config: config,
+		oauth2: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: config.ClientID}),
+	}, nil
+}
+
+// Данные state. State подписан ключом сервиса, поэтому его не нужно хранить
+// между запросами; nonce связывает ID-токен с этим state.
+type oidcState struct {
+	Nonce string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// Возвращает адрес авторизации у провайдера и state, который клиент должен
+// сверить с возвращённым провайдером
+func (c *oidcClient) authorizationURL() (url, state string, err error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	state, err = jwt.NewWithClaims(jwt.SigningMethodHS256, &oidcState{
+		Nonce: nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Audience:  jwt.ClaimStrings{oidcStateAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(c.config.StateTTL)),
+		},
+	}).SignedString(jwtKey)
+	if err != nil {
+		return "", "", err
+	}
+	return c.oauth2.AuthCodeURL(state, oidc.Nonce(nonce)), state, nil
+}
+
+// Проверяет state и возвращает nonce
+func parseOIDCState(state string) (string, error) {
+	claims := &oidcState{}
+	token, err := jwt.ParseWithClaims(state, claims, func(token *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired(),
+		jwt.WithIssuer(tokenIssuer), jwt.WithAudience(oidcStateAudience))
+	if err != nil || !token.Valid || claims.Nonce == "" {
+		return "", fmt.Errorf("invalid state")
+	}
+	return claims.Nonce, nil
+}
+
+// Обменивает код авторизации на ID-токен и определяет по нему пользователя
+func (c *oidcClient) exchange(ctx context.Context, code, state string) (*oidcIdentity, error) {
+	nonce, err := parseOIDCState(state)
+	if err != nil {
+		return nil, badRequest(codeInvalidOIDCState, "Invalid or expired state")
+	}
+
+	token, err := c.oauth2.Exchange(ctx, code)
+	if err != nil {
+		return nil, oidcFailed(ctx, "Authorization code was rejected by the provider", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, unauthorized(codeOIDCFailed, "Provider did not return an ID token")
+	}
+	idToken, err := c.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, oidcFailed(ctx, "Invalid ID token", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, unauthorized(codeOIDCFailed, "ID token does not match the login request")
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, oidcFailed(ctx, "Invalid ID token", err)
+	}
+	username, err := c.config.username(claims)
+	if err != nil {
+		requestLogger(ctx).Warn("Provider identity cannot be mapped to a username", zap.Error(err))
+		return nil, forbidden(codeRegistrationDenied, "Provider identity cannot be mapped to a username")
+	}
+	return &oidcIdentity{Issuer: idToken.Issuer, Subject: idToken.Subject, Username: username}, nil
+}
+
+// Причина отказа попадает только в лог, клиенту сообщается общий код
+func oidcFailed(ctx context.Context, message string, err error) *apiError {
+	requestLogger(ctx).Warn(message, zap.Error(err))
+	return unauthorized(codeOIDCFailed, message)
+}
+
+// Имена, допустимые в /api/auth; совпадает с шаблоном AuthRequest.username
+var usernameFormat = regexp.MustCompile(`^[a-z0-9_.-]{1,64}$`)
+
+// Определяет имя пользователя по claims ID-токена
+func (c oidcConfig) username(claims map[string]any) (string, error) {
+	value, _ := claims[c.UsernameClaim].(string)
+	if value == "" {
+		return "", fmt.Errorf("claim %q is missing", c.UsernameClaim)
+	}
+	// Адрес почты годится как имя, только если провайдер его подтвердил
+	if c.UsernameClaim == "email" {
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return "", fmt.Errorf("email %q is not verified", value)
+		}
+	}
+	if c.UsernamePattern != nil {
+		match := c.UsernamePattern.FindStringSubmatch(value)
+		if match == nil {
+			return "", fmt.Errorf("claim %q value %q does not match the username pattern", c.UsernameClaim, value)
+		}
+		value = match[1]
+	}
+	username := normalizeUsername(value)
+	if !usernameFormat.MatchString(username) {
+		return "", fmt.Errorf("invalid username %q", username)
+	}
+	return username, nil
+}
+
+// Находит пользователя, связанного с учётной записью провайдера. При первом
+// входе связывает её с существующим пользователем или создаёт нового.
+func linkIdentity(ctx context.Context, identity *oidcIdentity, config oidcConfig) (int, string, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, "", internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	var userID int
+	var username string
+	err = tx.QueryRowContext(ctx, `
+        SELECT u.id, lower(u.username) FROM user_identities i JOIN users u ON u.id = i.user_id
+        WHERE i.issuer = $1 AND i.subject = $2
+    `, identity.Issuer, identity.Subject).Scan(&userID, &username)
+	if err == nil {
+		return userID, username, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, "", internalError("Database error", err)
+	}
+
+	username = identity.Username
+	created := false
+	err = tx.QueryRowContext(ctx, "SELECT id FROM users WHERE lower(username) = $1 AND departed_at IS NULL AND kind = 'person' FOR UPDATE", username).Scan(&userID)
+	switch {
+	case err == nil:
+		if !config.LinkExisting {
+			return 0, "", conflict(codeUsernameTaken, "Username already taken")
+		}
+	case err != sql.ErrNoRows:
+		return 0, "", internalError("Database error", err)
+	case !config.AutoProvision || registration.DirectoryOnly || !registration.allowed(username):
+		return 0, "", forbidden(codeRegistrationDenied, "Registration is not allowed for this username")
+	default:
+		// Пароль не задаётся: такой пользователь входит только через провайдера
+		var ids []int
+		ids, err = insertAccounts(ctx, tx, []newAccount{{Username: username, Coins: registration.StartingBalance}})
+		if err != nil {
+			return 0, "", internalError("Failed to create user", err)
+		}
+		if userID = ids[0]; userID == 0 {
+			// Имя занял параллельный запрос или уволенный сотрудник
+			return 0, "", conflict(codeUsernameTaken, "Username already taken")
+		}
+		created = true
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO user_identities (issuer, subject, user_id) VALUES ($1, $2, $3)",
+		identity.Issuer, identity.Subject, userID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, "", conflict(codeIdentityConflict, "Account is already linked to another identity")
+		}
+		return 0, "", internalError("Failed to link identity", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, "", internalError("Failed to commit transaction", err)
+	}
+	if created {
+		signupsTotal.Inc()
+	}
+	return userID, username, nil
+}
+
+func (apiHandler) APIAuthOidcGet(ctx context.Context) (oas.APIAuthOidcGetRes, error) {
+	if sso == nil {
+		return nil, notFound(codeOIDCDisabled, "OIDC login is not configured")
+	}
+	url, state, err := sso.authorizationURL()
+	if err != nil {
+		return nil, internalError("Failed to create state", err)
+	}
+	return &oas.OidcLoginResponse{AuthorizationUrl: url, State: state}, nil
+}
+
+func (apiHandler) APIAuthOidcCallbackPost(ctx context.Context, req *oas.OidcCallbackRequest) (oas.APIAuthOidcCallbackPostRes, error) {
+	if sso == nil {
+		return nil, notFound(codeOIDCDisabled, "OIDC login is not configured")
+	}
+	identity, err := sso.exchange(ctx, req.Code, req.State)
+	if err != nil {
+		return nil, err
+	}
+	setRequestUser(ctx, identity.Username)
+
+	userID, username, err := linkIdentity(ctx, identity, sso.config)
+	if err != nil {
+		return nil, err
+	}
+	setRequestUserID(ctx, userID)
+
+	forgetUserState(userID)
+	state, ok, err := currentUserState(ctx, userID)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if !ok || state.Status != statusActive {
+		return nil, forbidden(codeAccountInactive, "Account is "+state.Status)
+	}
+	return completeLogin(ctx, username, userID)
+}
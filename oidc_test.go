@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+const testOIDCClientID = "avito-shop"
+
+// Локальный OIDC-провайдер: отдаёт метаданные, ключи и выдаёт ID-токены
+// по заранее выданным кодам авторизации
+type mockOIDCProvider struct {
+	*httptest.Server
+	key   *rsa.PrivateKey
+	mu    sync.Mutex
+	codes map[string]jwt.MapClaims
+}
+
+func newMockOIDCProvider(t testing.TB) *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Не удалось создать ключ: %v", err)
+	}
+	p := &mockOIDCProvider{key: key, codes: make(map[string]jwt.MapClaims)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		respondWithJSON(w, http.StatusOK, map[string]any{
+			"issuer":                                p.URL,
+			"authorization_endpoint":                p.URL + "/authorize",
+			"token_endpoint":                        p.URL + "/token",
+			"jwks_uri":                              p.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		respondWithJSON(w, http.StatusOK, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		p.mu.Lock()
+		claims, ok := p.codes[r.PostForm.Get("code")]
+		delete(p.codes, r.PostForm.Get("code"))
+		p.mu.Unlock()
+		if !ok {
+			respondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		respondWithJSON(w, http.StatusOK, map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   300,
+			"id_token":     idToken,
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// Выдаёт одноразовый код, в обмен на который провайдер вернёт ID-токен
+// с указанными claims
+func (p *mockOIDCProvider) issueCode(code string, claims jwt.MapClaims) {
+	now := time.Now()
+	token := jwt.MapClaims{
+		"iss": p.URL,
+		"aud": testOIDCClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Minute).Unix(),
+	}
+	for k, v := range claims {
+		token[k] = v
+	}
+	p.mu.Lock()
+	p.codes[code] = token
+	p.mu.Unlock()
+}
+
+func (p *mockOIDCProvider) config() oidcConfig {
+	return oidcConfig{
+		Issuer:        p.URL,
+		ClientID:      testOIDCClientID,
+		ClientSecret:  "secret",
+		RedirectURL:   "http://localhost/callback",
+		Scopes:        []string{"profile", "email"},
+		UsernameClaim: "preferred_username",
+		AutoProvision: true,
+		LinkExisting:  true,
+		StateTTL:      time.Minute,
+	}
+}
+
+// Начинает вход и возвращает state и nonce из адреса авторизации
+func startOIDCLogin(t testing.TB, client *oidcClient) (state, nonce string) {
+	authURL, state, err := client.authorizationURL()
+	if err != nil {
+		t.Fatalf("Не удалось начать вход: %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("Неверный адрес авторизации: %v", err)
+	}
+	assert.Equal(t, state, parsed.Query().Get("state"))
+	assert.Equal(t, testOIDCClientID, parsed.Query().Get("client_id"))
+	return state, parsed.Query().Get("nonce")
+}
+
+func TestOIDCExchange(t *testing.T) {
+	provider := newMockOIDCProvider(t)
+	client, err := newOIDCClient(context.Background(), provider.config())
+	if err != nil {
+		t.Fatalf("Не удалось подключиться к провайдеру: %v", err)
+	}
+
+	state, nonce := startOIDCLogin(t, client)
+	provider.issueCode("good", jwt.MapClaims{"sub": "u-1", "nonce": nonce, "preferred_username": " Alice "})
+	identity, err := client.exchange(context.Background(), "good", state)
+	assert.NoError(t, err)
+	assert.Equal(t, &oidcIdentity{Issuer: provider.URL, Subject: "u-1", Username: "alice"}, identity)
+
+	_, err = client.exchange(context.Background(), "good", state)
+	assert.Equal(t, codeOIDCFailed, err.(*apiError).code, "Код авторизации одноразовый")
+
+	provider.issueCode("replayed", jwt.MapClaims{"sub": "u-1", "nonce": "other", "preferred_username": "alice"})
+	_, err = client.exchange(context.Background(), "replayed", state)
+	assert.Equal(t, codeOIDCFailed, err.(*apiError).code, "ID-токен другого входа не должен приниматься")
+
+	provider.issueCode("foreign", jwt.MapClaims{"sub": "u-1", "nonce": nonce, "aud": "other-client", "preferred_username": "alice"})
+	_, err = client.exchange(context.Background(), "foreign", state)
+	assert.Equal(t, codeOIDCFailed, err.(*apiError).code, "ID-токен другого клиента не должен приниматься")
+
+	_, err = client.exchange(context.Background(), "good", signTestToken(t, 1))
+	assert.Equal(t, codeInvalidOIDCState, err.(*apiError).code, "Токен API не является state")
+}
+
+func TestOIDCUsernameMapping(t *testing.T) {
+	config := oidcConfig{UsernameClaim: "email", UsernamePattern: regexp.MustCompile(`^([^@]+)@corp\.example$`)}
+
+	username, err := config.username(map[string]any{"email": "Bob.Smith@corp.example", "email_verified": true})
+	assert.NoError(t, err)
+	assert.Equal(t, "bob.smith", username)
+
+	_, err = config.username(map[string]any{"email": "bob@corp.example", "email_verified": false})
+	assert.Error(t, err, "Неподтверждённый адрес не должен приниматься")
+	_, err = config.username(map[string]any{"email": "bob@other.example"})
+	assert.Error(t, err, "Адрес вне шаблона не должен приниматься")
+	_, err = config.username(map[string]any{"sub": "bob"})
+	assert.Error(t, err, "Без claim имя не определяется")
+
+	config = oidcConfig{UsernameClaim: "preferred_username"}
+	_, err = config.username(map[string]any{"preferred_username": "bob smith"})
+	assert.Error(t, err, "Имя должно соответствовать формату имён сервиса")
+}
+
+func TestAPIAuthOidc_Disabled(t *testing.T) {
+	code, errResponse := performRawRequest(t, "GET", "/api/auth/oidc", "", "")
+	assert.Equal(t, http.StatusNotFound, code, "Ожидался код 404")
+	assert.Equal(t, codeOIDCDisabled, errResponse.Code.Value)
+}
+
+func TestAPIAuthOidcGet(t *testing.T) {
+	provider := newMockOIDCProvider(t)
+	client, err := newOIDCClient(context.Background(), provider.config())
+	if err != nil {
+		t.Fatalf("Не удалось подключиться к провайдеру: %v", err)
+	}
+	defer func(saved *oidcClient) { sso = saved }(sso)
+	sso = client
+
+	req := httptest.NewRequest("GET", "/api/auth/oidc", nil)
+	recorder := httptest.NewRecorder()
+	NewRouter().ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Ожидался код 200")
+
+	var response oas.OidcLoginResponse
+	json.Unmarshal(recorder.Body.Bytes(), &response)
+	parsed, err := url.Parse(response.AuthorizationUrl)
+	assert.NoError(t, err)
+	assert.Equal(t, provider.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "openid profile email", parsed.Query().Get("scope"))
+	_, err = parseOIDCState(response.State)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Срок действия токена сброса пароля
+var passwordResetTTL = 24 * time.Hour
+
+// В БД хранится только хэш токенов сброса, кодов восстановления и API-ключей,
+// поэтому утечка таблиц не позволяет ими воспользоваться
+func hashSecret(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Выдаёт новый токен, поскольку смена пароля отзывает все предыдущие.
+// Признак входа со вторым фактором сохраняется.
+func tokenAfterPasswordChange(ctx context.Context, userID int, secondFactor bool) (*oas.AuthResponse, error) {
+	forgetUserState(userID)
+	tokenString, err := signToken(ctx, userID, tokenAudience, tokenTTL, secondFactor)
+	if err != nil {
+		return nil, internalError("Failed to create token", err)
+	}
+	return &oas.AuthResponse{Token: oas.NewOptString(tokenString)}, nil
+}
+
+func (apiHandler) APIPasswordPost(ctx context.Context, req *oas.ChangePasswordRequest) (oas.APIPasswordPostRes, error) {
+	claims := claimsFromContext(ctx)
+
+	var username string
+	err := db.QueryRowContext(ctx, "SELECT lower(username) FROM users WHERE id = $1", claims.UserID).Scan(&username)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	// Подбор текущего пароля ограничивается так же, как подбор при входе
+	if err := checkLoginLockout(ctx, username); err != nil {
+		return nil, err
+	}
+
+	// Пароль сравнивается и меняется одним запросом, чтобы параллельная смена
+	// не прошла со старым паролем
+	result, err := db.ExecContext(ctx, `
+        UPDATE users SET password = $3, token_version = token_version + 1
+        WHERE id = $1 AND password = $2
+    `, claims.UserID, req.OldPassword, req.NewPassword)
+	if err != nil {
+		return nil, internalError("Failed to update password", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		if err := recordLoginFailure(ctx, username); err != nil {
+			return nil, err
+		}
+		return nil, forbidden(codeInvalidCredentials, "Invalid password")
+	}
+	resetLoginFailures(ctx, username)
+
+	return tokenAfterPasswordChange(ctx, claims.UserID, claims.MFA)
+}
+
+func (apiHandler) APIAdminUsersUsernamePasswordResetPost(ctx context.Context, params oas.APIAdminUsersUsernamePasswordResetPostParams) (oas.APIAdminUsersUsernamePasswordResetPostRes, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	var userID int
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT id, status FROM users WHERE lower(username) = $1", normalizeUsername(params.Username)).Scan(&userID, &status)
+	if err == sql.ErrNoRows {
+		return nil, notFound(codeUserNotFound, "User not found")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if status == statusDeactivated {
+		return nil, badRequest(codeAccountInactive, "Account is deactivated")
+	}
+
+	token, err := newResetToken()
+	if err != nil {
+		return nil, internalError("Failed to create reset token", err)
+	}
+	expiresAt := time.Now().Add(passwordResetTTL).UTC().Truncate(time.Second)
+
+	// Действует только последний выданный токен
+	if _, err = tx.ExecContext(ctx, "DELETE FROM password_resets WHERE user_id = $1 AND used_at IS NULL", userID); err != nil {
+		return nil, internalError("Failed to revoke reset tokens", err)
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO password_resets (user_id, token_hash, expires_at) VALUES ($1, $2, $3)",
+		userID, hashSecret(token), expiresAt)
+	if err != nil {
+		return nil, internalError("Failed to store reset token", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+
+	return &oas.PasswordResetTokenResponse{ResetToken: token, ExpiresAt: expiresAt}, nil
+}
+
+func (apiHandler) APIPasswordResetPost(ctx context.Context, req *oas.ResetPasswordRequest) (oas.APIPasswordResetPostRes, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	var userID int
+	err = tx.QueryRowContext(ctx, `
+        UPDATE password_resets SET used_at = NOW()
+        WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+        RETURNING user_id
+    `, hashSecret(req.ResetToken)).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return nil, badRequest(codeInvalidResetToken, "Invalid or expired reset token")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+
+	var username string
+	err = tx.QueryRowContext(ctx, `
+        UPDATE users SET password = $2, token_version = token_version + 1
+        WHERE id = $1 AND status = 'active'
+        RETURNING lower(username)
+    `, userID, req.NewPassword).Scan(&username)
+	if err == sql.ErrNoRows {
+		return nil, badRequest(codeAccountInactive, "Account is not active")
+	}
+	if err != nil {
+		return nil, internalError("Failed to update password", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+	setRequestUser(ctx, username)
+	setRequestUserID(ctx, userID)
+
+	// Токен сброса заменяет только пароль: при подключённом TOTP нужен второй шаг.
+	// Блокировка входа после неудачных попыток снимается вместе с ним.
+	forgetUserState(userID)
+	return completeLogin(ctx, username, userID)
+}
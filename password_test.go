@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+func TestNewResetToken(t *testing.T) {
+	first, err := newResetToken()
+	assert.NoError(t, err)
+	second, err := newResetToken()
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	assert.Len(t, first, 43, "32 байта в base64url без дополнения")
+	assert.Len(t, hashSecret(first), 64)
+	assert.NotEqual(t, first, hashSecret(first), "В БД не должен храниться сам токен")
+}
+
+func TestPasswordChange_Validation(t *testing.T) {
+	token := signTestToken(t, 1)
+
+	code, errResponse := performRawRequest(t, "POST", "/api/password", token, `{"newPassword":"secret"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+	assert.Equal(t, []oas.ErrorDetail{{Field: "oldPassword", Code: fieldRequired, Message: "field is required"}}, errResponse.Details)
+
+	code, errResponse = performRawRequest(t, "POST", "/api/password/reset", "",
+		`{"resetToken":"token","newPassword":"`+strings.Repeat("a", 129)+`"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+	assert.Equal(t, fieldTooLong, errResponse.Details[0].Code)
+}
@@ -0,0 +1,505 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Состояния ожидающего перевода, в которых монеты зарезервированы
+const openTransferStatuses = `('awaiting_approval', 'awaiting_acceptance')`
+
+// Сколько истёкших переводов возвращается за одну транзакцию
+const expireBatchSize = 100
+
+// Правила двухфазных переводов
+type pendingPolicy struct {
+	// Получатель должен подтвердить перевод
+	RequireAcceptance bool
+	// Переводы от этой суммы одобряет руководитель отправителя; 0 – не требуется
+	ApprovalThreshold int
+	// Через сколько неподтверждённый перевод возвращается отправителю
+	TTL time.Duration
+	// Период проверки истёкших переводов
+	SweepInterval time.Duration
+}
+
+var pendingTransfers = pendingPolicy{TTL: 72 * time.Hour, SweepInterval: time.Minute}
+
+// Читает TRANSFER_REQUIRE_ACCEPTANCE, TRANSFER_APPROVAL_THRESHOLD,
+// PENDING_TRANSFER_TTL и PENDING_TRANSFER_SWEEP_INTERVAL
+func initPendingTransfers() error {
+	var env envReader
+	policy := pendingPolicy{
+		RequireAcceptance: envString("TRANSFER_REQUIRE_ACCEPTANCE", "false") == "true",
+		ApprovalThreshold: env.Int("TRANSFER_APPROVAL_THRESHOLD", 0),
+		TTL:               env.Duration("PENDING_TRANSFER_TTL", pendingTransfers.TTL),
+		SweepInterval:     env.Duration("PENDING_TRANSFER_SWEEP_INTERVAL", pendingTransfers.SweepInterval),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if policy.ApprovalThreshold < 0 || policy.TTL <= 0 || policy.SweepInterval <= 0 {
+		return fmt.Errorf("invalid pending transfer settings")
+	}
+	pendingTransfers = policy
+	return nil
+}
+
+// Первое состояние перевода на эту сумму
+func (p pendingPolicy) stage(amount int) oas.TransferStatus {
+	switch {
+	case p.ApprovalThreshold > 0 && amount >= p.ApprovalThreshold:
+		return oas.TransferStatusAwaitingApproval
+	case p.RequireAcceptance:
+		return oas.TransferStatusAwaitingAcceptance
+	}
+	return oas.TransferStatusCompleted
+}
+
+// Откладывает перевод, монеты которого уже списаны с отправителя. Одобряет
+// перевод руководитель отправителя, а если его нет или перевод адресован
+// ему самому – администратор.
+func createPendingTransfer(ctx context.Context, tx *sql.Tx, senderID int, leg transferLeg, note transferNote) (int, error) {
+	var id int
+	err := tx.QueryRowContext(ctx, `
+        INSERT INTO pending_transfers (sender_id, receiver_id, amount, budget_amount, message, category, public, status, approver_id, expires_at)
+        SELECT $1, $2, $3, $9, $4, $5, $6, $7,
+            CASE WHEN $7 = 'awaiting_approval' AND manager_id <> $2 THEN manager_id END,
+            NOW() + make_interval(secs => $8)
+        FROM users WHERE id = $1
+        RETURNING id
+    `, senderID, leg.ReceiverID, leg.Amount, note.Message, note.Category, note.Public, string(leg.Status), pendingTransfers.TTL.Seconds(), leg.Budget).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, insertLots(ctx, tx, senderID, id, leg.Lots)
+}
+
+// Ожидающий перевод, заблокированный в транзакции
+type pendingTransfer struct {
+	ID         int
+	SenderID   int
+	ReceiverID int
+	Amount     int
+	Status     oas.TransferStatus
+	ApproverID sql.NullInt64
+	Note       transferNote
+	// Часть суммы из бюджета на благодарности и время списания
+	Budget    int
+	CreatedAt time.Time
+}
+
+func lockPendingTransfer(ctx context.Context, tx *sql.Tx, id int) (*pendingTransfer, error) {
+	t := &pendingTransfer{ID: id}
+	var status string
+	err := tx.QueryRowContext(ctx, `
+        SELECT sender_id, receiver_id, amount, budget_amount, created_at, status, approver_id, message, category, public
+        FROM pending_transfers WHERE id = $1
+        FOR UPDATE
+    `, id).Scan(&t.SenderID, &t.ReceiverID, &t.Amount, &t.Budget, &t.CreatedAt, &status, &t.ApproverID, &t.Note.Message, &t.Note.Category, &t.Note.Public)
+	if err == sql.ErrNoRows {
+		return nil, notFound(codeTransferNotFound, "Transfer not found")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	t.Status = oas.TransferStatus(status)
+	return t, nil
+}
+
+// Забирает удерживаемые личные монеты перевода. Для переводов, отложенных
+// до учёта партий, они становятся новым начислением.
+func (t *pendingTransfer) heldLots(ctx context.Context, tx *sql.Tx) ([]lotPortion, error) {
+	portions, err := releaseLots(ctx, tx, t.ID)
+	if err != nil {
+		return nil, internalError("Failed to release coins", err)
+	}
+	return append(portions, freshLot(t.Amount-t.Budget-sumPortions(portions))...), nil
+}
+
+// Зачисляет монеты получателю и записывает перевод в историю; монеты
+// из бюджета становятся у получателя новым начислением. Если получателя
+// уволили, пока перевод ждал, монеты возвращаются отправителю.
+func (t *pendingTransfer) complete(ctx context.Context, tx *sql.Tx) error {
+	accounts, err := lockAccounts(ctx, tx, t.SenderID, t.ReceiverID)
+	if err != nil {
+		return internalError("Failed to lock accounts", err)
+	}
+	if receiver, ok := accounts[t.ReceiverID]; !ok || receiver.Status == statusDeactivated {
+		return t.refund(ctx, tx, oas.TransferStatusCancelled)
+	}
+
+	portions, err := t.heldLots(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if err := credit(ctx, tx, t.ReceiverID, append(portions, freshLot(t.Budget)...)); err != nil {
+		return err
+	}
+	transactionID, err := recordTransfer(ctx, tx, t.SenderID, t.ReceiverID, t.Amount, t.Budget, t.Note)
+	if err != nil {
+		return internalError("Failed to record transaction", err)
+	}
+	return t.finish(ctx, tx, oas.TransferStatusAccepted, transactionID)
+}
+
+// Возвращает зарезервированные монеты отправителю: личные – на баланс,
+// из бюджета на благодарности – в бюджет
+func (t *pendingTransfer) refund(ctx context.Context, tx *sql.Tx, status oas.TransferStatus) error {
+	portions, err := t.heldLots(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if err := credit(ctx, tx, t.SenderID, portions); err != nil {
+		return err
+	}
+	if err := givingBudgets.restore(ctx, tx, t.SenderID, t.Budget, t.CreatedAt); err != nil {
+		return internalError("Failed to update giving budget", err)
+	}
+	return t.finish(ctx, tx, status, 0)
+}
+
+func (t *pendingTransfer) finish(ctx context.Context, tx *sql.Tx, status oas.TransferStatus, transactionID int) error {
+	_, err := tx.ExecContext(ctx, `
+        UPDATE pending_transfers SET status = $2, resolved_at = NOW(), transaction_id = NULLIF($3, 0)
+        WHERE id = $1
+    `, t.ID, string(status), transactionID)
+	if err != nil {
+		return internalError("Failed to update transfer", err)
+	}
+	t.Status = status
+	return nil
+}
+
+// Переход ожидающего перевода по действию пользователя
+type transferAction func(ctx context.Context, tx *sql.Tx, t *pendingTransfer, userID int) error
+
+func acceptTransfer(ctx context.Context, tx *sql.Tx, t *pendingTransfer, userID int) error {
+	if userID != t.ReceiverID {
+		return notFound(codeTransferNotFound, "Transfer not found")
+	}
+	if t.Status != oas.TransferStatusAwaitingAcceptance {
+		return conflict(codeTransferNotPending, "Transfer is not awaiting acceptance")
+	}
+	return t.complete(ctx, tx)
+}
+
+func declineTransfer(ctx context.Context, tx *sql.Tx, t *pendingTransfer, userID int) error {
+	if userID != t.ReceiverID {
+		return notFound(codeTransferNotFound, "Transfer not found")
+	}
+	if t.Status != oas.TransferStatusAwaitingAcceptance {
+		return conflict(codeTransferNotPending, "Transfer is not awaiting acceptance")
+	}
+	return t.refund(ctx, tx, oas.TransferStatusDeclined)
+}
+
+func cancelTransfer(ctx context.Context, tx *sql.Tx, t *pendingTransfer, userID int) error {
+	if userID != t.SenderID {
+		return notFound(codeTransferNotFound, "Transfer not found")
+	}
+	if t.Status != oas.TransferStatusAwaitingApproval && t.Status != oas.TransferStatusAwaitingAcceptance {
+		return conflict(codeTransferNotPending, "Transfer is no longer pending")
+	}
+	return t.refund(ctx, tx, oas.TransferStatusCancelled)
+}
+
+// Одобрить или отклонить перевод может руководитель отправителя или
+// администратор, но не участники перевода
+func checkApprover(ctx context.Context, t *pendingTransfer, userID int) error {
+	if userID == t.SenderID || userID == t.ReceiverID {
+		return forbidden(codeTransferForbidden, "Cannot approve your own transfer")
+	}
+	if !t.ApproverID.Valid || int(t.ApproverID.Int64) != userID {
+		if err := requireAdmin(ctx); err != nil {
+			var apiErr *apiError
+			if errors.As(err, &apiErr) && apiErr.code == codeAdminRequired {
+				return notFound(codeTransferNotFound, "Transfer not found")
+			}
+			return err
+		}
+	}
+	if t.Status != oas.TransferStatusAwaitingApproval {
+		return conflict(codeTransferNotPending, "Transfer is not awaiting approval")
+	}
+	return nil
+}
+
+func approveTransfer(ctx context.Context, tx *sql.Tx, t *pendingTransfer, userID int) error {
+	if err := checkApprover(ctx, t, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE pending_transfers SET approved_by = $2 WHERE id = $1", t.ID, userID); err != nil {
+		return internalError("Failed to update transfer", err)
+	}
+	if !pendingTransfers.RequireAcceptance {
+		return t.complete(ctx, tx)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE pending_transfers SET status = 'awaiting_acceptance' WHERE id = $1", t.ID); err != nil {
+		return internalError("Failed to update transfer", err)
+	}
+	t.Status = oas.TransferStatusAwaitingAcceptance
+	return nil
+}
+
+func rejectTransfer(ctx context.Context, tx *sql.Tx, t *pendingTransfer, userID int) error {
+	if err := checkApprover(ctx, t, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE pending_transfers SET approved_by = $2 WHERE id = $1", t.ID, userID); err != nil {
+		return internalError("Failed to update transfer", err)
+	}
+	return t.refund(ctx, tx, oas.TransferStatusRejected)
+}
+
+// Выполняет действие над ожидающим переводом в транзакции и возвращает
+// перевод в новом состоянии
+func applyTransferAction(ctx context.Context, id int, action transferAction) (*oas.PendingTransfer, error) {
+	userID := claimsFromContext(ctx).UserID
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	t, err := lockPendingTransfer(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := action(ctx, tx, t, userID); err != nil {
+		return nil, err
+	}
+	resp, err := scanPendingTransfer(tx.QueryRowContext(ctx, pendingTransferQuery+"WHERE p.id = $1", id))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+
+	switch t.Status {
+	case oas.TransferStatusAccepted:
+		transfersTotal.Inc()
+		coinsTransferredTotal.Add(float64(t.Amount))
+		userInfoCache.Invalidate(ctx, t.SenderID, t.ReceiverID)
+	case oas.TransferStatusDeclined, oas.TransferStatusRejected, oas.TransferStatusCancelled:
+		userInfoCache.Invalidate(ctx, t.SenderID)
+	}
+	requestLogger(ctx).Info("Pending transfer updated", zap.Int("transfer_id", id), zap.String("status", string(t.Status)))
+	return resp, nil
+}
+
+// Возвращает отправителям монеты переводов, не подтверждённых вовремя
+func expirePendingTransfers(ctx context.Context) (int, error) {
+	expired := 0
+	for {
+		senders, err := expirePendingBatch(ctx)
+		if err != nil {
+			return expired, err
+		}
+		expired += len(senders)
+		userInfoCache.Invalidate(ctx, senders...)
+		if len(senders) < expireBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// Возвращает монеты части истёкших переводов; переводы, с которыми сейчас
+// работает другой запрос или экземпляр, пропускаются до следующего раза
+func expirePendingBatch(ctx context.Context) ([]int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, sender_id, amount, budget_amount, created_at FROM pending_transfers
+        WHERE status IN `+openTransferStatuses+` AND expires_at <= NOW()
+        ORDER BY id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    `, expireBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	var transfers []*pendingTransfer
+	for rows.Next() {
+		t := &pendingTransfer{}
+		if err := rows.Scan(&t.ID, &t.SenderID, &t.Amount, &t.Budget, &t.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	senders := make([]int, 0, len(transfers))
+	for _, t := range transfers {
+		if err := t.refund(ctx, tx, oas.TransferStatusExpired); err != nil {
+			return nil, err
+		}
+		senders = append(senders, t.SenderID)
+	}
+	return senders, tx.Commit()
+}
+
+// Запускает проверку истёкших переводов с периодом SweepInterval до отмены ctx
+func startPendingTransferSweeper(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(pendingTransfers.SweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := expirePendingTransfers(ctx)
+				if err != nil {
+					logger.Error("Failed to expire pending transfers", zap.Error(err))
+				}
+				if n > 0 {
+					logger.Info("Pending transfers expired", zap.Int("count", n))
+				}
+			}
+		}
+	}()
+}
+
+// Ожидающий перевод с именами участников для ответа
+const pendingTransferQuery = `
+    SELECT p.id, s.username, r.username, p.amount, p.budget_amount, p.message, p.category, p.public, p.status,
+        a.username, p.created_at, p.expires_at, p.resolved_at, p.transaction_id
+    FROM pending_transfers p
+    JOIN users s ON s.id = p.sender_id
+    JOIN users r ON r.id = p.receiver_id
+    LEFT JOIN users a ON a.id = p.approver_id
+`
+
+func scanPendingTransfer(row interface{ Scan(...any) error }) (*oas.PendingTransfer, error) {
+	var t oas.PendingTransfer
+	var status string
+	var message, category, approver sql.NullString
+	var resolvedAt sql.NullTime
+	var transactionID sql.NullInt64
+	var budget int
+	err := row.Scan(&t.ID, &t.FromUser, &t.ToUser, &t.Amount, &budget, &message, &category, &t.Public, &status,
+		&approver, &t.CreatedAt, &t.ExpiresAt, &resolvedAt, &transactionID)
+	if err != nil {
+		return nil, err
+	}
+	t.Status = oas.TransferStatus(status)
+	if budget != 0 {
+		t.FromBudget = oas.NewOptInt(budget)
+	}
+	if message.Valid {
+		t.Message = oas.NewOptString(message.String)
+	}
+	if category.Valid {
+		t.Category = oas.NewOptTransferCategory(oas.TransferCategory(category.String))
+	}
+	if approver.Valid {
+		t.Approver = oas.NewOptString(approver.String)
+	}
+	if resolvedAt.Valid {
+		t.ResolvedAt = oas.NewOptDateTime(resolvedAt.Time)
+	}
+	if transactionID.Valid {
+		t.TransactionId = oas.NewOptInt(int(transactionID.Int64))
+	}
+	return &t, nil
+}
+
+func queryPendingTransfers(ctx context.Context, where string, args ...any) ([]oas.PendingTransfer, error) {
+	rows, err := db.QueryContext(ctx, pendingTransferQuery+where+" ORDER BY p.id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := []oas.PendingTransfer{}
+	for rows.Next() {
+		t, err := scanPendingTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *t)
+	}
+	return transfers, rows.Err()
+}
+
+func (apiHandler) APITransfersPendingGet(ctx context.Context) (oas.APITransfersPendingGetRes, error) {
+	userID := claimsFromContext(ctx).UserID
+	admin, err := isAdmin(ctx, userID)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+
+	resp := &oas.PendingTransferList{}
+	if resp.Incoming, err = queryPendingTransfers(ctx, "WHERE p.receiver_id = $1 AND p.status = 'awaiting_acceptance'", userID); err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if resp.Outgoing, err = queryPendingTransfers(ctx, "WHERE p.sender_id = $1 AND p.status IN "+openTransferStatuses, userID); err != nil {
+		return nil, internalError("Database error", err)
+	}
+	resp.Approvals, err = queryPendingTransfers(ctx, `
+        WHERE p.status = 'awaiting_approval' AND p.sender_id <> $1 AND p.receiver_id <> $1
+          AND (p.approver_id = $1 OR ($2 AND p.approver_id IS NULL))
+    `, userID, admin)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	return resp, nil
+}
+
+func (apiHandler) APITransfersTransferIdAcceptPost(ctx context.Context, params oas.APITransfersTransferIdAcceptPostParams) (oas.APITransfersTransferIdAcceptPostRes, error) {
+	resp, err := applyTransferAction(ctx, params.TransferId, acceptTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (apiHandler) APITransfersTransferIdDeclinePost(ctx context.Context, params oas.APITransfersTransferIdDeclinePostParams) (oas.APITransfersTransferIdDeclinePostRes, error) {
+	resp, err := applyTransferAction(ctx, params.TransferId, declineTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (apiHandler) APITransfersTransferIdCancelPost(ctx context.Context, params oas.APITransfersTransferIdCancelPostParams) (oas.APITransfersTransferIdCancelPostRes, error) {
+	resp, err := applyTransferAction(ctx, params.TransferId, cancelTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (apiHandler) APITransfersTransferIdApprovePost(ctx context.Context, params oas.APITransfersTransferIdApprovePostParams) (oas.APITransfersTransferIdApprovePostRes, error) {
+	resp, err := applyTransferAction(ctx, params.TransferId, approveTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (apiHandler) APITransfersTransferIdRejectPost(ctx context.Context, params oas.APITransfersTransferIdRejectPostParams) (oas.APITransfersTransferIdRejectPostRes, error) {
+	resp, err := applyTransferAction(ctx, params.TransferId, rejectTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
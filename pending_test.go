@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+func TestPendingPolicy_Stage(t *testing.T) {
+	policy := pendingPolicy{}
+	assert.Equal(t, oas.TransferStatusCompleted, policy.stage(1_000_000), "По умолчанию переводы выполняются сразу")
+
+	policy.RequireAcceptance = true
+	assert.Equal(t, oas.TransferStatusAwaitingAcceptance, policy.stage(10))
+
+	policy.ApprovalThreshold = 500
+	assert.Equal(t, oas.TransferStatusAwaitingAcceptance, policy.stage(499))
+	assert.Equal(t, oas.TransferStatusAwaitingApproval, policy.stage(500), "Крупный перевод сначала одобряет руководитель")
+
+	policy.RequireAcceptance = false
+	assert.Equal(t, oas.TransferStatusCompleted, policy.stage(10))
+}
+
+func TestInitPendingTransfers(t *testing.T) {
+	defer func(saved pendingPolicy) { pendingTransfers = saved }(pendingTransfers)
+
+	t.Setenv("TRANSFER_REQUIRE_ACCEPTANCE", "true")
+	t.Setenv("PENDING_TRANSFER_TTL", "24h")
+	assert.NoError(t, initPendingTransfers())
+	assert.True(t, pendingTransfers.RequireAcceptance)
+	assert.Equal(t, 24*time.Hour, pendingTransfers.TTL)
+	assert.Equal(t, time.Minute, pendingTransfers.SweepInterval)
+
+	t.Setenv("TRANSFER_APPROVAL_THRESHOLD", "-1")
+	assert.Error(t, initPendingTransfers())
+
+	t.Setenv("TRANSFER_APPROVAL_THRESHOLD", "")
+	t.Setenv("PENDING_TRANSFER_TTL", "3 days")
+	assert.Error(t, initPendingTransfers(), "Неразбираемый срок должен останавливать запуск")
+}
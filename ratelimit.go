@@ -0,0 +1,478 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// Параметры token bucket: скорость пополнения (токенов в секунду) и ёмкость
+type rateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type rateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+	// Время до полного восстановления ёмкости
+	Reset time.Duration
+}
+
+// Политика блокировки после неудачных попыток входа: после MaxFailures
+// подряд вход блокируется на Base, каждая следующая блокировка вдвое дольше
+type lockoutPolicy struct {
+	MaxFailures int
+	Base        time.Duration
+	Max         time.Duration
+}
+
+func (p lockoutPolicy) duration(level int) time.Duration {
+	d := p.Base << level
+	if d <= 0 || d > p.Max {
+		return p.Max
+	}
+	return d
+}
+
+// Хранилище состояния лимитов: в памяти процесса или в Redis
+type rateLimiter interface {
+	Allow(ctx context.Context, key string, limit rateLimit) (rateLimitResult, error)
+	// Учитывает неудачную попытку и возвращает длительность блокировки (0 – без блокировки)
+	RecordFailure(ctx context.Context, key string, policy lockoutPolicy) (time.Duration, error)
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// Лимиты по умолчанию: для /api/auth на IP клиента, для остальных операций на пользователя
+var defaultRateLimits = map[string]rateLimit{
+	"ApiAuthPost":               {Rate: 20, Burst: 40},
+	"ApiRegisterPost":           {Rate: 1, Burst: 5},
+	"ApiPasswordPost":           {Rate: 1, Burst: 5},
+	"ApiPasswordResetPost":      {Rate: 1, Burst: 5},
+	"ApiAuth2FAPost":            {Rate: 1, Burst: 5},
+	"ApiAuthOidcCallbackPost":   {Rate: 5, Burst: 10},
+	"Api2FATotpConfirmPost":     {Rate: 1, Burst: 5},
+	"Api2FATotpDisablePost":     {Rate: 1, Burst: 5},
+	"ApiBuyItemGet":             {Rate: 20, Burst: 40},
+	"ApiInfoGet":                {Rate: 50, Burst: 100},
+	"ApiKudosGet":               {Rate: 20, Burst: 40},
+	"ApiSendCoinPost":           {Rate: 10, Burst: 20},
+	"ApiSendCoinBatchPost":      {Rate: 1, Burst: 5},
+	"ApiTransactionDisputePost": {Rate: 1, Burst: 5},
+	"ApiSchedulesPost":          {Rate: 1, Burst: 5},
+}
+
+var (
+	limiter      rateLimiter = newMemoryLimiter()
+	routeLimits              = defaultRateLimits
+	loginLockout             = lockoutPolicy{MaxFailures: 5, Base: 30 * time.Second, Max: time.Hour}
+	trustProxy               = false
+)
+
+// Читает настройки ограничений из окружения:
+// RATE_LIMITS="ApiAuthPost=5:10,ApiSendCoinPost=off" (скорость в секунду:ёмкость),
+// RATE_LIMIT_BACKEND=memory|redis, LOGIN_MAX_FAILURES, LOGIN_LOCKOUT_BASE,
+// LOGIN_LOCKOUT_MAX, TRUST_PROXY_HEADERS
+func initRateLimits(client *redis.Client) error {
+	limits, err := parseRateLimits(defaultRateLimits, envString("RATE_LIMITS", ""))
+	if err != nil {
+		return err
+	}
+	routeLimits = limits
+
+	var env envReader
+	lockout := lockoutPolicy{
+		MaxFailures: env.Int("LOGIN_MAX_FAILURES", loginLockout.MaxFailures),
+		Base:        env.Duration("LOGIN_LOCKOUT_BASE", loginLockout.Base),
+		Max:         env.Duration("LOGIN_LOCKOUT_MAX", loginLockout.Max),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	loginLockout = lockout
+	trustProxy = envString("TRUST_PROXY_HEADERS", "") == "true"
+
+	switch backend := envString("RATE_LIMIT_BACKEND", "memory"); backend {
+	case "memory":
+		limiter = newMemoryLimiter()
+	case "redis":
+		if client == nil {
+			return fmt.Errorf("RATE_LIMIT_BACKEND=redis requires REDIS_ADDR")
+		}
+		limiter = newRedisLimiter(client)
+	default:
+		return fmt.Errorf("unknown rate limit backend %q", backend)
+	}
+	return nil
+}
+
+func parseRateLimits(defaults map[string]rateLimit, spec string) (map[string]rateLimit, error) {
+	limits := make(map[string]rateLimit, len(defaults))
+	for name, limit := range defaults {
+		limits[name] = limit
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q", entry)
+		}
+		if value == "off" {
+			delete(limits, name)
+			continue
+		}
+		rateStr, burstStr, ok := strings.Cut(value, ":")
+		rate, rateErr := strconv.ParseFloat(rateStr, 64)
+		burst, burstErr := strconv.Atoi(burstStr)
+		if !ok || rateErr != nil || burstErr != nil || rate <= 0 || burst <= 0 {
+			return nil, fmt.Errorf("invalid rate limit %q", entry)
+		}
+		limits[name] = rateLimit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
+
+// IP клиента; X-Forwarded-For учитывается только за доверенным прокси
+func clientIP(r *http.Request) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Идентичность, на которую считается лимит: пользователь из токена,
+// проверенный API-ключ или IP. Разобранный токен возвращается в контексте
+// запроса, чтобы проверка доступа не разбирала его второй раз.
+func rateLimitIdentity(r *http.Request) (string, context.Context) {
+	ctx := r.Context()
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if isAPIKey(token) {
+			if id, ok := cachedAPIKeyID(token); ok {
+				return "apikey:" + strconv.Itoa(id), ctx
+			}
+			return "ip:" + clientIP(r), ctx
+		}
+		ctx, claims, err := withParsedToken(ctx, token)
+		if err == nil {
+			if claims.legacy() {
+				return "username:" + claims.Username, ctx
+			}
+			return "user:" + claims.Subject, ctx
+		}
+		return "ip:" + clientIP(r), ctx
+	}
+	return "ip:" + clientIP(r), ctx
+}
+
+// Middleware, ограничивающая частоту запросов к маршруту
+func rateLimited(name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, ok := routeLimits[name]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		identity := "ip:" + clientIP(r)
+		if name != "ApiAuthPost" {
+			var ctx context.Context
+			identity, ctx = rateLimitIdentity(r)
+			r = r.WithContext(ctx)
+		}
+
+		result, err := limiter.Allow(r.Context(), "ratelimit:"+name+":"+identity, limit)
+		if err != nil {
+			// Недоступность хранилища лимитов не должна ронять сервис
+			requestLogger(r.Context()).Warn("Rate limiter unavailable", zap.Error(err))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		if !result.Allowed {
+			rateLimitedTotal.WithLabelValues(name).Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			respondWithError(w, http.StatusTooManyRequests, codeRateLimited, "Too many requests")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// Неудачные входы считаются на пару пользователь и IP клиента: подбор пароля
+// с одного адреса блокируется, но чужие попытки не блокируют вход самому
+// сотруднику. Подбор с многих адресов сдерживает лимит /api/auth на IP.
+func loginLockoutKey(ctx context.Context, username string) string {
+	return "login:" + username + ":" + requestClientIP(ctx)
+}
+
+// Возвращает ошибку, если вход под пользователем с этого IP временно заблокирован
+func checkLoginLockout(ctx context.Context, username string) error {
+	lockedFor, err := limiter.LockedFor(ctx, loginLockoutKey(ctx, username))
+	if err != nil {
+		requestLogger(ctx).Warn("Rate limiter unavailable", zap.Error(err))
+		return nil
+	}
+	if lockedFor > 0 {
+		return tooManyRequests(codeLoginLocked, "Too many failed login attempts", lockedFor)
+	}
+	return nil
+}
+
+// Учитывает неудачный вход; при достижении порога возвращает ошибку блокировки
+func recordLoginFailure(ctx context.Context, username string) error {
+	lockedFor, err := limiter.RecordFailure(ctx, loginLockoutKey(ctx, username), loginLockout)
+	if err != nil {
+		requestLogger(ctx).Warn("Rate limiter unavailable", zap.Error(err))
+		return nil
+	}
+	if lockedFor > 0 {
+		requestLogger(ctx).Warn("Login locked after failed attempts", zap.String("username", username), zap.String("client_ip", requestClientIP(ctx)), zap.Duration("locked_for", lockedFor))
+		return tooManyRequests(codeLoginLocked, "Too many failed login attempts", lockedFor)
+	}
+	return nil
+}
+
+func resetLoginFailures(ctx context.Context, username string) {
+	if err := limiter.Reset(ctx, loginLockoutKey(ctx, username)); err != nil {
+		requestLogger(ctx).Warn("Rate limiter unavailable", zap.Error(err))
+	}
+}
+
+// Реализация в памяти процесса; подходит для одного экземпляра сервиса
+type memoryLimiter struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	buckets   map[string]*tokenBucket
+	lockouts  map[string]*lockoutState
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	limit   rateLimit
+}
+
+type lockoutState struct {
+	failures    int
+	level       int
+	lockedUntil time.Time
+	updated     time.Time
+}
+
+// Состояние блокировок хранится сутки с последней неудачной попытки
+const lockoutStateTTL = 24 * time.Hour
+
+func newMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{
+		now:      time.Now,
+		buckets:  make(map[string]*tokenBucket),
+		lockouts: make(map[string]*lockoutState),
+	}
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string, limit rateLimit) (rateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.limit = limit
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return bucketResult(allowed, b.tokens, limit), nil
+}
+
+// Общая для реализаций часть: результат по остатку токенов в корзине
+func bucketResult(allowed bool, tokens float64, limit rateLimit) rateLimitResult {
+	result := rateLimitResult{Allowed: allowed, Remaining: int(tokens)}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+	}
+	result.Reset = time.Duration((float64(limit.Burst) - tokens) / limit.Rate * float64(time.Second))
+	return result
+}
+
+// Раз в минуту удаляет полностью восстановившиеся корзины и устаревшие блокировки
+func (l *memoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	for key, s := range l.lockouts {
+		if now.Sub(s.updated) > lockoutStateTTL {
+			delete(l.lockouts, key)
+		}
+	}
+}
+
+func (l *memoryLimiter) RecordFailure(ctx context.Context, key string, policy lockoutPolicy) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	s, ok := l.lockouts[key]
+	if !ok {
+		s = &lockoutState{}
+		l.lockouts[key] = s
+	}
+	s.updated = now
+	s.failures++
+	if s.failures < policy.MaxFailures {
+		return 0, nil
+	}
+	lockedFor := policy.duration(s.level)
+	s.level++
+	s.failures = 0
+	s.lockedUntil = now.Add(lockedFor)
+	return lockedFor, nil
+}
+
+func (l *memoryLimiter) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if s, ok := l.lockouts[key]; ok {
+		if d := s.lockedUntil.Sub(l.now()); d > 0 {
+			return d, nil
+		}
+	}
+	return 0, nil
+}
+
+func (l *memoryLimiter) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.lockouts, key)
+	return nil
+}
+
+// Реализация в Redis; лимиты общие для всех экземпляров сервиса
+type redisLimiter struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+func newRedisLimiter(client *redis.Client) *redisLimiter {
+	return &redisLimiter{client: client, now: time.Now}
+}
+
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil then
+  tokens = burst
+  ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit rateLimit) (rateLimitResult, error) {
+	res, err := tokenBucketScript.Run(ctx, l.client, []string{key},
+		limit.Rate, limit.Burst, l.now().UnixMilli()).Slice()
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+	tokens, err := strconv.ParseFloat(res[1].(string), 64)
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+	return bucketResult(res[0].(int64) == 1, tokens, limit), nil
+}
+
+var loginFailureScript = redis.NewScript(`
+local failures = redis.call('HINCRBY', KEYS[1], 'failures', 1)
+local lockedFor = 0
+if failures >= tonumber(ARGV[1]) then
+  local level = redis.call('HINCRBY', KEYS[1], 'level', 1) - 1
+  lockedFor = math.min(tonumber(ARGV[3]), tonumber(ARGV[2]) * 2 ^ level)
+  redis.call('HSET', KEYS[1], 'failures', 0, 'locked_until', tonumber(ARGV[4]) + lockedFor)
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[5])
+return lockedFor
+`)
+
+func (l *redisLimiter) RecordFailure(ctx context.Context, key string, policy lockoutPolicy) (time.Duration, error) {
+	lockedMs, err := loginFailureScript.Run(ctx, l.client, []string{key},
+		policy.MaxFailures, policy.Base.Milliseconds(), policy.Max.Milliseconds(),
+		l.now().UnixMilli(), lockoutStateTTL.Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(lockedMs) * time.Millisecond, nil
+}
+
+func (l *redisLimiter) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	lockedUntil, err := l.client.HGet(ctx, key, "locked_until").Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if d := time.UnixMilli(lockedUntil).Sub(l.now()); d > 0 {
+		return d, nil
+	}
+	return 0, nil
+}
+
+func (l *redisLimiter) Reset(ctx context.Context, key string) error {
+	return l.client.Del(ctx, key).Err()
+}
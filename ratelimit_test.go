@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// Возвращает реализации лимитера с общими управляемыми часами
+func testLimiters(t *testing.T) (map[string]rateLimiter, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	memory := newMemoryLimiter()
+	memory.now = clock
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	redisImpl := newRedisLimiter(client)
+	redisImpl.now = clock
+
+	return map[string]rateLimiter{"memory": memory, "redis": redisImpl}, &now
+}
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	limiters, now := testLimiters(t)
+	limit := rateLimit{Rate: 1, Burst: 3}
+
+	for name, l := range limiters {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Ёмкость корзины расходуется полностью, затем запросы отклоняются
+			for i := 0; i < 3; i++ {
+				result, err := l.Allow(ctx, "key", limit)
+				assert.NoError(t, err)
+				assert.True(t, result.Allowed, "Запрос %d должен пройти", i+1)
+				assert.Equal(t, 2-i, result.Remaining)
+			}
+			result, err := l.Allow(ctx, "key", limit)
+			assert.NoError(t, err)
+			assert.False(t, result.Allowed, "Запрос сверх ёмкости должен быть отклонён")
+			assert.Equal(t, time.Second, result.RetryAfter)
+
+			// Другой ключ не затронут
+			result, _ = l.Allow(ctx, "other", limit)
+			assert.True(t, result.Allowed, "Лимиты разных ключей независимы")
+
+			// Через секунду восстанавливается один токен
+			*now = now.Add(time.Second)
+			result, _ = l.Allow(ctx, "key", limit)
+			assert.True(t, result.Allowed, "Токен должен восстановиться")
+			result, _ = l.Allow(ctx, "key", limit)
+			assert.False(t, result.Allowed)
+		})
+	}
+}
+
+func TestRateLimiter_ProgressiveLockout(t *testing.T) {
+	limiters, now := testLimiters(t)
+	policy := lockoutPolicy{MaxFailures: 3, Base: time.Minute, Max: 3 * time.Minute}
+
+	for name, l := range limiters {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := 0; i < 2; i++ {
+				lockedFor, err := l.RecordFailure(ctx, "login:user1", policy)
+				assert.NoError(t, err)
+				assert.Zero(t, lockedFor, "До порога вход не блокируется")
+			}
+			lockedFor, _ := l.RecordFailure(ctx, "login:user1", policy)
+			assert.Equal(t, time.Minute, lockedFor, "Первая блокировка длится Base")
+
+			remaining, _ := l.LockedFor(ctx, "login:user1")
+			assert.Equal(t, time.Minute, remaining)
+
+			// Следующая серия неудач удваивает блокировку, но не выше Max
+			*now = now.Add(time.Minute)
+			for i := 0; i < 3; i++ {
+				lockedFor, _ = l.RecordFailure(ctx, "login:user1", policy)
+			}
+			assert.Equal(t, 2*time.Minute, lockedFor)
+			for i := 0; i < 3; i++ {
+				lockedFor, _ = l.RecordFailure(ctx, "login:user1", policy)
+			}
+			assert.Equal(t, 3*time.Minute, lockedFor)
+
+			// Успешный вход сбрасывает состояние
+			assert.NoError(t, l.Reset(ctx, "login:user1"))
+			remaining, _ = l.LockedFor(ctx, "login:user1")
+			assert.Zero(t, remaining)
+		})
+	}
+}
+
+func TestRateLimitedMiddleware(t *testing.T) {
+	previousLimiter, previousLimits := limiter, routeLimits
+	limiter = newMemoryLimiter()
+	routeLimits = map[string]rateLimit{"TestRoute": {Rate: 1, Burst: 2}}
+	t.Cleanup(func() { limiter, routeLimits = previousLimiter, previousLimits })
+
+	handler := rateLimited("TestRoute", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	token := signTestToken(t, 1)
+	var recorder *httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+	}
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code, "Ожидался код 429")
+	assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	assert.Equal(t, "2", recorder.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Contains(t, recorder.Body.String(), codeRateLimited)
+
+	// Лимит считается на пользователя, а не на IP
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, 2))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Другой пользователь не должен попадать под чужой лимит")
+}
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := parseRateLimits(defaultRateLimits, "ApiAuthPost=0.5:5, ApiInfoGet=off")
+	assert.NoError(t, err)
+	assert.Equal(t, rateLimit{Rate: 0.5, Burst: 5}, limits["ApiAuthPost"])
+	assert.NotContains(t, limits, "ApiInfoGet")
+	assert.Equal(t, defaultRateLimits["ApiSendCoinPost"], limits["ApiSendCoinPost"])
+
+	_, err = parseRateLimits(defaultRateLimits, "ApiAuthPost=fast")
+	assert.Error(t, err)
+}
+
+func TestLoginLockout_PerClientIP(t *testing.T) {
+	previousLimiter, previousPolicy := limiter, loginLockout
+	limiter = newMemoryLimiter()
+	loginLockout = lockoutPolicy{MaxFailures: 2, Base: time.Minute, Max: time.Hour}
+	t.Cleanup(func() { limiter, loginLockout = previousLimiter, previousPolicy })
+
+	fromIP := func(ip string) context.Context {
+		return context.WithValue(context.Background(), requestLogKey{}, &requestLog{logger: logger, clientIP: ip})
+	}
+	attacker, employee := fromIP("203.0.113.7"), fromIP("198.51.100.1")
+
+	assert.NoError(t, recordLoginFailure(attacker, "user1"))
+	assert.Error(t, recordLoginFailure(attacker, "user1"), "Порог неудачных попыток достигнут")
+	assert.Error(t, checkLoginLockout(attacker, "user1"), "Вход с адреса подбора заблокирован")
+	assert.NoError(t, checkLoginLockout(employee, "user1"), "Чужие попытки не блокируют вход с другого адреса")
+}
+
+func TestRateLimitedMiddleware_ParsesTokenOnce(t *testing.T) {
+	previousLimiter, previousLimits := limiter, routeLimits
+	limiter = newMemoryLimiter()
+	routeLimits = map[string]rateLimit{"TestRoute": {Rate: 100, Burst: 100}}
+	t.Cleanup(func() { limiter, routeLimits = previousLimiter, previousLimits })
+
+	token := signTestToken(t, 1)
+	var handlerClaims *Claims
+	handler := rateLimited("TestRoute", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Проверка доступа в обработчике получает токен, разобранный лимитом
+		handlerClaims, _ = parseToken(r.Context(), token)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	lookups := func() float64 {
+		return testutil.ToFloat64(tokenCacheRequests.WithLabelValues("hit")) +
+			testutil.ToFloat64(tokenCacheRequests.WithLabelValues("miss"))
+	}
+	before := lookups()
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, before+1, lookups(), "Запрос должен учитываться в метриках кэша токенов один раз")
+	if assert.NotNil(t, handlerClaims) {
+		assert.Equal(t, 1, handlerClaims.UserID)
+	}
+}
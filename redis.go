@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Подключается к Redis по адресу REDIS_ADDR; без адреса возвращает nil
+func initRedis(ctx context.Context) (*redis.Client, error) {
+	addr := envString("REDIS_ADDR", "")
+	if addr == "" {
+		return nil, nil
+	}
+	var env envReader
+	database := env.Int("REDIS_DB", 0)
+	if err := env.Err(); err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: envString("REDIS_PASSWORD", ""),
+		DB:       database,
+	})
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+	return client, nil
+}
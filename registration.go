@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Правила создания учётных записей
+type registrationPolicy struct {
+	// Создавать пользователя при первом входе через /api/auth
+	AutoRegister bool
+	// Допустимые шаблоны имён; пустой список – любые имена
+	Patterns []*regexp.Regexp
+	// Список сотрудников, которым разрешена регистрация; nil – без ограничений
+	Roster map[string]struct{}
+	// Учётные записи создаются только импортом справочника сотрудников
+	DirectoryOnly bool
+	// Баланс новых пользователей
+	StartingBalance int
+}
+
+var registration = registrationPolicy{AutoRegister: true, StartingBalance: 1000}
+
+var (
+	errUsernameTaken      = errors.New("username already taken")
+	errRegistrationClosed = errors.New("accounts are created only by directory import")
+)
+
+// Читает политику регистрации из AUTO_REGISTER, USERNAME_PATTERNS
+// (регулярные выражения через запятую), REGISTRATION_ROSTER (путь к файлу
+// с именами сотрудников, по одному в строке), REGISTRATION_DIRECTORY_ONLY
+// и STARTING_BALANCE
+func initRegistration() error {
+	var env envReader
+	policy := registrationPolicy{
+		AutoRegister:    envString("AUTO_REGISTER", "true") == "true",
+		DirectoryOnly:   envString("REGISTRATION_DIRECTORY_ONLY", "false") == "true",
+		StartingBalance: env.Int("STARTING_BALANCE", 1000),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if policy.StartingBalance < 0 {
+		return fmt.Errorf("invalid starting balance %d", policy.StartingBalance)
+	}
+
+	for _, expr := range strings.Split(envString("USERNAME_PATTERNS", ""), ",") {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			continue
+		}
+		// Шаблон должен описывать имя целиком
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid username pattern %q: %w", expr, err)
+		}
+		policy.Patterns = append(policy.Patterns, re)
+	}
+
+	if path := envString("REGISTRATION_ROSTER", ""); path != "" {
+		roster, err := loadRoster(path)
+		if err != nil {
+			return err
+		}
+		policy.Roster = roster
+	}
+
+	registration = policy
+	return nil
+}
+
+// Загружает список сотрудников; пустые строки и строки с # пропускаются
+func loadRoster(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open roster: %w", err)
+	}
+	defer file.Close()
+
+	roster := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		roster[normalizeUsername(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read roster: %w", err)
+	}
+	return roster, nil
+}
+
+// Приводит имя к каноническому виду, чтобы «User1» и « user1» были одним пользователем
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// Проверяет, можно ли зарегистрировать нормализованное имя
+func (p registrationPolicy) allowed(username string) bool {
+	if p.Roster != nil {
+		if _, ok := p.Roster[username]; !ok {
+			return false
+		}
+	}
+	if len(p.Patterns) == 0 {
+		return true
+	}
+	for _, re := range p.Patterns {
+		if re.MatchString(username) {
+			return true
+		}
+	}
+	return false
+}
+
+// Создаёт пользователя со стартовым балансом и возвращает его ID. Имя
+// сотрудника, импортированного из справочника, занято, даже пока у него нет
+// пароля: пароль он получает по токену сброса от администратора или входит
+// через OIDC, иначе учётную запись присвоил бы первый, кто назовёт это имя.
+func createUser(ctx context.Context, username, password string) (int, error) {
+	if registration.DirectoryOnly {
+		return 0, errRegistrationClosed
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Стартовый баланс – первая партия монет
+	ids, err := insertAccounts(ctx, tx, []newAccount{{
+		Username: username,
+		Password: sql.NullString{String: password, Valid: true},
+		Coins:    registration.StartingBalance,
+	}})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, errUsernameTaken
+		}
+		return 0, err
+	}
+	if ids[0] == 0 {
+		return 0, errUsernameTaken
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	signupsTotal.Inc()
+	return ids[0], nil
+}
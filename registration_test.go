@@ -0,0 +1,51 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeUsername(t *testing.T) {
+	assert.Equal(t, "user1", normalizeUsername("  User1\t"))
+	assert.Equal(t, "ivan.petrov", normalizeUsername("Ivan.Petrov"))
+}
+
+func TestRegistrationPolicy_Allowed(t *testing.T) {
+	t.Setenv("USERNAME_PATTERNS", `[a-z]+\.[a-z]+, svc-[a-z]+`)
+	assert.NoError(t, initRegistration())
+	t.Cleanup(func() { registration = registrationPolicy{AutoRegister: true} })
+
+	assert.True(t, registration.AutoRegister, "Авторегистрация включена по умолчанию")
+	assert.True(t, registration.allowed("ivan.petrov"))
+	assert.True(t, registration.allowed("svc-payroll"))
+	assert.False(t, registration.allowed("ivan.petrov1"), "Шаблон должен совпадать с именем целиком")
+	assert.False(t, registration.allowed("ivanpetrov"))
+}
+
+func TestRegistrationPolicy_Roster(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roster.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("# сотрудники\nIvan.Petrov\n\n  anna.smirnova  \n"), 0o644))
+	t.Setenv("REGISTRATION_ROSTER", path)
+	t.Setenv("AUTO_REGISTER", "false")
+	assert.NoError(t, initRegistration())
+	t.Cleanup(func() { registration = registrationPolicy{AutoRegister: true} })
+
+	assert.False(t, registration.AutoRegister)
+	assert.True(t, registration.allowed("ivan.petrov"), "Имена из списка нормализуются")
+	assert.True(t, registration.allowed("anna.smirnova"))
+	assert.False(t, registration.allowed("petr.ivanov"), "Регистрация вне списка запрещена")
+
+	// Отказ возвращается до обращения к БД
+	code, errResponse := performRawRequest(t, "POST", "/api/register", "", `{"username":"petr.ivanov","password":"secret"}`)
+	assert.Equal(t, http.StatusForbidden, code, "Ожидался код 403")
+	assert.Equal(t, codeRegistrationDenied, errResponse.Code.Value)
+}
+
+func TestInitRegistration_InvalidPattern(t *testing.T) {
+	t.Setenv("USERNAME_PATTERNS", "[a-z")
+	assert.Error(t, initRegistration())
+}
@@ -0,0 +1,509 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Сколько запланированных переводов выполняется за одну выборку
+const scheduleBatchSize = 100
+
+// Правила запланированных переводов
+type schedulePolicy struct {
+	// Период проверки наступивших переводов
+	Interval time.Duration
+	// После стольких неудач подряд повторяющееся расписание приостанавливается
+	MaxFailures int
+	// Сколько активных и приостановленных расписаний может быть у пользователя
+	MaxPerUser int
+}
+
+var schedules = schedulePolicy{Interval: time.Minute, MaxFailures: 3, MaxPerUser: 20}
+
+// Читает SCHEDULED_TRANSFER_INTERVAL, SCHEDULED_TRANSFER_MAX_FAILURES
+// и SCHEDULED_TRANSFER_MAX_PER_USER
+func initSchedules() error {
+	var env envReader
+	policy := schedulePolicy{
+		Interval:    env.Duration("SCHEDULED_TRANSFER_INTERVAL", schedules.Interval),
+		MaxFailures: env.Int("SCHEDULED_TRANSFER_MAX_FAILURES", schedules.MaxFailures),
+		MaxPerUser:  env.Int("SCHEDULED_TRANSFER_MAX_PER_USER", schedules.MaxPerUser),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if policy.Interval <= 0 || policy.MaxFailures < 1 || policy.MaxPerUser < 1 {
+		return fmt.Errorf("invalid scheduled transfer settings")
+	}
+	schedules = policy
+	return nil
+}
+
+// Время повтора номер k расписания, начатого в start. Ежемесячный перевод
+// выполняется в тот же день месяца, а в коротком месяце – в последний день.
+// Дни считаются по UTC.
+func occurrenceAt(start time.Time, recurrence oas.Recurrence, k int) time.Time {
+	start = start.UTC()
+	switch recurrence {
+	case oas.RecurrenceWeekly:
+		return start.AddDate(0, 0, 7*k)
+	case oas.RecurrenceMonthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(k), 1,
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), time.UTC)
+		lastDay := first.AddDate(0, 1, -1).Day()
+		return first.AddDate(0, 0, min(start.Day(), lastDay)-1)
+	}
+	return start
+}
+
+// Первый повтор не раньше номера k, наступающий после now. Пропущенные
+// повторы не выполняются. У разового расписания повторов нет.
+func nextOccurrence(start time.Time, recurrence oas.Recurrence, k int, now time.Time) (int, time.Time, bool) {
+	if recurrence == oas.RecurrenceOnce {
+		return k, time.Time{}, false
+	}
+	for !occurrenceAt(start, recurrence, k).After(now) {
+		k++
+	}
+	return k, occurrenceAt(start, recurrence, k), true
+}
+
+// Расписание, заблокированное в транзакции
+type schedule struct {
+	ID         int
+	SenderID   int
+	ReceiverID int
+	ToUser     string
+	Amount     int
+	Note       transferNote
+	UseBudget  bool
+	Recurrence oas.Recurrence
+	Status     oas.ScheduleStatus
+	StartAt    time.Time
+	Occurrence int
+}
+
+func lockSchedule(ctx context.Context, tx *sql.Tx, id int) (*schedule, error) {
+	s := &schedule{ID: id}
+	var recurrence, status string
+	err := tx.QueryRowContext(ctx, `
+        SELECT sender_id, receiver_id, amount, recurrence, status, start_at, occurrence
+        FROM scheduled_transfers WHERE id = $1
+        FOR UPDATE
+    `, id).Scan(&s.SenderID, &s.ReceiverID, &s.Amount, &recurrence, &status, &s.StartAt, &s.Occurrence)
+	if err == sql.ErrNoRows {
+		return nil, notFound(codeScheduleNotFound, "Schedule not found")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	s.Recurrence, s.Status = oas.Recurrence(recurrence), oas.ScheduleStatus(status)
+	return s, nil
+}
+
+// Переход расписания по действию владельца
+type scheduleAction func(ctx context.Context, tx *sql.Tx, s *schedule) error
+
+func pauseSchedule(ctx context.Context, tx *sql.Tx, s *schedule) error {
+	if s.Status != oas.ScheduleStatusActive {
+		return conflict(codeScheduleNotActive, "Schedule is not active")
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE scheduled_transfers SET status = 'paused' WHERE id = $1", s.ID); err != nil {
+		return internalError("Failed to update schedule", err)
+	}
+	return nil
+}
+
+// Возобновляет расписание со следующего повтора; разовый перевод, время
+// которого прошло за время паузы, выполняется при следующей проверке
+func resumeSchedule(ctx context.Context, tx *sql.Tx, s *schedule) error {
+	if s.Status != oas.ScheduleStatusPaused {
+		return conflict(codeScheduleNotPaused, "Schedule is not paused")
+	}
+	occurrence, next, ok := nextOccurrence(s.StartAt, s.Recurrence, s.Occurrence, time.Now())
+	if !ok {
+		next = s.StartAt
+		if now := time.Now(); next.Before(now) {
+			next = now
+		}
+	}
+	_, err := tx.ExecContext(ctx, `
+        UPDATE scheduled_transfers SET status = 'active', occurrence = $2, next_run_at = $3, failures = 0
+        WHERE id = $1
+    `, s.ID, occurrence, next)
+	if err != nil {
+		return internalError("Failed to update schedule", err)
+	}
+	return nil
+}
+
+func cancelSchedule(ctx context.Context, tx *sql.Tx, s *schedule) error {
+	if s.Status != oas.ScheduleStatusActive && s.Status != oas.ScheduleStatusPaused {
+		return conflict(codeScheduleNotActive, "Schedule is already finished")
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE scheduled_transfers SET status = 'cancelled', next_run_at = NULL WHERE id = $1", s.ID); err != nil {
+		return internalError("Failed to update schedule", err)
+	}
+	return nil
+}
+
+// Выполняет действие над своим расписанием и возвращает его в новом состоянии
+func applyScheduleAction(ctx context.Context, id int, action scheduleAction) (*oas.ScheduledTransfer, error) {
+	userID := claimsFromContext(ctx).UserID
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	s, err := lockSchedule(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.SenderID != userID {
+		return nil, notFound(codeScheduleNotFound, "Schedule not found")
+	}
+	if err := action(ctx, tx, s); err != nil {
+		return nil, err
+	}
+	resp, err := scanSchedule(tx.QueryRowContext(ctx, scheduleQuery+"WHERE s.id = $1", id))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+	requestLogger(ctx).Info("Schedule updated", zap.Int("schedule_id", id), zap.String("status", string(resp.Status)))
+	return resp, nil
+}
+
+// Выполняет наступившие переводы, пока они есть
+func runDueSchedules(ctx context.Context) (int, error) {
+	executed := 0
+	for {
+		due, err := claimDueSchedules(ctx)
+		if err != nil {
+			return executed, err
+		}
+		for _, s := range due {
+			runSchedule(ctx, s)
+		}
+		executed += len(due)
+		if len(due) < scheduleBatchSize {
+			return executed, nil
+		}
+	}
+}
+
+// Забирает наступившие переводы и переносит расписания на следующий повтор
+// до выполнения переводов. Если экземпляр упадёт между этими шагами, перевод
+// будет пропущен, но не выполнится дважды. Расписания, с которыми сейчас
+// работает другой экземпляр или запрос, пропускаются до следующей проверки.
+func claimDueSchedules(ctx context.Context) ([]*schedule, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT s.id, s.sender_id, s.receiver_id, r.username, s.amount, s.message, s.category, s.public,
+            s.use_budget, s.recurrence, s.start_at, s.occurrence
+        FROM scheduled_transfers s
+        JOIN users r ON r.id = s.receiver_id
+        WHERE s.status = 'active' AND s.next_run_at <= NOW()
+        ORDER BY s.next_run_at
+        LIMIT $1
+        FOR UPDATE OF s SKIP LOCKED
+    `, scheduleBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	var due []*schedule
+	for rows.Next() {
+		s := &schedule{Status: oas.ScheduleStatusActive}
+		var recurrence string
+		err := rows.Scan(&s.ID, &s.SenderID, &s.ReceiverID, &s.ToUser, &s.Amount, &s.Note.Message, &s.Note.Category, &s.Note.Public,
+			&s.UseBudget, &recurrence, &s.StartAt, &s.Occurrence)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		s.Recurrence = oas.Recurrence(recurrence)
+		due = append(due, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, s := range due {
+		occurrence, next, ok := nextOccurrence(s.StartAt, s.Recurrence, s.Occurrence+1, now)
+		if !ok {
+			// Разовый перевод считается выполненным; при ошибке расписание
+			// получит статус failed
+			_, err = tx.ExecContext(ctx, `
+                UPDATE scheduled_transfers SET status = 'completed', next_run_at = NULL, last_run_at = NOW()
+                WHERE id = $1
+            `, s.ID)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+                UPDATE scheduled_transfers SET occurrence = $2, next_run_at = $3, last_run_at = NOW()
+                WHERE id = $1
+            `, s.ID, occurrence, next)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return due, tx.Commit()
+}
+
+// Выполняет перевод по расписанию с теми же проверками, что и /api/sendCoin.
+// О неудаче отправитель получает уведомление.
+func runSchedule(ctx context.Context, s *schedule) {
+	log := logger.With(zap.Int("schedule_id", s.ID), zap.Int("user_id", s.SenderID))
+
+	err := checkScheduleSender(ctx, s.SenderID)
+	if err == nil {
+		err = executeTransfers(ctx, s.SenderID, []transferLeg{{ToUser: s.ToUser, Amount: s.Amount}}, s.Note, s.UseBudget)
+	}
+	if err == nil {
+		scheduledTransfersTotal.WithLabelValues("completed").Inc()
+		if _, err := db.ExecContext(ctx, "UPDATE scheduled_transfers SET failures = 0, last_error = NULL WHERE id = $1", s.ID); err != nil {
+			log.Error("Failed to update schedule", zap.Error(err))
+		}
+		log.Info("Scheduled transfer executed", zap.Int("amount", s.Amount))
+		return
+	}
+
+	apiErr := &apiError{code: codeInternalError, message: "Internal error"}
+	if !errors.As(err, &apiErr) || apiErr.code == codeInternalError {
+		log.Error("Scheduled transfer failed", zap.Error(err))
+	}
+	scheduledTransfersTotal.WithLabelValues("failed").Inc()
+
+	var status string
+	var failures int
+	err = db.QueryRowContext(ctx, `
+        UPDATE scheduled_transfers
+        SET failures = failures + 1, last_error = $2,
+            status = CASE
+                WHEN recurrence = 'once' THEN 'failed'
+                WHEN status = 'active' AND failures + 1 >= $3 THEN 'paused'
+                ELSE status
+            END
+        WHERE id = $1
+        RETURNING status, failures
+    `, s.ID, apiErr.code, schedules.MaxFailures).Scan(&status, &failures)
+	if err != nil {
+		log.Error("Failed to update schedule", zap.Error(err))
+		return
+	}
+
+	kind := oas.NotificationKindScheduleFailed
+	message := fmt.Sprintf("Scheduled transfer of %d coins to %s failed: %s", s.Amount, s.ToUser, apiErr.message)
+	if s.Recurrence != oas.RecurrenceOnce && status == string(oas.ScheduleStatusPaused) {
+		kind = oas.NotificationKindSchedulePaused
+		message += fmt.Sprintf(". The schedule is paused after %d failed transfers in a row", failures)
+	}
+	if err := notify(ctx, s.SenderID, kind, message, s.ID); err != nil {
+		log.Error("Failed to notify user", zap.Error(err))
+	}
+	log.Info("Scheduled transfer not executed", zap.String("code", apiErr.code), zap.String("status", status))
+}
+
+// Токен заблокированного пользователя отзывается, а расписание продолжает
+// работать, поэтому статус отправителя проверяется при каждом переводе
+func checkScheduleSender(ctx context.Context, senderID int) error {
+	var status string
+	if err := db.QueryRowContext(ctx, "SELECT status FROM users WHERE id = $1", senderID).Scan(&status); err != nil {
+		return internalError("Database error", err)
+	}
+	if status != statusActive {
+		return badRequest(codeAccountInactive, "Sender account is not active")
+	}
+	return nil
+}
+
+// Запускает выполнение наступивших переводов с периодом Interval до отмены ctx
+func startScheduler(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(schedules.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := runDueSchedules(ctx)
+				if err != nil {
+					logger.Error("Failed to run scheduled transfers", zap.Error(err))
+				}
+				if n > 0 {
+					logger.Info("Scheduled transfers processed", zap.Int("count", n))
+				}
+			}
+		}
+	}()
+}
+
+// Расписание с именем получателя для ответа
+const scheduleQuery = `
+    SELECT s.id, r.username, s.amount, s.message, s.category, s.public, s.use_budget, s.recurrence, s.status,
+        s.next_run_at, s.last_run_at, s.last_error, s.failures, s.created_at
+    FROM scheduled_transfers s
+    JOIN users r ON r.id = s.receiver_id
+`
+
+func scanSchedule(row interface{ Scan(...any) error }) (*oas.ScheduledTransfer, error) {
+	var s oas.ScheduledTransfer
+	var recurrence, status string
+	var message, category, lastError sql.NullString
+	var nextRunAt, lastRunAt sql.NullTime
+	err := row.Scan(&s.ID, &s.ToUser, &s.Amount, &message, &category, &s.Public, &s.UseBudget, &recurrence, &status,
+		&nextRunAt, &lastRunAt, &lastError, &s.Failures, &s.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.Recurrence, s.Status = oas.Recurrence(recurrence), oas.ScheduleStatus(status)
+	if message.Valid {
+		s.Message = oas.NewOptString(message.String)
+	}
+	if category.Valid {
+		s.Category = oas.NewOptTransferCategory(oas.TransferCategory(category.String))
+	}
+	if nextRunAt.Valid {
+		s.NextRunAt = oas.NewOptDateTime(nextRunAt.Time)
+	}
+	if lastRunAt.Valid {
+		s.LastRunAt = oas.NewOptDateTime(lastRunAt.Time)
+	}
+	if lastError.Valid {
+		s.LastError = oas.NewOptString(lastError.String)
+	}
+	return &s, nil
+}
+
+func (apiHandler) APISchedulesPost(ctx context.Context, req *oas.ScheduleRequest) (oas.APISchedulesPostRes, error) {
+	claims := claimsFromContext(ctx)
+	if err := mfa.requireForTransfer(claims, req.Amount); err != nil {
+		return nil, err
+	}
+	if !req.StartAt.After(time.Now()) {
+		return nil, validationFailed(oas.ErrorDetail{Field: "startAt", Code: fieldInvalid, Message: "must be in the future"})
+	}
+	recurrence := req.Recurrence.Or(oas.RecurrenceOnce)
+	note := newTransferNote(req.Message, req.Category, req.Public)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Строка отправителя блокируется, чтобы параллельные запросы не превысили MaxPerUser
+	var senderName string
+	if err := tx.QueryRowContext(ctx, "SELECT username FROM users WHERE id = $1 FOR UPDATE", claims.UserID).Scan(&senderName); err != nil {
+		return nil, internalError("Database error", err)
+	}
+	toUser := normalizeUsername(req.ToUser)
+	if toUser == normalizeUsername(senderName) {
+		return nil, badRequest(codeSelfTransfer, "Cannot send coins to yourself")
+	}
+	var receiverID int
+	var receiverStatus string
+	err = tx.QueryRowContext(ctx, "SELECT id, status FROM users WHERE lower(username) = $1", toUser).Scan(&receiverID, &receiverStatus)
+	if err == sql.ErrNoRows {
+		return nil, badRequest(codeReceiverNotFound, "Receiver not found")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if receiverStatus == statusDeactivated {
+		return nil, badRequest(codeReceiverInactive, "Receiver account is deactivated")
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM scheduled_transfers WHERE sender_id = $1 AND status IN ('active', 'paused')", claims.UserID).Scan(&count)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if count >= schedules.MaxPerUser {
+		return nil, conflict(codeTooManySchedules, fmt.Sprintf("No more than %d active schedules are allowed", schedules.MaxPerUser))
+	}
+
+	var id int
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO scheduled_transfers (sender_id, receiver_id, amount, message, category, public, use_budget, recurrence, start_at, next_run_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+        RETURNING id
+    `, claims.UserID, receiverID, req.Amount, note.Message, note.Category, note.Public, req.UseBudget.Or(false), string(recurrence), req.StartAt).Scan(&id)
+	if err != nil {
+		return nil, internalError("Failed to create schedule", err)
+	}
+	resp, err := scanSchedule(tx.QueryRowContext(ctx, scheduleQuery+"WHERE s.id = $1", id))
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+	requestLogger(ctx).Info("Transfer scheduled", zap.Int("schedule_id", id), zap.String("recurrence", string(recurrence)))
+	return resp, nil
+}
+
+func (apiHandler) APISchedulesGet(ctx context.Context) (oas.APISchedulesGetRes, error) {
+	userID := claimsFromContext(ctx).UserID
+	rows, err := db.QueryContext(ctx, scheduleQuery+"WHERE s.sender_id = $1 ORDER BY s.id DESC", userID)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	defer rows.Close()
+
+	resp := &oas.ScheduledTransferList{Schedules: []oas.ScheduledTransfer{}}
+	for rows.Next() {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return nil, internalError("Database error", err)
+		}
+		resp.Schedules = append(resp.Schedules, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, internalError("Database error", err)
+	}
+	return resp, nil
+}
+
+func (apiHandler) APISchedulesScheduleIdPausePost(ctx context.Context, params oas.APISchedulesScheduleIdPausePostParams) (oas.APISchedulesScheduleIdPausePostRes, error) {
+	resp, err := applyScheduleAction(ctx, params.ScheduleId, pauseSchedule)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (apiHandler) APISchedulesScheduleIdResumePost(ctx context.Context, params oas.APISchedulesScheduleIdResumePostParams) (oas.APISchedulesScheduleIdResumePostRes, error) {
+	resp, err := applyScheduleAction(ctx, params.ScheduleId, resumeSchedule)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (apiHandler) APISchedulesScheduleIdCancelPost(ctx context.Context, params oas.APISchedulesScheduleIdCancelPostParams) (oas.APISchedulesScheduleIdCancelPostRes, error) {
+	resp, err := applyScheduleAction(ctx, params.ScheduleId, cancelSchedule)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+func TestOccurrenceAt(t *testing.T) {
+	start := time.Date(2025, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, time.February, 14, 9, 0, 0, 0, time.UTC), occurrenceAt(start, oas.RecurrenceWeekly, 2))
+	assert.Equal(t, time.Date(2025, time.February, 28, 9, 0, 0, 0, time.UTC), occurrenceAt(start, oas.RecurrenceMonthly, 1), "В коротком месяце – последний день")
+	assert.Equal(t, time.Date(2025, time.March, 31, 9, 0, 0, 0, time.UTC), occurrenceAt(start, oas.RecurrenceMonthly, 2), "После короткого месяца день не сдвигается")
+	assert.Equal(t, time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC), occurrenceAt(start, oas.RecurrenceMonthly, 12))
+}
+
+func TestNextOccurrence(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	k, next, ok := nextOccurrence(start, oas.RecurrenceWeekly, 1, start)
+	assert.True(t, ok)
+	assert.Equal(t, 1, k)
+	assert.Equal(t, start.AddDate(0, 0, 7), next)
+
+	// Пропущенные повторы не выполняются
+	k, next, ok = nextOccurrence(start, oas.RecurrenceMonthly, 1, time.Date(2025, time.April, 15, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, 4, k)
+	assert.Equal(t, time.Date(2025, time.May, 1, 9, 0, 0, 0, time.UTC), next)
+
+	_, _, ok = nextOccurrence(start, oas.RecurrenceOnce, 1, start)
+	assert.False(t, ok, "У разового расписания повторов нет")
+}
+
+func TestInitSchedules(t *testing.T) {
+	defer func(saved schedulePolicy) { schedules = saved }(schedules)
+
+	t.Setenv("SCHEDULED_TRANSFER_MAX_FAILURES", "5")
+	assert.NoError(t, initSchedules())
+	assert.Equal(t, 5, schedules.MaxFailures)
+	assert.Equal(t, time.Minute, schedules.Interval)
+
+	t.Setenv("SCHEDULED_TRANSFER_INTERVAL", "0s")
+	assert.Error(t, initSchedules())
+}
+
+func TestAPISchedulesPost_PastStart(t *testing.T) {
+	stubUserState(t, 42, userState{Status: statusActive})
+
+	code, errResponse := performRawRequest(t, "POST", "/api/schedules", signTestToken(t, 42), `{"toUser":"bob","amount":10,"startAt":"2020-01-01T00:00:00Z"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Время в прошлом должно отклоняться до обращения к БД")
+	assert.Equal(t, []oas.ErrorDetail{{Field: "startAt", Code: fieldInvalid, Message: "must be in the future"}}, errResponse.Details)
+}
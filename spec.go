@@ -0,0 +1,176 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Спецификация, из которой сгенерирован сервер. Ошибки ogen о лишних полях
+// и границах чисел не типизированы, поэтому подробности таких ошибок
+// берутся из схемы тела запроса.
+//
+//go:embed api/schema.json
+var specJSON []byte
+
+// Часть JSON Schema, нужная для разбора ошибок запроса
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Minimum              *int64                 `json:"minimum"`
+	Maximum              *int64                 `json:"maximum"`
+}
+
+var (
+	requestSchemasOnce sync.Once
+	requestSchemas     map[oas.OperationName]*jsonSchema
+)
+
+// Схема тела запроса операции; nil – операция без тела или схема не разобрана
+func requestSchema(operation string) *jsonSchema {
+	requestSchemasOnce.Do(func() {
+		schemas, err := loadRequestSchemas()
+		if err != nil {
+			logger.Error("Failed to load request schemas", zap.Error(err))
+		}
+		requestSchemas = schemas
+	})
+	return requestSchemas[oas.OperationName(operation)]
+}
+
+var pathParamRe = regexp.MustCompile(`\{[^}]+\}`)
+
+// Сопоставляет операции сгенерированного сервера схемам тел запросов
+func loadRequestSchemas() (map[oas.OperationName]*jsonSchema, error) {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]struct {
+					Schema *jsonSchema `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]*jsonSchema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(specJSON, &spec); err != nil {
+		return nil, err
+	}
+	server, err := oas.NewServer(apiHandler{}, securityHandler{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ссылки заменяются схемами; циклических ссылок в спецификации нет
+	var resolve func(s *jsonSchema) *jsonSchema
+	resolve = func(s *jsonSchema) *jsonSchema {
+		if s == nil {
+			return nil
+		}
+		if s.Ref != "" {
+			return resolve(spec.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")])
+		}
+		for name, p := range s.Properties {
+			s.Properties[name] = resolve(p)
+		}
+		s.Items = resolve(s.Items)
+		return s
+	}
+
+	schemas := make(map[oas.OperationName]*jsonSchema)
+	for path, methods := range spec.Paths {
+		for method, op := range methods {
+			body, ok := op.RequestBody.Content["application/json"]
+			if !ok {
+				continue
+			}
+			route, ok := server.FindRoute(strings.ToUpper(method), pathParamRe.ReplaceAllString(path, "1"))
+			if !ok {
+				return schemas, fmt.Errorf("operation %s %s not found", method, path)
+			}
+			schemas[oas.OperationName(route.Name())] = resolve(body.Schema)
+		}
+	}
+	return schemas, nil
+}
+
+// Схема поля по имени из ошибки ogen: имя свойства или индекс вида [0]
+func (s *jsonSchema) field(name string) *jsonSchema {
+	switch {
+	case s == nil:
+		return nil
+	case strings.HasPrefix(name, "["):
+		return s.Items
+	}
+	return s.Properties[name]
+}
+
+// Описание границ числового поля; пустое – границ нет
+func (s *jsonSchema) rangeMessage() string {
+	if s == nil || (s.Type != "integer" && s.Type != "number") {
+		return ""
+	}
+	switch {
+	case s.Minimum != nil && s.Maximum != nil:
+		return fmt.Sprintf("must be between %d and %d", *s.Minimum, *s.Maximum)
+	case s.Minimum != nil:
+		return fmt.Sprintf("must be at least %d", *s.Minimum)
+	case s.Maximum != nil:
+		return fmt.Sprintf("must be at most %d", *s.Maximum)
+	}
+	return ""
+}
+
+// Ищет в теле запроса поля, которых нет в схеме объектов без
+// additionalProperties; так же их отвергает сгенерированный декодер
+func unknownFields(s *jsonSchema, body []byte) []oas.ErrorDetail {
+	var value any
+	if s == nil || json.Unmarshal(body, &value) != nil {
+		return nil
+	}
+	var details []oas.ErrorDetail
+	var walk func(s *jsonSchema, value any, prefix string)
+	walk = func(s *jsonSchema, value any, prefix string) {
+		if s == nil {
+			return
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				name := k
+				if prefix != "" {
+					name = prefix + "." + k
+				}
+				p, ok := s.Properties[k]
+				if !ok && s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					details = append(details, oas.ErrorDetail{Field: name, Code: fieldUnknown, Message: "unknown field"})
+					continue
+				}
+				walk(p, v[k], name)
+			}
+		case []any:
+			for i, item := range v {
+				walk(s.Items, item, fmt.Sprintf("%s[%d]", prefix, i))
+			}
+		}
+	}
+	walk(s, value, "")
+	return details
+}
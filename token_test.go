@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// Подписывает токен старого формата, содержащий только имя пользователя
+func signLegacyTestToken(t *testing.T, username string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		Username: username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	})
+	tokenString, err := token.SignedString(jwtKey)
+	if err != nil {
+		t.Fatalf("Не удалось подписать токен: %v", err)
+	}
+	return tokenString
+}
+
+func signTestClaims(t *testing.T, claims jwt.RegisteredClaims) string {
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{RegisteredClaims: claims}).SignedString(jwtKey)
+	if err != nil {
+		t.Fatalf("Не удалось подписать токен: %v", err)
+	}
+	return tokenString
+}
+
+// Подставляет состояние пользователя в кэш, чтобы проверка токена обходилась без БД
+func stubUserState(t testing.TB, userID int, state userState) {
+	state.expires = time.Now().Add(time.Hour)
+	userStates.Store(userID, state)
+	t.Cleanup(func() { forgetUserState(userID) })
+}
+
+func TestIssueToken(t *testing.T) {
+	tokenString := signTestToken(t, 42)
+
+	claims, err := validateToken(context.Background(), tokenString)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, claims.UserID)
+	assert.Equal(t, "42", claims.Subject)
+	assert.Equal(t, tokenIssuer, claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{tokenAudience}, claims.Audience)
+	assert.NotNil(t, claims.IssuedAt)
+	assert.NotEmpty(t, claims.ID)
+	assert.Empty(t, claims.Username, "Новый токен не должен содержать имя пользователя")
+	assert.False(t, claims.legacy())
+
+	other := signTestToken(t, 42)
+	assert.NotEqual(t, tokenString, other, "Токены должны различаться jti")
+}
+
+func TestValidateToken_RejectsForeignClaims(t *testing.T) {
+	expires := jwt.NewNumericDate(time.Now().Add(time.Minute))
+	tests := []struct {
+		name   string
+		claims jwt.RegisteredClaims
+	}{
+		{"Чужой издатель", jwt.RegisteredClaims{Subject: "1", Issuer: "other", Audience: jwt.ClaimStrings{tokenAudience}, ExpiresAt: expires}},
+		{"Чужой получатель", jwt.RegisteredClaims{Subject: "1", Issuer: tokenIssuer, Audience: jwt.ClaimStrings{"other"}, ExpiresAt: expires}},
+		{"Нечисловой sub", jwt.RegisteredClaims{Subject: "user1", Issuer: tokenIssuer, Audience: jwt.ClaimStrings{tokenAudience}, ExpiresAt: expires}},
+		{"Без срока действия", jwt.RegisteredClaims{Subject: "1", Issuer: tokenIssuer, Audience: jwt.ClaimStrings{tokenAudience}}},
+		{"Без пользователя", jwt.RegisteredClaims{ExpiresAt: expires}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseToken(context.Background(), signTestClaims(t, tt.claims))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateToken_LegacyCompatibility(t *testing.T) {
+	t.Cleanup(func() { acceptLegacyTokens = true })
+
+	claims, err := parseToken(context.Background(), signLegacyTestToken(t, "legacy_user1"))
+	assert.NoError(t, err, "Старые токены должны приниматься в переходный период")
+	assert.True(t, claims.legacy())
+	assert.Equal(t, "legacy_user1", claims.Username)
+
+	acceptLegacyTokens = false
+	_, err = parseToken(context.Background(), signLegacyTestToken(t, "legacy_user2"))
+	assert.Error(t, err, "После переходного периода старые токены должны отклоняться")
+}
+
+func TestValidateToken_UserState(t *testing.T) {
+	tokenString := signTestToken(t, 43)
+
+	_, err := validateToken(context.Background(), tokenString)
+	assert.NoError(t, err)
+
+	// Блокировка увеличивает версию токенов
+	stubUserState(t, 43, userState{Status: statusSuspended, TokenVersion: 1})
+	_, err = validateToken(context.Background(), tokenString)
+	var apiErr *apiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, codeInvalidToken, apiErr.code, "Токен, выданный до блокировки, должен быть отозван")
+	}
+
+	// Новый токен заблокированного пользователя тоже не принимается
+	claims, err := parseToken(context.Background(), tokenString)
+	assert.NoError(t, err)
+	suspended := *claims
+	suspended.TokenVersion = 1
+	err = checkUserState(context.Background(), &suspended)
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, codeAccountInactive, apiErr.code)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Сколько проверенных токенов хранится в памяти
+const tokenCacheSize = 100_000
+
+// Кэш проверенных токенов. Запись живёт не дольше самого токена, а при
+// переполнении вытесняется та, к которой дольше всего не обращались.
+type tokenClaimsCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// Записи от недавно использованных к давно не использованным
+	order *list.List
+	now   func() time.Time
+}
+
+type tokenCacheEntry struct {
+	token  string
+	claims *Claims
+}
+
+func newTokenClaimsCache(size int) *tokenClaimsCache {
+	return &tokenClaimsCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// Возвращает данные токена; истёкший токен удаляется и не находится
+func (c *tokenClaimsCache) Load(token string) (*Claims, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[token]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*tokenCacheEntry)
+	if expiresAt := entry.claims.ExpiresAt; expiresAt == nil || !c.now().Before(expiresAt.Time) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.claims, true
+}
+
+// Сохраняет данные токена; токены без срока действия не кэшируются
+func (c *tokenClaimsCache) Store(token string, claims *Claims) {
+	if claims.ExpiresAt == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[token]; ok {
+		element.Value.(*tokenCacheEntry).claims = claims
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[token] = c.order.PushFront(&tokenCacheEntry{token: token, claims: claims})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *tokenClaimsCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*tokenCacheEntry).token)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenClaimsCache_Expiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newTokenClaimsCache(10)
+	cache.now = func() time.Time { return now }
+
+	claims := &Claims{UserID: 1, RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute))}}
+	cache.Store("token", claims)
+	cached, ok := cache.Load("token")
+	assert.True(t, ok, "Действующий токен должен находиться в кэше")
+	assert.Same(t, claims, cached)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Load("token")
+	assert.False(t, ok, "Истёкший токен не должен возвращаться из кэша")
+	assert.Empty(t, cache.entries, "Истёкшая запись должна удаляться")
+
+	cache.Store("no-exp", &Claims{UserID: 2})
+	_, ok = cache.Load("no-exp")
+	assert.False(t, ok, "Токен без срока действия не кэшируется")
+}
+
+func TestTokenClaimsCache_Bounded(t *testing.T) {
+	cache := newTokenClaimsCache(2)
+	expiresAt := jwt.NewNumericDate(time.Now().Add(time.Hour))
+	claims := func(id int) *Claims {
+		return &Claims{UserID: id, RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: expiresAt}}
+	}
+
+	cache.Store("a", claims(1))
+	cache.Store("b", claims(2))
+	cache.Load("a")
+	cache.Store("c", claims(3))
+
+	_, ok := cache.Load("b")
+	assert.False(t, ok, "Вытесняется запись, к которой дольше всего не обращались")
+	for _, token := range []string{"a", "c"} {
+		_, ok := cache.Load(token)
+		assert.True(t, ok, fmt.Sprintf("Запись %s должна остаться", token))
+	}
+	assert.Equal(t, 2, cache.order.Len())
+}
+
+func TestParseToken_ExpiredCacheHit(t *testing.T) {
+	defer func(saved *tokenClaimsCache) { tokenCache = saved }(tokenCache)
+	tokenCache = newTokenClaimsCache(10)
+
+	// Токен попал в кэш, пока действовал, и с тех пор истёк
+	registered := jwt.RegisteredClaims{Subject: "1", Issuer: tokenIssuer, Audience: jwt.ClaimStrings{tokenAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Second))}
+	token := signTestClaims(t, registered)
+	tokenCache.Store(token, &Claims{UserID: 1, RegisteredClaims: registered})
+
+	_, err := parseToken(context.Background(), token)
+	assert.Error(t, err, "Истёкший токен из кэша должен отклоняться")
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lib/pq"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Параметры TOTP по RFC 6238, которые поддерживают все распространённые
+// приложения-аутентификаторы
+const (
+	totpIssuer = "avito-shop"
+	totpPeriod = 30
+	// Допустимое расхождение часов клиента и сервера в шагах
+	totpSkew = 1
+)
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// Токен второго шага входа подписывается тем же ключом, но для другого
+// получателя, поэтому parseToken не принимает его как токен API
+const mfaTokenAudience = "avito-shop-mfa"
+
+// Количество кодов восстановления, выдаваемых при подключении TOTP
+const recoveryCodeCount = 10
+
+// Когда вход со вторым фактором обязателен
+type mfaPolicy struct {
+	// Административные операции
+	RequireForAdmins bool
+	// Переводы на сумму не меньше порога; 0 – без ограничений
+	TransferThreshold int
+	// Время на ввод кода после проверки пароля
+	TokenTTL time.Duration
+}
+
+var mfa = mfaPolicy{TokenTTL: 5 * time.Minute}
+
+// Читает MFA_REQUIRED_FOR_ADMINS, MFA_TRANSFER_THRESHOLD и MFA_TOKEN_TTL
+func initMFA() error {
+	var env envReader
+	policy := mfaPolicy{
+		RequireForAdmins:  envString("MFA_REQUIRED_FOR_ADMINS", "false") == "true",
+		TransferThreshold: env.Int("MFA_TRANSFER_THRESHOLD", 0),
+		TokenTTL:          env.Duration("MFA_TOKEN_TTL", 5*time.Minute),
+	}
+	if err := env.Err(); err != nil {
+		return err
+	}
+	if policy.TransferThreshold < 0 {
+		return fmt.Errorf("invalid MFA transfer threshold %d", policy.TransferThreshold)
+	}
+	mfa = policy
+	return nil
+}
+
+// Проверяет, что операция выполняется с токеном, полученным со вторым фактором.
+// Сервисному аккаунту второй фактор недоступен: его API-ключ с разрешением
+// grant:coins выдаёт администратор, и такой ключ переводит без порога.
+func (p mfaPolicy) requireForTransfer(claims *Claims, amount int) error {
+	if claims.APIKeyID != 0 && slices.Contains(claims.Scopes, string(oas.ApiKeyScopeGrantCoins)) {
+		return nil
+	}
+	if p.TransferThreshold > 0 && amount >= p.TransferThreshold && !claims.MFA {
+		return forbidden(codeMfaRequired, "Two-factor authentication is required for transfers of "+strconv.Itoa(p.TransferThreshold)+" coins or more")
+	}
+	return nil
+}
+
+// Ищет шаг времени, которому соответствует код, в пределах totpSkew
+func matchTOTP(secret, code string, now time.Time) (int64, bool) {
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totpOpts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != int(totpOpts.Digits) {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Коды восстановления выдаются в виде xxxxx-xxxxx; дефисы, пробелы и регистр
+// при вводе не важны
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashSecret(code)
+}
+
+// Включена ли у пользователя двухфакторная аутентификация
+func totpEnabled(ctx context.Context, userID int) (bool, error) {
+	var enabled bool
+	err := db.QueryRowContext(ctx, "SELECT enabled_at IS NOT NULL FROM totp_credentials WHERE user_id = $1", userID).Scan(&enabled)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return enabled, err
+}
+
+// Проверяет TOTP-код или код восстановления. Каждый код принимается
+// только один раз.
+func verifySecondFactor(ctx context.Context, userID int, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if !isTOTPCode(code) {
+		result, err := db.ExecContext(ctx, `
+            UPDATE recovery_codes SET used_at = NOW()
+            WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+        `, userID, hashRecoveryCode(code))
+		if err != nil {
+			return false, err
+		}
+		n, _ := result.RowsAffected()
+		return n == 1, nil
+	}
+
+	var secret string
+	err := db.QueryRowContext(ctx, "SELECT secret FROM totp_credentials WHERE user_id = $1 AND enabled_at IS NOT NULL", userID).Scan(&secret)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	step, ok := matchTOTP(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	// Шаг запоминается условным обновлением, чтобы перехваченный код нельзя
+	// было использовать повторно, в том числе параллельным запросом
+	result, err := db.ExecContext(ctx, `
+        UPDATE totp_credentials SET last_step = $2
+        WHERE user_id = $1 AND (last_step IS NULL OR last_step < $2)
+    `, userID, step)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n == 1, nil
+}
+
+// Завершает проверку пароля: выдаёт токен или, если подключён TOTP, токен
+// второго шага. Неудачные попытки сбрасываются только после последнего шага,
+// чтобы знание пароля не позволяло перебирать коды без блокировки.
+func completeLogin(ctx context.Context, username string, userID int) (*oas.AuthResponse, error) {
+	enabled, err := totpEnabled(ctx, userID)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if enabled {
+		mfaToken, err := signToken(ctx, userID, mfaTokenAudience, mfa.TokenTTL, false)
+		if err != nil {
+			return nil, internalError("Failed to create token", err)
+		}
+		return &oas.AuthResponse{MfaRequired: oas.NewOptBool(true), MfaToken: oas.NewOptString(mfaToken)}, nil
+	}
+
+	resetLoginFailures(ctx, username)
+	tokenString, err := issueToken(ctx, userID)
+	if err != nil {
+		return nil, internalError("Failed to create token", err)
+	}
+	return &oas.AuthResponse{Token: oas.NewOptString(tokenString)}, nil
+}
+
+// Проверяет токен второго шага входа
+func parseMFAToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if claims.Issuer != tokenIssuer || !slices.Contains(claims.Audience, mfaTokenAudience) {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if claims.UserID, err = strconv.Atoi(claims.Subject); err != nil || claims.UserID <= 0 {
+		return nil, fmt.Errorf("invalid token")
+	}
+	return claims, nil
+}
+
+// Имя пользователя для учёта неудачных попыток
+func lockoutUsername(ctx context.Context, userID int) (string, error) {
+	var username string
+	err := db.QueryRowContext(ctx, "SELECT lower(username) FROM users WHERE id = $1", userID).Scan(&username)
+	if err != nil {
+		return "", internalError("Database error", err)
+	}
+	return username, nil
+}
+
+func (apiHandler) APIAuth2FAPost(ctx context.Context, req *oas.MfaLoginRequest) (oas.APIAuth2FAPostRes, error) {
+	claims, err := parseMFAToken(req.MfaToken)
+	if err != nil {
+		return nil, unauthorized(codeInvalidToken, "Invalid or expired MFA token")
+	}
+	// Блокировка или смена пароля после первого шага отзывает токен второго
+	if err := checkUserState(ctx, claims); err != nil {
+		return nil, err
+	}
+	setRequestUserID(ctx, claims.UserID)
+
+	username, err := lockoutUsername(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	setRequestUser(ctx, username)
+	if err := checkLoginLockout(ctx, username); err != nil {
+		return nil, err
+	}
+
+	ok, err := verifySecondFactor(ctx, claims.UserID, req.Code)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if !ok {
+		if err := recordLoginFailure(ctx, username); err != nil {
+			return nil, err
+		}
+		return nil, unauthorized(codeInvalidMfaCode, "Invalid code")
+	}
+	resetLoginFailures(ctx, username)
+
+	tokenString, err := signToken(ctx, claims.UserID, tokenAudience, tokenTTL, true)
+	if err != nil {
+		return nil, internalError("Failed to create token", err)
+	}
+	return &oas.AuthResponse{Token: oas.NewOptString(tokenString)}, nil
+}
+
+func (apiHandler) API2FATotpPost(ctx context.Context) (oas.API2FATotpPostRes, error) {
+	claims := claimsFromContext(ctx)
+
+	username, err := lockoutUsername(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      totpIssuer,
+		AccountName: username,
+		Period:      totpPeriod,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		return nil, internalError("Failed to generate secret", err)
+	}
+
+	// Повторное начало подключения заменяет неподтверждённый секрет
+	var userID int
+	err = db.QueryRowContext(ctx, `
+        INSERT INTO totp_credentials (user_id, secret) VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_step = NULL, created_at = NOW()
+        WHERE totp_credentials.enabled_at IS NULL
+        RETURNING user_id
+    `, claims.UserID, key.Secret()).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return nil, conflict(codeMfaAlreadyEnabled, "Two-factor authentication is already enabled")
+	}
+	if err != nil {
+		return nil, internalError("Failed to store secret", err)
+	}
+
+	return &oas.TotpEnrollResponse{Secret: key.Secret(), OtpauthUri: key.URL()}, nil
+}
+
+func (apiHandler) API2FATotpConfirmPost(ctx context.Context, req *oas.MfaCodeRequest) (oas.API2FATotpConfirmPostRes, error) {
+	claims := claimsFromContext(ctx)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	var secret string
+	var enabled bool
+	err = tx.QueryRowContext(ctx, "SELECT secret, enabled_at IS NOT NULL FROM totp_credentials WHERE user_id = $1 FOR UPDATE", claims.UserID).Scan(&secret, &enabled)
+	if err == sql.ErrNoRows {
+		return nil, badRequest(codeMfaNotEnrolled, "Two-factor enrolment has not been started")
+	}
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if enabled {
+		return nil, conflict(codeMfaAlreadyEnabled, "Two-factor authentication is already enabled")
+	}
+
+	step, ok := matchTOTP(secret, strings.TrimSpace(req.Code), time.Now())
+	if !ok {
+		return nil, badRequest(codeInvalidMfaCode, "Invalid code")
+	}
+	if _, err = tx.ExecContext(ctx, "UPDATE totp_credentials SET enabled_at = NOW(), last_step = $2 WHERE user_id = $1", claims.UserID, step); err != nil {
+		return nil, internalError("Failed to enable two-factor authentication", err)
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = newRecoveryCode(); err != nil {
+			return nil, internalError("Failed to generate recovery codes", err)
+		}
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	if _, err = tx.ExecContext(ctx, "DELETE FROM recovery_codes WHERE user_id = $1", claims.UserID); err != nil {
+		return nil, internalError("Failed to revoke recovery codes", err)
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO recovery_codes (user_id, code_hash) SELECT $1, unnest($2::text[])", claims.UserID, pq.Array(hashes))
+	if err != nil {
+		return nil, internalError("Failed to store recovery codes", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+
+	return &oas.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (apiHandler) API2FATotpDisablePost(ctx context.Context, req *oas.MfaCodeRequest) (oas.API2FATotpDisablePostRes, error) {
+	claims := claimsFromContext(ctx)
+
+	enabled, err := totpEnabled(ctx, claims.UserID)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if !enabled {
+		return nil, badRequest(codeMfaNotEnrolled, "Two-factor authentication is not enabled")
+	}
+
+	// Код подбирается с тем же ограничением, что и при входе, на случай
+	// перехваченного токена
+	username, err := lockoutUsername(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLoginLockout(ctx, username); err != nil {
+		return nil, err
+	}
+	ok, err := verifySecondFactor(ctx, claims.UserID, req.Code)
+	if err != nil {
+		return nil, internalError("Database error", err)
+	}
+	if !ok {
+		if err := recordLoginFailure(ctx, username); err != nil {
+			return nil, err
+		}
+		return nil, badRequest(codeInvalidMfaCode, "Invalid code")
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+	if _, err = tx.ExecContext(ctx, "DELETE FROM recovery_codes WHERE user_id = $1", claims.UserID); err != nil {
+		return nil, internalError("Failed to delete recovery codes", err)
+	}
+	if _, err = tx.ExecContext(ctx, "DELETE FROM totp_credentials WHERE user_id = $1", claims.UserID); err != nil {
+		return nil, internalError("Failed to disable two-factor authentication", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, internalError("Failed to commit transaction", err)
+	}
+
+	return &oas.MfaStatusResponse{Enabled: false}, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func testTOTPCode(t *testing.T, at time.Time) string {
+	code, err := totp.GenerateCodeCustom(testTOTPSecret, at, totpOpts)
+	if err != nil {
+		t.Fatalf("Не удалось сгенерировать код: %v", err)
+	}
+	return code
+}
+
+func TestMatchTOTP(t *testing.T) {
+	now := time.Unix(1_700_000_010, 0)
+	current := now.Unix() / totpPeriod
+
+	step, ok := matchTOTP(testTOTPSecret, testTOTPCode(t, now), now)
+	assert.True(t, ok, "Код текущего шага должен приниматься")
+	assert.Equal(t, current, step)
+
+	step, ok = matchTOTP(testTOTPSecret, testTOTPCode(t, now.Add(-totpPeriod*time.Second)), now)
+	assert.True(t, ok, "Допускается расхождение часов на один шаг")
+	assert.Equal(t, current-1, step)
+
+	_, ok = matchTOTP(testTOTPSecret, testTOTPCode(t, now.Add(-2*totpPeriod*time.Second)), now)
+	assert.False(t, ok, "Устаревший код не должен приниматься")
+
+	_, ok = matchTOTP(testTOTPSecret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	code, err := newRecoveryCode()
+	assert.NoError(t, err)
+	assert.Len(t, code, 11, "Код в виде xxxxx-xxxxx")
+	assert.Equal(t, byte('-'), code[5])
+	assert.False(t, isTOTPCode(code), "Код восстановления не должен приниматься за TOTP-код")
+
+	assert.Equal(t, hashRecoveryCode(code), hashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(code, "-", ""))),
+		"Дефисы, пробелы и регистр не должны влиять на проверку")
+	assert.True(t, isTOTPCode("012345"))
+}
+
+func TestMFAToken_NotAcceptedByAPI(t *testing.T) {
+	stubUserState(t, 42, userState{Status: statusActive})
+
+	mfaToken, err := signToken(context.Background(), 42, mfaTokenAudience, time.Minute, false)
+	assert.NoError(t, err)
+	_, err = parseToken(context.Background(), mfaToken)
+	assert.Error(t, err, "Токен второго шага не должен давать доступ к API")
+	claims, err := parseMFAToken(mfaToken)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, claims.UserID)
+
+	_, err = parseMFAToken(signTestToken(t, 42))
+	assert.Error(t, err, "Токен API не должен приниматься на втором шаге")
+
+	code, errResponse := performRawRequest(t, "POST", "/api/auth/2fa", "", `{"mfaToken":"`+signTestToken(t, 42)+`","code":"123456"}`)
+	assert.Equal(t, http.StatusUnauthorized, code, "Ожидался код 401")
+	assert.Equal(t, codeInvalidToken, errResponse.Code.Value)
+}
+
+func TestMFAPolicy_Transfers(t *testing.T) {
+	defer func(saved mfaPolicy) { mfa = saved }(mfa)
+	mfa.TransferThreshold = 100
+
+	code, errResponse := performRawRequest(t, "POST", "/api/sendCoin", signTestToken(t, 42), `{"toUser":"user2","amount":100}`)
+	assert.Equal(t, http.StatusForbidden, code, "Крупный перевод без второго фактора должен отклоняться")
+	assert.Equal(t, codeMfaRequired, errResponse.Code.Value)
+
+	assert.NoError(t, mfa.requireForTransfer(&Claims{}, 99))
+	assert.NoError(t, mfa.requireForTransfer(&Claims{MFA: true}, 100))
+	assert.NoError(t, mfa.requireForTransfer(&Claims{APIKeyID: 1, Scopes: []string{"grant:coins"}}, 100),
+		"Ключ сервисного аккаунта с grant:coins не требует второго фактора")
+	assert.Error(t, mfa.requireForTransfer(&Claims{APIKeyID: 1, Scopes: []string{"read:info"}}, 100),
+		"Без grant:coins порог действует и для ключа")
+	assert.Error(t, mfa.requireForTransfer(&Claims{Scopes: []string{"grant:coins"}}, 100),
+		"Разрешения учитываются только у API-ключей")
+	mfa.TransferThreshold = 0
+	assert.NoError(t, mfa.requireForTransfer(&Claims{}, 1_000_000), "Нулевой порог отключает требование")
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Баланс и статус пользователя, строка которого заблокирована в транзакции
+type account struct {
+	ID       int
+	Username string
+	Coins    int
+	Status   string
+}
+
+// Блокирует строки пользователей до конца транзакции. Блокировки берутся
+// в порядке id, поэтому встречные переводы между одними и теми же
+// пользователями ждут друг друга, а не взаимоблокируются.
+func lockAccounts(ctx context.Context, tx *sql.Tx, ids ...int) (map[int]*account, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, username, coins, status FROM users
+        WHERE id = ANY($1)
+        ORDER BY id
+        FOR UPDATE
+    `, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make(map[int]*account, len(ids))
+	for rows.Next() {
+		a := &account{}
+		if err := rows.Scan(&a.ID, &a.Username, &a.Coins, &a.Status); err != nil {
+			return nil, err
+		}
+		accounts[a.ID] = a
+	}
+	return accounts, rows.Err()
+}
+
+// Списывает монеты, если их хватает; баланс проверяется тем же оператором.
+// Возвращает списанные части партий, начиная с самых старых.
+func debit(ctx context.Context, tx *sql.Tx, userID, amount int) ([]lotPortion, error) {
+	var balance int
+	err := tx.QueryRowContext(ctx, "UPDATE users SET coins = coins - $1 WHERE id = $2 AND coins >= $1 RETURNING coins + $1", amount, userID).Scan(&balance)
+	if err == sql.ErrNoRows {
+		return nil, badRequest(codeNotEnoughCoins, "Not enough coins")
+	}
+	if err != nil {
+		return nil, internalError("Failed to update sender balance", err)
+	}
+	portions, err := takeLots(ctx, tx, userID, balance, amount)
+	if err != nil {
+		return nil, internalError("Failed to update sender balance", err)
+	}
+	return portions, nil
+}
+
+// Зачисляет части партий. Отрицательный баланс сначала погашается самыми
+// старыми из них.
+func credit(ctx context.Context, tx *sql.Tx, userID int, portions []lotPortion) error {
+	amount := sumPortions(portions)
+	var balance int
+	err := tx.QueryRowContext(ctx, "UPDATE users SET coins = coins + $1 WHERE id = $2 RETURNING coins - $1", amount, userID).Scan(&balance)
+	if err != nil {
+		return internalError("Failed to update receiver balance", err)
+	}
+	if balance < 0 {
+		_, portions = splitPortions(portions, -balance)
+	}
+	if err := insertLots(ctx, tx, userID, 0, portions); err != nil {
+		return internalError("Failed to update receiver balance", err)
+	}
+	return nil
+}
+
+// Один перевод в составе операции
+type transferLeg struct {
+	ToUser string
+	Amount int
+	// Поле запроса для details в ответе об ошибке; пустое – без details
+	Field string
+
+	ReceiverID int
+	Status     oas.TransferStatus
+	// Выполненный перевод или перевод, ожидающий подтверждения
+	TransactionID     int
+	PendingTransferID int
+	// Сколько монет взято из бюджета на благодарности
+	Budget int
+	// Списанные с отправителя части партий, без бюджета
+	Lots []lotPortion
+}
+
+// Переводит монеты от отправителя всем получателям в одной транзакции:
+// либо выполняются все переводы, либо ни один. Переводы, которые должен
+// подтвердить получатель или одобрить руководитель, откладываются, а монеты
+// резервируются списанием с отправителя. С useBudget монеты сначала берутся
+// из бюджета на благодарности. Ошибки получателей собираются в details,
+// ответ получает код первой из них.
+func executeTransfers(ctx context.Context, senderID int, legs []transferLeg, note transferNote, useBudget bool) error {
+	names := make([]string, len(legs))
+	total := 0
+	for i := range legs {
+		legs[i].ToUser = normalizeUsername(legs[i].ToUser)
+		names[i] = legs[i].ToUser
+		total += legs[i].Amount
+	}
+
+	// Получатели ищутся до начала транзакции, чтобы блокировать строки одним запросом
+	receivers := make(map[string]int, len(legs))
+	rows, err := db.QueryContext(ctx, "SELECT id, lower(username) FROM users WHERE lower(username) = ANY($1)", pq.Array(names))
+	if err != nil {
+		return internalError("Database error", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return internalError("Database error", err)
+		}
+		receivers[name] = id
+	}
+	if err := rows.Err(); err != nil {
+		return internalError("Database error", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return internalError("Failed to start transaction", err)
+	}
+	defer tx.Rollback()
+
+	ids := []int{senderID}
+	for _, id := range receivers {
+		ids = append(ids, id)
+	}
+	accounts, err := lockAccounts(ctx, tx, ids...)
+	if err != nil {
+		return internalError("Database error", err)
+	}
+	sender, ok := accounts[senderID]
+	if !ok {
+		return unauthorized(codeAccountInactive, "Account is not active")
+	}
+
+	// Проверки в том же порядке, что и у одиночного перевода: сначала
+	// перевод самому себе, затем баланс, получатели и ограничения переводов
+	failed := &transferFailure{}
+	for i := range legs {
+		if legs[i].ToUser == normalizeUsername(sender.Username) {
+			failed.add(legs[i], badRequest(codeSelfTransfer, "Cannot send coins to yourself"))
+		}
+	}
+	if err := failed.err(); err != nil {
+		return err
+	}
+	budget := 0
+	if useBudget {
+		if budget, err = givingBudgets.lock(ctx, tx, senderID); err != nil {
+			return internalError("Database error", err)
+		}
+	}
+	if sender.Coins+budget < total {
+		return badRequest(codeNotEnoughCoins, "Not enough coins")
+	}
+	for i := range legs {
+		id, ok := receivers[legs[i].ToUser]
+		switch {
+		case !ok || accounts[id] == nil:
+			failed.add(legs[i], badRequest(codeReceiverNotFound, "Receiver not found"))
+		case accounts[id].Status == statusDeactivated:
+			failed.add(legs[i], badRequest(codeReceiverInactive, "Receiver account is deactivated"))
+		default:
+			legs[i].ReceiverID = id
+		}
+	}
+	if err := failed.err(); err != nil {
+		return err
+	}
+	if err := transferLimits.check(ctx, tx, senderID, legs); err != nil {
+		return err
+	}
+
+	// Бюджет расходуется по получателям в порядке запроса
+	fromBudget := min(budget, total)
+	if fromBudget > 0 {
+		if err := givingBudgets.spend(ctx, tx, senderID, fromBudget); err != nil {
+			return internalError("Failed to update giving budget", err)
+		}
+	}
+	var lots []lotPortion
+	if total > fromBudget {
+		if lots, err = debit(ctx, tx, senderID, total-fromBudget); err != nil {
+			return err
+		}
+	}
+	for i := range legs {
+		leg := &legs[i]
+		leg.Budget = min(fromBudget, leg.Amount)
+		fromBudget -= leg.Budget
+		leg.Lots, lots = splitPortions(lots, leg.Amount-leg.Budget)
+		if leg.Status = pendingTransfers.stage(leg.Amount); leg.Status != oas.TransferStatusCompleted {
+			if leg.PendingTransferID, err = createPendingTransfer(ctx, tx, senderID, *leg, note); err != nil {
+				return internalError("Failed to record pending transfer", err)
+			}
+			continue
+		}
+		// Монеты из бюджета становятся у получателя новым начислением
+		if err := credit(ctx, tx, leg.ReceiverID, append(leg.Lots, freshLot(leg.Budget)...)); err != nil {
+			return err
+		}
+		if leg.TransactionID, err = recordTransfer(ctx, tx, senderID, leg.ReceiverID, leg.Amount, leg.Budget, note); err != nil {
+			return internalError("Failed to record transaction", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return internalError("Failed to commit transaction", err)
+	}
+
+	invalidate := []int{senderID}
+	for _, leg := range legs {
+		if leg.Status == oas.TransferStatusCompleted {
+			transfersTotal.Inc()
+			coinsTransferredTotal.Add(float64(leg.Amount))
+			invalidate = append(invalidate, leg.ReceiverID)
+		}
+	}
+	userInfoCache.Invalidate(ctx, invalidate...)
+	return nil
+}
+
+// Записывает выполненный перевод в историю
+func recordTransfer(ctx context.Context, tx *sql.Tx, senderID, receiverID, amount, budget int, note transferNote) (int, error) {
+	var transactionID int
+	err := tx.QueryRowContext(ctx, `
+        INSERT INTO transactions (sender_id, receiver_id, amount, budget_amount, message, category, public)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id
+    `, senderID, receiverID, amount, budget, note.Message, note.Category, note.Public).Scan(&transactionID)
+	return transactionID, err
+}
+
+// Ошибки отдельных получателей перевода
+type transferFailure struct {
+	first   *apiError
+	details []oas.ErrorDetail
+}
+
+func (f *transferFailure) add(leg transferLeg, err *apiError) {
+	if f.first == nil {
+		f.first = err
+	}
+	if leg.Field != "" {
+		f.details = append(f.details, oas.ErrorDetail{Field: leg.Field, Code: err.code, Message: err.message})
+	}
+}
+
+func (f *transferFailure) err() error {
+	if f.first == nil {
+		return nil
+	}
+	f.first.details = f.details
+	return f.first
+}
+
+// Раскладывает запрос пакетного перевода на отдельные переводы
+func batchLegs(req *oas.BatchSendCoinRequest) ([]transferLeg, error) {
+	legs := make([]transferLeg, len(req.Transfers))
+	seen := make(map[string]bool, len(req.Transfers))
+	var details []oas.ErrorDetail
+	for i, transfer := range req.Transfers {
+		legs[i] = transferLeg{ToUser: transfer.ToUser, Amount: transfer.Amount.Or(0), Field: fmt.Sprintf("transfers[%d].toUser", i)}
+		name := normalizeUsername(transfer.ToUser)
+		if seen[name] {
+			details = append(details, oas.ErrorDetail{Field: legs[i].Field, Code: fieldInvalid, Message: "duplicate recipient"})
+		}
+		seen[name] = true
+
+		// Сумма задаётся либо у каждого получателя, либо общей суммой
+		field := fmt.Sprintf("transfers[%d].amount", i)
+		switch _, set := req.TotalAmount.Get(); {
+		case set && transfer.Amount.Set:
+			details = append(details, oas.ErrorDetail{Field: field, Code: fieldInvalid, Message: "must not be set together with totalAmount"})
+		case !set && !transfer.Amount.Set:
+			details = append(details, oas.ErrorDetail{Field: field, Code: fieldRequired, Message: "required without totalAmount"})
+		}
+	}
+
+	if total, ok := req.TotalAmount.Get(); ok && len(details) == 0 {
+		if total < len(legs) {
+			details = append(details, oas.ErrorDetail{Field: "totalAmount", Code: fieldOutOfRange, Message: "must be at least one coin per recipient"})
+		}
+		for i := range legs {
+			legs[i].Amount = total / len(legs)
+			if i < total%len(legs) {
+				legs[i].Amount++
+			}
+		}
+	}
+
+	if len(details) > 0 {
+		return nil, validationFailed(details...)
+	}
+	return legs, nil
+}
+
+func (apiHandler) APISendCoinBatchPost(ctx context.Context, req *oas.BatchSendCoinRequest) (oas.APISendCoinBatchPostRes, error) {
+	claims := claimsFromContext(ctx)
+	legs, err := batchLegs(req)
+	if err != nil {
+		return nil, err
+	}
+	total := 0
+	for _, leg := range legs {
+		total += leg.Amount
+	}
+	if err := mfa.requireForTransfer(claims, total); err != nil {
+		return nil, err
+	}
+
+	note := newTransferNote(req.Message, req.Category, req.Public)
+	if err := executeTransfers(ctx, claims.UserID, legs, note, req.UseBudget.Or(false)); err != nil {
+		return nil, err
+	}
+
+	resp := &oas.BatchSendCoinResponse{TotalAmount: total, Transfers: make([]oas.BatchTransferResult, len(legs))}
+	for i, leg := range legs {
+		result := oas.BatchTransferResult{ToUser: leg.ToUser, Amount: leg.Amount, Status: leg.Status}
+		if leg.TransactionID != 0 {
+			result.TransactionId = oas.NewOptInt(leg.TransactionID)
+		}
+		if leg.PendingTransferID != 0 {
+			result.PendingTransferId = oas.NewOptInt(leg.PendingTransferID)
+		}
+		if leg.Budget != 0 {
+			result.FromBudget = oas.NewOptInt(leg.Budget)
+		}
+		resp.Transfers[i] = result
+	}
+	return resp, nil
+}
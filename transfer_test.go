@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+func TestBatchLegs_EvenSplit(t *testing.T) {
+	req := &oas.BatchSendCoinRequest{
+		Transfers:   []oas.BatchTransfer{{ToUser: "alice"}, {ToUser: "bob"}, {ToUser: "carol"}},
+		TotalAmount: oas.NewOptInt(100),
+	}
+	legs, err := batchLegs(req)
+	assert.NoError(t, err)
+	amounts := []int{legs[0].Amount, legs[1].Amount, legs[2].Amount}
+	assert.Equal(t, []int{34, 33, 33}, amounts, "Остаток достаётся первым получателям")
+	assert.Equal(t, "transfers[1].toUser", legs[1].Field)
+
+	req.TotalAmount = oas.NewOptInt(2)
+	_, err = batchLegs(req)
+	assert.Equal(t, "totalAmount", err.(*apiError).details[0].Field, "Каждому получателю нужна хотя бы одна монета")
+}
+
+func TestSendCoinBatch_Validation(t *testing.T) {
+	stubUserState(t, 42, userState{Status: statusActive})
+	token := signTestToken(t, 42)
+
+	tests := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{"повтор получателя", `{"transfers":[{"toUser":"bob","amount":1},{"toUser":" Bob ","amount":1}]}`, "transfers[1].toUser"},
+		{"нет суммы", `{"transfers":[{"toUser":"bob"}]}`, "transfers[0].amount"},
+		{"обе суммы", `{"transfers":[{"toUser":"bob","amount":1}],"totalAmount":5}`, "transfers[0].amount"},
+		{"пустой список", `{"transfers":[]}`, "transfers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, errResponse := performRawRequest(t, "POST", "/api/sendCoin/batch", token, tt.body)
+			assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+			assert.Equal(t, codeValidationFailed, errResponse.Code.Value)
+			if assert.NotEmpty(t, errResponse.Details) {
+				assert.Equal(t, tt.field, errResponse.Details[0].Field)
+			}
+		})
+	}
+}
+
+func TestTransferPolicy_MaxAmount(t *testing.T) {
+	policy := transferPolicy{MaxAmount: 100}
+	legs := []transferLeg{{ToUser: "alice", Amount: 100, Field: "transfers[0].toUser"}, {ToUser: "bob", Amount: 101, Field: "transfers[1].toUser"}}
+
+	// Проверка суммы не обращается к БД
+	err := policy.check(context.Background(), nil, 42, legs)
+	apiErr := err.(*apiError)
+	assert.Equal(t, codeAmountLimitExceeded, apiErr.code)
+	assert.Len(t, apiErr.details, 1)
+	assert.Equal(t, "transfers[1].toUser", apiErr.details[0].Field)
+
+	assert.NoError(t, transferPolicy{}.check(context.Background(), nil, 42, legs), "Нулевые значения отключают ограничения")
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/ogen-go/ogen/validate"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sozmeme/avito-test-winter/internal/oas"
+)
+
+// Выполняет запрос с сырым телом и разбирает ErrorResponse
+func performRawRequest(t *testing.T, method, path, token, body string) (int, oas.ErrorResponse) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	recorder := httptest.NewRecorder()
+	NewRouter().ServeHTTP(recorder, req)
+
+	var errResponse oas.ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &errResponse); err != nil {
+		t.Fatalf("Ошибка при парсинге JSON: %v", err)
+	}
+	return recorder.Code, errResponse
+}
+
+// Подписывает токен без обращения к БД
+func signTestToken(t testing.TB, userID int) string {
+	stubUserState(t, userID, userState{Status: statusActive})
+	tokenString, err := issueToken(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("Не удалось подписать токен: %v", err)
+	}
+	return tokenString
+}
+
+func TestValidation_UnknownField(t *testing.T) {
+	code, errResponse := performRawRequest(t, "POST", "/api/auth", "",
+		`{"username":"testuser","password":"testpassword","admin":true}`)
+
+	assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+	assert.Equal(t, codeValidationFailed, errResponse.Code.Value)
+	assert.Equal(t, []oas.ErrorDetail{{Field: "admin", Code: fieldUnknown, Message: "unknown field"}}, errResponse.Details)
+	assert.NotEmpty(t, errResponse.Errors.Value, "Поле errors сохраняется для совместимости со схемой")
+}
+
+func TestValidation_Username(t *testing.T) {
+	code, errResponse := performRawRequest(t, "POST", "/api/auth", "",
+		`{"username":"`+strings.Repeat("a", 65)+`","password":"testpassword"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+	if assert.Len(t, errResponse.Details, 1) {
+		assert.Equal(t, "username", errResponse.Details[0].Field)
+		assert.Equal(t, fieldTooLong, errResponse.Details[0].Code)
+	}
+
+	code, errResponse = performRawRequest(t, "POST", "/api/auth", "",
+		`{"username":"robert'); DROP TABLE users;--","password":"testpassword"}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+	if assert.Len(t, errResponse.Details, 1) {
+		assert.Equal(t, fieldInvalidFormat, errResponse.Details[0].Code)
+	}
+}
+
+func TestValidation_RequiredFields(t *testing.T) {
+	code, errResponse := performRawRequest(t, "POST", "/api/sendCoin", signTestToken(t, 1), `{"amount":10}`)
+
+	assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400")
+	assert.Equal(t, []oas.ErrorDetail{{Field: "toUser", Code: fieldRequired, Message: "field is required"}}, errResponse.Details)
+}
+
+func TestValidation_AmountBounds(t *testing.T) {
+	token := signTestToken(t, 1)
+
+	for _, amount := range []string{"0", "-5", "1000001"} {
+		code, errResponse := performRawRequest(t, "POST", "/api/sendCoin", token, `{"toUser":"user2","amount":`+amount+`}`)
+
+		assert.Equal(t, http.StatusBadRequest, code, "Ожидался код 400 для суммы %s", amount)
+		if assert.Len(t, errResponse.Details, 1) {
+			assert.Equal(t, "amount", errResponse.Details[0].Field)
+			assert.Equal(t, fieldOutOfRange, errResponse.Details[0].Code)
+		}
+	}
+}
+
+func TestValidation_BodyTooLarge(t *testing.T) {
+	code, errResponse := performRawRequest(t, "POST", "/api/auth", "",
+		`{"username":"testuser","password":"`+strings.Repeat("a", 10<<20)+`"}`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code, "Ожидался код 413")
+	assert.Equal(t, codePayloadTooLarge, errResponse.Code.Value)
+}
+
+// Подробности ошибок строятся по типам ошибок ogen; тест ловит обновление
+// генератора, после которого типы перестанут совпадать
+func TestValidation_OgenErrorTypes(t *testing.T) {
+	var captured error
+	server, err := oas.NewServer(apiHandler{}, securityHandler{},
+		oas.WithErrorHandler(func(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+			captured = err
+		}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	serve := func(body string) error {
+		captured = nil
+		req := httptest.NewRequest("POST", "/api/auth", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		server.ServeHTTP(httptest.NewRecorder(), req)
+		return captured
+	}
+
+	var decodeErr *ogenerrors.DecodeRequestError
+	var bodyErr *ogenerrors.DecodeBodyError
+	err = serve(`{"username":"testuser","password":"testpassword","admin":true}`)
+	if assert.True(t, errors.As(err, &decodeErr), "Ожидалась DecodeRequestError: %v", err) {
+		assert.Equal(t, string(oas.APIAuthPostOperation), decodeErr.OperationName())
+	}
+	if assert.True(t, errors.As(err, &bodyErr), "Лишнее поле должно приходить как DecodeBodyError: %v", err) {
+		assert.JSONEq(t, `{"username":"testuser","password":"testpassword","admin":true}`, string(bodyErr.Body))
+	}
+
+	var validateErr *validate.Error
+	var maxLen *validate.MaxLengthError
+	err = serve(`{"username":"` + strings.Repeat("a", 65) + `","password":"testpassword"}`)
+	if assert.True(t, errors.As(err, &validateErr), "Ожидалась validate.Error: %v", err) && assert.Len(t, validateErr.Fields, 1) {
+		assert.Equal(t, "username", validateErr.Fields[0].Name)
+		assert.True(t, errors.As(validateErr.Fields[0].Error, &maxLen), "Ожидалась MaxLengthError: %v", validateErr.Fields[0].Error)
+	}
+
+	// Границы чисел ogen не типизирует: код out_of_range берётся из схемы
+	assert.NotEmpty(t, requestSchema(string(oas.APISendCoinPostOperation)).field("amount").rangeMessage())
+	assert.Equal(t, fieldUnknown, unknownFields(requestSchema(string(oas.APIAuthPostOperation)), []byte(`{"admin":true}`))[0].Code)
+}